@@ -0,0 +1,229 @@
+package canvas
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WithEnrollments is an Option for account course listings that will
+// only list courses with enrollments when true and only courses without
+// enrollments when false.
+func WithEnrollments(b bool) Option {
+	return Opt("with_enrollments", b)
+}
+
+// Users will list the users in the account. If search is not empty
+// then only users whose name, login id, email, or sis id match it
+// are listed; canvas needs at least two characters to search.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.api_index
+func (a *Account) Users(search string, opts ...Option) ([]*User, error) {
+	if search != "" {
+		opts = append(opts, Opt("search_term", search))
+	}
+	return collectUsers(a.cli, a.path("/users"), opts)
+}
+
+// SubAccounts will list the account's sub-accounts. When recursive is
+// true the sub-accounts of every sub-account are listed as well.
+//
+// https://canvas.instructure.com/doc/api/accounts.html#method.accounts.sub_accounts
+func (a *Account) SubAccounts(recursive bool, opts ...Option) (accounts []Account, err error) {
+	if recursive {
+		opts = append(opts, Opt("recursive", true))
+	}
+	ch := make(chan Account)
+	errs := newPaginatedList(a.cli, a.path("/sub_accounts"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			acct := Account{cli: a.cli}
+			if err := dec.Decode(&acct); err != nil {
+				return err
+			}
+			ch <- acct
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case acct := <-ch:
+			accounts = append(accounts, acct)
+		case err, ok := <-errs:
+			if !ok {
+				return accounts, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// CreateCourse will create a new course in the account. Other course
+// settings can be given as options like Opt("course[course_code]", "CS 101").
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.create
+func (a *Account) CreateCourse(name string, opts ...Option) (*Course, error) {
+	q := params{"course[name]": {name}}
+	q.Add(opts)
+	resp, err := post(a.cli, a.path("/courses"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	c := &Course{client: a.cli, errorHandler: ConcurrentErrorHandler}
+	return c, decodeJSON(resp.Body, c)
+}
+
+// CreateUser will create a new user in the account with a login. Other
+// settings can be given as options like Opt("pseudonym[sis_user_id]", "123")
+// or Opt("pseudonym[send_confirmation]", true).
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.create
+func (a *Account) CreateUser(name, loginID string, opts ...Option) (*User, error) {
+	q := params{
+		"user[name]":           {name},
+		"pseudonym[unique_id]": {loginID},
+	}
+	q.Add(opts)
+	resp, err := post(a.cli, a.path("/users"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	u := &User{client: a.cli}
+	return u, decodeJSON(resp.Body, u)
+}
+
+// Admin is a user that has an admin role in an account.
+type Admin struct {
+	ID            int    `json:"id"`
+	Role          string `json:"role"`
+	RoleID        int    `json:"role_id"`
+	WorkflowState string `json:"workflow_state"`
+	User          *User  `json:"user"`
+}
+
+// Admins will list the account's admins. Use
+// ArrayOpt("user_id", ids...) to only list some users.
+//
+// https://canvas.instructure.com/doc/api/admins.html#method.admins.index
+func (a *Account) Admins(opts ...Option) (admins []*Admin, err error) {
+	ch := make(chan *Admin)
+	errs := newPaginatedList(a.cli, a.path("/admins"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			admin := &Admin{}
+			if err := dec.Decode(admin); err != nil {
+				return err
+			}
+			if admin.User != nil {
+				admin.User.client = a.cli
+			}
+			ch <- admin
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case admin := <-ch:
+			admins = append(admins, admin)
+		case err, ok := <-errs:
+			if !ok {
+				return admins, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// AddAdmin will make a user an admin of the account. The role
+// defaults to "AccountAdmin" when it is empty.
+//
+// https://canvas.instructure.com/doc/api/admins.html#method.admins.create
+func (a *Account) AddAdmin(userID int, role string) (*Admin, error) {
+	q := params{"user_id": {strconv.Itoa(userID)}}
+	if role != "" {
+		q.Set("role", role)
+	}
+	resp, err := post(a.cli, a.path("/admins"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	admin := &Admin{}
+	return admin, decodeJSON(resp.Body, admin)
+}
+
+func (a *Account) path(s string) string {
+	return fmt.Sprintf("/accounts/%d%s", a.ID, s)
+}
+
+// UserLookup is the result of looking up one user.
+type UserLookup struct {
+	User *User
+	// Err is any error from looking up the user.
+	Err error
+}
+
+// UsersByLoginIDs will look up the users with the given login ids
+// concurrently. A lookup is returned for every id, keyed by the id,
+// along with an error that joins the errors of every lookup that
+// failed. Users are looked up in the account's root account.
+//
+// https://canvas.instructure.com/doc/api/file.object_ids.html
+func (a *Account) UsersByLoginIDs(ids []string) (map[string]*UserLookup, error) {
+	return a.usersBy("sis_login_id", ids)
+}
+
+// UsersBySISIDs will look up the users with the given sis user ids
+// concurrently. See UsersByLoginIDs.
+//
+// https://canvas.instructure.com/doc/api/file.object_ids.html
+func (a *Account) UsersBySISIDs(ids []string) (map[string]*UserLookup, error) {
+	return a.usersBy("sis_user_id", ids)
+}
+
+func (a *Account) usersBy(kind string, ids []string) (map[string]*UserLookup, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errl    []error
+		lookups = make(map[string]*UserLookup, len(ids))
+		sem     = make(chan struct{}, bulkWorkers)
+	)
+	for _, id := range ids {
+		if _, ok := lookups[id]; ok {
+			continue
+		}
+		res := &UserLookup{}
+		lookups[id] = res
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer func() { <-sem; wg.Done() }()
+			res.User, res.Err = getUser(a.cli, sisRef(kind, id), nil)
+			if res.Err != nil {
+				mu.Lock()
+				errl = append(errl, fmt.Errorf("%s %q: %w", kind, id, res.Err))
+				mu.Unlock()
+			}
+		}(id)
+	}
+	wg.Wait()
+	sort.Slice(errl, func(i, j int) bool { return errl[i].Error() < errl[j].Error() })
+	return lookups, joinErrs(errl)
+}
+
+// sisRef returns an id that can be used in place of a canvas id in api
+// paths. Ids with characters that canvas cannot take in a path are
+// hex encoded.
+func sisRef(kind, id string) string {
+	if strings.ContainsAny(id, "./%?#") {
+		return fmt.Sprintf("%s:hex:%s", kind, hex.EncodeToString([]byte(id)))
+	}
+	return kind + ":" + id
+}
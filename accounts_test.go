@@ -0,0 +1,127 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAccountAdmin(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	a := &Account{ID: 1, cli: client}
+	link := func(w http.ResponseWriter, path string) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1%s?page=1>; rel="last"`, DefaultHost, path))
+	}
+	mux.HandleFunc("/api/v1/accounts/1/courses", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			if q.Get("state[]") != "available" || q.Get("with_enrollments") != "true" {
+				t.Errorf("wrong filters: %v", q)
+			}
+			link(w, r.URL.Path)
+			fmt.Fprint(w, `[{"id":5,"name":"CS 101"}]`)
+		case "POST":
+			if q.Get("course[name]") != "CS 102" || q.Get("course[course_code]") != "cs102" {
+				t.Errorf("wrong course: %v", q)
+			}
+			fmt.Fprint(w, `{"id":6,"name":"CS 102","course_code":"cs102"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/accounts/1/users", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			is.Equal(q.Get("search_term"), "jo")
+			link(w, r.URL.Path)
+			fmt.Fprint(w, `[{"id":2,"name":"Jo"}]`)
+		case "POST":
+			if q.Get("user[name]") != "Sam" || q.Get("pseudonym[unique_id]") != "sam@school.edu" {
+				t.Errorf("wrong user: %v", q)
+			}
+			fmt.Fprint(w, `{"id":3,"name":"Sam"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/accounts/1/sub_accounts", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query().Get("recursive"), "true")
+		link(w, r.URL.Path)
+		fmt.Fprint(w, `[{"id":7,"name":"Engineering","parent_account_id":1},{"id":8,"name":"CS","parent_account_id":7}]`)
+	})
+	mux.HandleFunc("/api/v1/accounts/1/admins", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			link(w, r.URL.Path)
+			fmt.Fprint(w, `[{"id":4,"role":"AccountAdmin","user":{"id":2,"name":"Jo"}}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("user_id") != "3" || q.Get("role") != "" {
+				t.Errorf("wrong admin: %v", q)
+			}
+			fmt.Fprint(w, `{"id":9,"role":"AccountAdmin","user":{"id":3}}`)
+		}
+	})
+
+	courses, err := a.Courses(CourseStateFilter(WorkflowAvailable), WithEnrollments(true))
+	is.NoErr(err)
+	is.Equal(len(courses), 1)
+	course, err := a.CreateCourse("CS 102", Opt("course[course_code]", "cs102"))
+	is.NoErr(err)
+	is.Equal(course.ID, 6)
+	is.True(course.client != nil)
+
+	users, err := a.Users("jo")
+	is.NoErr(err)
+	is.Equal(users[0].Name, "Jo")
+	user, err := a.CreateUser("Sam", "sam@school.edu")
+	is.NoErr(err)
+	is.Equal(user.ID, 3)
+
+	subs, err := a.SubAccounts(true)
+	is.NoErr(err)
+	is.Equal(len(subs), 2)
+	is.Equal(subs[1].ParentAccountID, 7)
+
+	admins, err := a.Admins()
+	is.NoErr(err)
+	is.Equal(admins[0].User.ID, 2)
+	admin, err := a.AddAdmin(3, "")
+	is.NoErr(err)
+	is.Equal(admin.ID, 9)
+}
+
+func TestUsersByIDs(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	a := &Account{ID: 1, cli: client}
+	mux.HandleFunc("/api/v1/users/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/users/sis_login_id:jo":
+			fmt.Fprint(w, `{"id":2,"login_id":"jo"}`)
+		case "/api/v1/users/sis_login_id:hex:6a6f2e736d697468": // jo.smith
+			fmt.Fprint(w, `{"id":3,"login_id":"jo.smith"}`)
+		case "/api/v1/users/sis_user_id:s100":
+			fmt.Fprint(w, `{"id":4,"sis_user_id":"s100"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"message":"The specified resource does not exist."}]}`)
+		}
+	})
+
+	users, err := a.UsersByLoginIDs([]string{"jo", "jo.smith", "missing", "jo"})
+	is.True(err != nil)
+	is.Equal(len(users), 3)
+	is.NoErr(users["jo"].Err)
+	is.Equal(users["jo"].User.ID, 2)
+	is.Equal(users["jo.smith"].User.ID, 3)
+	is.True(users["missing"].Err != nil)
+	is.True(users["missing"].User == nil)
+
+	users, err = a.UsersBySISIDs([]string{"s100"})
+	is.NoErr(err)
+	is.Equal(users["s100"].User.ID, 4)
+}
@@ -0,0 +1,115 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// AccountStatistics are the totals for an account
+// during one term.
+type AccountStatistics struct {
+	Courses          int `json:"courses"`
+	Subaccounts      int `json:"subaccounts"`
+	Teachers         int `json:"teachers"`
+	Students         int `json:"students"`
+	DiscussionTopics int `json:"discussion_topics"`
+	MediaObjects     int `json:"media_objects"`
+	Attachments      int `json:"attachments"`
+	Assignments      int `json:"assignments"`
+}
+
+// Statistics will get the account's totals for the current term.
+//
+// https://canvas.instructure.com/doc/api/analytics.html#method.analytics_api.department_statistics
+func (a *Account) Statistics(opts ...Option) (stats *AccountStatistics, err error) {
+	stats = &AccountStatistics{}
+	return stats, getjson(a.cli, stats, optEnc(opts), "/accounts/%d/analytics/current/statistics", a.ID)
+}
+
+// TermStatistics will get the account's totals for a term.
+func (a *Account) TermStatistics(termID int, opts ...Option) (stats *AccountStatistics, err error) {
+	stats = &AccountStatistics{}
+	return stats, getjson(a.cli, stats, optEnc(opts), "/accounts/%d/analytics/terms/%d/statistics", a.ID, termID)
+}
+
+// CourseCounts will count the account's courses in each term. The keys
+// of the map returned are enrollment term IDs. Options are passed to
+// the account courses endpoint so they can be used to filter the
+// courses that are counted.
+//
+//	// count published courses
+//	counts, err := account.CourseCounts(canvas.Opt("published", true))
+func (a *Account) CourseCounts(opts ...Option) (map[int]int, error) {
+	var (
+		mu     sync.Mutex
+		counts = make(map[int]int)
+	)
+	err := countPages(a.cli, fmt.Sprintf("/accounts/%d/courses", a.ID), func(dec decoder) error {
+		var c struct {
+			TermID int `json:"enrollment_term_id"`
+		}
+		if err := dec.Decode(&c); err != nil {
+			return err
+		}
+		mu.Lock()
+		counts[c.TermID]++
+		mu.Unlock()
+		return nil
+	}, opts)
+	return counts, err
+}
+
+// UserCounts is the number of users in an account.
+type UserCounts struct {
+	Total int
+	// LoggedInSince is the number of users that have
+	// logged in after the time given to Account.UserCounts.
+	LoggedInSince int
+	// NeverLoggedIn is the number of users that
+	// have never logged in.
+	NeverLoggedIn int
+}
+
+// UserCounts will count the account's users and how many of them
+// have logged in since the time given.
+func (a *Account) UserCounts(since time.Time, opts ...Option) (*UserCounts, error) {
+	var (
+		mu     sync.Mutex
+		counts = &UserCounts{}
+	)
+	opts = append([]Option{IncludeOpt("last_login")}, opts...)
+	err := countPages(a.cli, fmt.Sprintf("/accounts/%d/users", a.ID), func(dec decoder) error {
+		var u struct {
+			LastLogin time.Time `json:"last_login"`
+		}
+		if err := dec.Decode(&u); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		counts.Total++
+		if u.LastLogin.IsZero() {
+			counts.NeverLoggedIn++
+		} else if u.LastLogin.After(since) {
+			counts.LoggedInSince++
+		}
+		return nil
+	}, opts)
+	return counts, err
+}
+
+// countPages calls fn for every item of a paginated listing. Pages
+// are handled concurrently so fn must be safe to call from more than
+// one goroutine.
+func countPages(d doer, path string, fn func(decoder) error, opts []Option) error {
+	errs := newPaginatedList(d, path, func(r io.Reader) error {
+		return streamArray(r, fn)
+	}, opts).start()
+	var errl []error
+	for err := range errs {
+		errl = append(errl, err)
+	}
+	return joinErrs(errl)
+}
@@ -0,0 +1,53 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestAccountCounts(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	link := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=2>; rel="last"`, DefaultHost, r.URL.Path))
+	}
+	mux.HandleFunc("/api/v1/accounts/1/analytics/current/statistics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"courses":27,"teachers":36,"students":418}`)
+	})
+	mux.HandleFunc("/api/v1/accounts/1/courses", func(w http.ResponseWriter, r *http.Request) {
+		link(w, r)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id":3,"enrollment_term_id":2}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"enrollment_term_id":1},{"id":2,"enrollment_term_id":2}]`)
+	})
+	mux.HandleFunc("/api/v1/accounts/1/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include[]") != "last_login" {
+			t.Error("should include last_login")
+		}
+		link(w, r)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id":3,"last_login":null}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"last_login":"2020-09-10T00:00:00Z"},{"id":2,"last_login":"2020-08-01T00:00:00Z"}]`)
+	})
+	a := &Account{ID: 1, cli: client}
+	stats, err := a.Statistics()
+	is.NoErr(err)
+	is.Equal(stats.Students, 418)
+
+	counts, err := a.CourseCounts()
+	is.NoErr(err)
+	is.Equal(counts, map[int]int{1: 1, 2: 2})
+
+	users, err := a.UserCounts(time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.Equal(*users, UserCounts{Total: 3, LoggedInSince: 1, NeverLoggedIn: 1})
+}
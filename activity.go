@@ -0,0 +1,124 @@
+package canvas
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivityReport is how engaged each student in a course has been.
+type ActivityReport struct {
+	Students []StudentActivity
+}
+
+// StudentActivity is one student's activity in a course.
+type StudentActivity struct {
+	UserID       int
+	Name         string
+	SortableName string
+	SisUserID    string
+	SectionIDs   []int
+	// LastActivityAt is zero if the student has never
+	// been active in the course.
+	LastActivityAt    time.Time
+	TotalActivityTime time.Duration
+}
+
+// ActivityReport will build a report of when each student was last
+// active in the course and how much time they have spent in it. The
+// options are used to list the course's student enrollments, e.g.
+// EnrollmentStateFilter(EnrollmentActive). Students are sorted by
+// their sortable name.
+//
+// Students enrolled in more than one section are only reported once
+// with their latest activity.
+func (c *Course) ActivityReport(opts ...Option) (*ActivityReport, error) {
+	enrollments, err := c.ListEnrollments(
+		append([]Option{ArrayOpt("type", string(StudentEnrollment))}, opts...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[int]*StudentActivity)
+	for _, e := range enrollments {
+		sa, ok := byUser[e.UserID]
+		if !ok {
+			sa = &StudentActivity{UserID: e.UserID, SisUserID: e.SisUserID}
+			if e.User != nil {
+				sa.Name = e.User.Name
+				sa.SortableName = e.User.SortableName
+			}
+			byUser[e.UserID] = sa
+		}
+		sa.SectionIDs = append(sa.SectionIDs, e.CourseSectionID)
+		if e.LastActivityAt.After(sa.LastActivityAt) {
+			sa.LastActivityAt = e.LastActivityAt
+		}
+		if t := time.Duration(e.TotalActivityTime) * time.Second; t > sa.TotalActivityTime {
+			sa.TotalActivityTime = t
+		}
+	}
+	report := &ActivityReport{Students: make([]StudentActivity, 0, len(byUser))}
+	for _, sa := range byUser {
+		report.Students = append(report.Students, *sa)
+	}
+	sort.Slice(report.Students, func(i, j int) bool {
+		a, b := report.Students[i], report.Students[j]
+		if a.SortableName != b.SortableName {
+			return a.SortableName < b.SortableName
+		}
+		return a.UserID < b.UserID
+	})
+	return report, nil
+}
+
+// Inactive returns the students that have not been
+// active in the course since the time given.
+func (ar *ActivityReport) Inactive(since time.Time) []StudentActivity {
+	var inactive []StudentActivity
+	for _, s := range ar.Students {
+		if s.LastActivityAt.Before(since) {
+			inactive = append(inactive, s)
+		}
+	}
+	return inactive
+}
+
+// WriteCSV will write the report as a csv file. Times are written
+// in RFC3339 format and the total activity time is in seconds.
+func (ar *ActivityReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{
+		"User ID", "Name", "SIS User ID", "Section IDs",
+		"Last Activity", "Total Activity Time",
+	})
+	if err != nil {
+		return err
+	}
+	for _, s := range ar.Students {
+		sections := make([]string, len(s.SectionIDs))
+		for i, id := range s.SectionIDs {
+			sections[i] = strconv.Itoa(id)
+		}
+		var last string
+		if !s.LastActivityAt.IsZero() {
+			last = s.LastActivityAt.Format(time.RFC3339)
+		}
+		err = cw.Write([]string{
+			strconv.Itoa(s.UserID),
+			s.Name,
+			s.SisUserID,
+			strings.Join(sections, ";"),
+			last,
+			strconv.FormatInt(int64(s.TotalActivityTime/time.Second), 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,50 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestActivityReport(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type[]") != "StudentEnrollment" {
+			t.Error("should only list students")
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[
+			{"user_id":7,"course_section_id":4,"last_activity_at":"2020-09-01T10:00:00Z","total_activity_time":600,
+			 "user":{"id":7,"name":"Zoe Adams","sortable_name":"Adams, Zoe"}},
+			{"user_id":8,"course_section_id":4,"last_activity_at":null,"total_activity_time":0,
+			 "user":{"id":8,"name":"Al Brown","sortable_name":"Brown, Al"}},
+			{"user_id":7,"course_section_id":5,"last_activity_at":"2020-09-03T10:00:00Z","total_activity_time":300,
+			 "user":{"id":7,"name":"Zoe Adams","sortable_name":"Adams, Zoe"}}
+		]`)
+	})
+	c := &Course{ID: 1, client: client}
+	report, err := c.ActivityReport()
+	is.NoErr(err)
+	is.Equal(len(report.Students), 2)
+	zoe := report.Students[0]
+	is.Equal(zoe.Name, "Zoe Adams")
+	is.Equal(zoe.SectionIDs, []int{4, 5})
+	is.Equal(zoe.LastActivityAt, time.Date(2020, 9, 3, 10, 0, 0, 0, time.UTC))
+	is.Equal(zoe.TotalActivityTime, 10*time.Minute)
+
+	inactive := report.Inactive(time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC))
+	is.Equal(len(inactive), 1)
+	is.Equal(inactive[0].UserID, 8)
+
+	var b strings.Builder
+	is.NoErr(report.WriteCSV(&b))
+	is.Equal(b.String(), "User ID,Name,SIS User ID,Section IDs,Last Activity,Total Activity Time\n"+
+		"7,Zoe Adams,,4;5,2020-09-03T10:00:00Z,600\n"+
+		"8,Al Brown,,4,,0\n")
+}
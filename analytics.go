@@ -0,0 +1,208 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ActivityPoint is the number of page views and
+// participations in a course on one day.
+type ActivityPoint struct {
+	Date           time.Time `json:"-"`
+	Views          int       `json:"views"`
+	Participations int       `json:"participations"`
+}
+
+// UnmarshalJSON decodes the activity point. Canvas
+// sends the date without a time.
+func (ap *ActivityPoint) UnmarshalJSON(b []byte) error {
+	type point ActivityPoint
+	var raw struct {
+		*point
+		Date string `json:"date"`
+	}
+	raw.point = (*point)(ap)
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	date, err := parseAnalyticsDate(raw.Date)
+	ap.Date = date
+	return err
+}
+
+// TardinessBreakdown counts submissions by when they were turned in.
+// The counts are fractions of the total for course assignments.
+type TardinessBreakdown struct {
+	Total    float64 `json:"total"`
+	OnTime   float64 `json:"on_time"`
+	Late     float64 `json:"late"`
+	Missing  float64 `json:"missing"`
+	Floating float64 `json:"floating"`
+}
+
+// AssignmentAnalytics are the score statistics and
+// submission timing for an assignment in a course.
+type AssignmentAnalytics struct {
+	AssignmentID   int       `json:"assignment_id"`
+	Title          string    `json:"title"`
+	PointsPossible float64   `json:"points_possible"`
+	DueAt          time.Time `json:"due_at"`
+	UnlockAt       time.Time `json:"unlock_at"`
+	Muted          bool      `json:"muted"`
+	// The scores are nil when there are no graded submissions.
+	MinScore      *float64           `json:"min_score"`
+	MaxScore      *float64           `json:"max_score"`
+	Median        *float64           `json:"median"`
+	FirstQuartile *float64           `json:"first_quartile"`
+	ThirdQuartile *float64           `json:"third_quartile"`
+	Tardiness     TardinessBreakdown `json:"tardiness_breakdown"`
+}
+
+// StudentSummary sums up a student's activity in a course.
+type StudentSummary struct {
+	ID                  int                `json:"id"`
+	PageViews           int                `json:"page_views"`
+	PageViewsLevel      int                `json:"page_views_level"`
+	MaxPageViews        int                `json:"max_page_views"`
+	Participations      int                `json:"participations"`
+	ParticipationsLevel int                `json:"participations_level"`
+	MaxParticipations   int                `json:"max_participations"`
+	Tardiness           TardinessBreakdown `json:"tardiness_breakdown"`
+}
+
+// UserActivity is a student's page views by the hour and
+// their participations in a course.
+type UserActivity struct {
+	PageViews      map[time.Time]int
+	Participations []Participation
+}
+
+// Participation is one time that a student took part in a course.
+type Participation struct {
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
+}
+
+// UserAssignmentData is a student's submission for an assignment along
+// with the score statistics of the assignment.
+type UserAssignmentData struct {
+	AssignmentID   int       `json:"assignment_id"`
+	Title          string    `json:"title"`
+	PointsPossible float64   `json:"points_possible"`
+	DueAt          time.Time `json:"due_at"`
+	UnlockAt       time.Time `json:"unlock_at"`
+	Status         string    `json:"status"`
+	Excused        bool      `json:"excused"`
+	MinScore       *float64  `json:"min_score"`
+	MaxScore       *float64  `json:"max_score"`
+	Median         *float64  `json:"median"`
+	FirstQuartile  *float64  `json:"first_quartile"`
+	ThirdQuartile  *float64  `json:"third_quartile"`
+	ModuleIDs      []int     `json:"module_ids"`
+	Submission     struct {
+		Score       *float64  `json:"score"`
+		SubmittedAt time.Time `json:"submitted_at"`
+		PostedAt    time.Time `json:"posted_at"`
+	} `json:"submission"`
+}
+
+// ActivityAnalytics will get the course's page views and
+// participations for every day the course has had activity.
+//
+// https://canvas.instructure.com/doc/api/analytics.html#method.analytics_api.course_participation
+func (c *Course) ActivityAnalytics() ([]ActivityPoint, error) {
+	points := make([]ActivityPoint, 0)
+	return points, getjson(c.client, &points, nil, "/courses/%d/analytics/activity", c.ID)
+}
+
+// AssignmentAnalytics will get the score statistics and submission
+// timing of every assignment in the course.
+//
+// https://canvas.instructure.com/doc/api/analytics.html#method.analytics_api.course_assignments
+func (c *Course) AssignmentAnalytics(opts ...Option) ([]*AssignmentAnalytics, error) {
+	return assignmentAnalytics(c.client, c.ID, opts)
+}
+
+func assignmentAnalytics(d doer, courseID int, opts []Option) ([]*AssignmentAnalytics, error) {
+	assignments := make([]*AssignmentAnalytics, 0)
+	return assignments, getjson(d, &assignments, optEnc(opts), "/courses/%d/analytics/assignments", courseID)
+}
+
+// StudentSummaries will list the activity summaries of every student
+// in the course. Options like Opt("sort_column", "participations_descending")
+// or Opt("student_id", id) can be given.
+//
+// https://canvas.instructure.com/doc/api/analytics.html#method.analytics_api.course_student_summaries
+func (c *Course) StudentSummaries(opts ...Option) (sums []*StudentSummary, err error) {
+	ch := make(chan *StudentSummary)
+	errs := newPaginatedList(c.client, c.id("/courses/%d/analytics/student_summaries"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			s := &StudentSummary{}
+			if err := dec.Decode(s); err != nil {
+				return err
+			}
+			ch <- s
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case s := <-ch:
+			sums = append(sums, s)
+		case err, ok := <-errs:
+			if !ok {
+				return sums, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// UserActivity will get a student's page views and participations.
+//
+// https://canvas.instructure.com/doc/api/analytics.html#method.analytics_api.student_in_course_participation
+func (c *Course) UserActivity(userID int) (*UserActivity, error) {
+	var res struct {
+		PageViews      map[string]int  `json:"page_views"`
+		Participations []Participation `json:"participations"`
+	}
+	err := getjson(c.client, &res, nil, "/courses/%d/analytics/users/%d/activity", c.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	act := &UserActivity{
+		PageViews:      make(map[time.Time]int, len(res.PageViews)),
+		Participations: res.Participations,
+	}
+	for hour, views := range res.PageViews {
+		t, err := parseAnalyticsDate(hour)
+		if err != nil {
+			return nil, err
+		}
+		act.PageViews[t] = views
+	}
+	return act, nil
+}
+
+// UserAssignmentData will get a student's submissions for
+// every assignment in the course.
+//
+// https://canvas.instructure.com/doc/api/analytics.html#method.analytics_api.student_in_course_assignments
+func (c *Course) UserAssignmentData(userID int) ([]*UserAssignmentData, error) {
+	data := make([]*UserAssignmentData, 0)
+	return data, getjson(c.client, &data, nil, "/courses/%d/analytics/users/%d/assignments", c.ID, userID)
+}
+
+// parseAnalyticsDate parses the dates used by the analytics
+// api, which are sometimes only a day.
+func parseAnalyticsDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("could not parse analytics date %q", s)
+}
@@ -0,0 +1,68 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCourseAnalytics(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/analytics/activity", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"date":"2026-01-24","participations":3,"views":10}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/analytics/assignments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"assignment_id":4,"title":"hw","points_possible":10,"min_score":2,"max_score":10,"median":7,
+			"first_quartile":5,"third_quartile":9,"tardiness_breakdown":{"total":1,"on_time":0.75,"late":0.25}},
+			{"assignment_id":5,"title":"quiz","min_score":null}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/analytics/student_summaries", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query().Get("sort_column"), "participations_descending")
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/analytics/student_summaries?page=2>; rel="last"`, DefaultHost))
+		fmt.Fprintf(w, `[{"id":%s,"page_views":40,"participations":3,"tardiness_breakdown":{"total":5,"missing":1}}]`, r.URL.Query().Get("page"))
+	})
+	mux.HandleFunc("/api/v1/courses/1/analytics/users/7/activity", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"page_views":{"2026-01-24T13:00:00Z":19},
+			"participations":[{"created_at":"2026-01-24T13:05:00Z","url":"https://canvas.test/courses/1/discussion_topics/2"}]}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/analytics/users/7/assignments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"assignment_id":4,"title":"hw","status":"on_time","submission":{"score":8,"submitted_at":"2026-01-20T10:00:00Z"}}]`)
+	})
+	c := &Course{ID: 1, client: client}
+
+	points, err := c.ActivityAnalytics()
+	is.NoErr(err)
+	is.Equal(points[0].Views, 10)
+	is.True(points[0].Date.Equal(time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC)))
+
+	asses, err := c.AssignmentAnalytics()
+	is.NoErr(err)
+	is.Equal(len(asses), 2)
+	is.Equal(*asses[0].Median, 7.0)
+	is.Equal(asses[0].Tardiness.Late, 0.25)
+	is.True(asses[1].MinScore == nil)
+	stats, err := analyticsScoreStatistics(client, 1, 4)
+	is.NoErr(err)
+	is.Equal(stats.UpperQ, 9.0)
+
+	sums, err := c.StudentSummaries(Opt("sort_column", "participations_descending"))
+	is.NoErr(err)
+	is.Equal(len(sums), 2)
+	is.Equal(sums[1].ID, 2)
+	is.Equal(sums[0].Tardiness.Missing, 1.0)
+
+	act, err := c.UserActivity(7)
+	is.NoErr(err)
+	is.Equal(act.PageViews[time.Date(2026, 1, 24, 13, 0, 0, 0, time.UTC)], 19)
+	is.Equal(len(act.Participations), 1)
+
+	data, err := c.UserAssignmentData(7)
+	is.NoErr(err)
+	is.Equal(data[0].Status, "on_time")
+	is.Equal(*data[0].Submission.Score, 8.0)
+}
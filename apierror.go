@@ -0,0 +1,95 @@
+package canvas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when canvas responds with an error status. It
+// wraps the decoded canvas error so errors.As can still be used to get
+// the *Error, *AuthError, or *RateLimitError.
+type APIError struct {
+	// StatusCode is the response status code and Status
+	// is the full status, e.g. "404 Not Found".
+	StatusCode int
+	Status     string
+	// Method and Endpoint are the request method
+	// and the path that was requested.
+	Method   string
+	Endpoint string
+	// RequestID is the X-Request-Context-Id header that canvas
+	// sends, it helps canvas admins find the request in their logs.
+	RequestID string
+	// Err is the error from the response body.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	msg := e.Status
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if e.Method == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s %s: %s", e.Method, e.Endpoint, msg)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound returns true if the error is
+// from canvas responding with 404 Not Found.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized returns true if the error is
+// from canvas responding with 401 Unauthorized.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+func hasStatus(err error, code int) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == code
+}
+
+// newAPIError decodes an error response.
+// The response body is closed.
+func newAPIError(resp *http.Response) error {
+	defer resp.Body.Close()
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RequestID:  resp.Header.Get("X-Request-Context-Id"),
+	}
+	if resp.Request != nil {
+		e.Method, e.Endpoint = resp.Request.Method, resp.Request.URL.Path
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusUnauthorized:
+		ae := &AuthError{}
+		if decodeJSON(resp.Body, ae) != nil || ae.Status == "" && len(ae.Errors) == 0 {
+			ae = &AuthError{Status: resp.Status}
+		}
+		e.Err = ae
+	case http.StatusForbidden:
+		if isThrottled(resp) {
+			e.Err = newRateLimitError(resp)
+			break
+		}
+		fallthrough
+	default:
+		ce := &Error{}
+		if decodeJSON(resp.Body, ce) != nil || *ce == (Error{}) {
+			// not a canvas error
+			ce = &Error{Message: resp.Status}
+		}
+		ce.Status = resp.Status
+		e.Err = ce
+	}
+	return e
+}
@@ -0,0 +1,66 @@
+package canvas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAPIError(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/404", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Context-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"message":"The specified resource does not exist."}]}`)
+	})
+	mux.HandleFunc("/api/v1/courses/401", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"status":"unauthenticated","errors":[{"message":"user authorization required"}]}`)
+	})
+	mux.HandleFunc("/api/v1/courses/500", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `<html>oops</html>`)
+	})
+	mux.HandleFunc("/api/v1/courses/422", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"name is too long"}`)
+	})
+
+	err := getjson(client, &Course{}, nil, "/courses/404")
+	var e *APIError
+	is.True(errors.As(err, &e))
+	is.Equal(e.StatusCode, http.StatusNotFound)
+	is.Equal(e.Method, "GET")
+	is.Equal(e.Endpoint, "/api/v1/courses/404")
+	is.Equal(e.RequestID, "req-1")
+	is.Equal(err.Error(), "GET /api/v1/courses/404: The specified resource does not exist.")
+	var ae *AuthError
+	is.True(errors.As(err, &ae))
+	is.True(IsNotFound(err))
+	is.True(!IsUnauthorized(err))
+	is.True(!IsRateLimit(err))
+
+	_, err = put(client, "/courses/401", nil)
+	is.True(IsUnauthorized(err))
+	is.True(!IsNotFound(err))
+
+	_, err = get(client, "/courses/500", nil)
+	is.True(errors.As(err, &e))
+	is.Equal(e.StatusCode, http.StatusInternalServerError)
+	is.Equal(err.Error(), "GET /api/v1/courses/500: 500 Internal Server Error")
+	var ce *Error
+	is.True(errors.As(err, &ce))
+	is.Equal(ce.Status, "500 Internal Server Error")
+
+	_, err = post(client, "/courses/422", nil)
+	is.True(errors.As(err, &ce))
+	is.Equal(ce.Message, "name is too long")
+	is.Equal(err.Error(), "POST /api/v1/courses/422: name is too long")
+
+	is.True(!IsNotFound(errors.New("404")))
+}
@@ -0,0 +1,88 @@
+package canvas
+
+import (
+	"io"
+)
+
+// AssignmentGroup is a group of assignments in a course.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html
+type AssignmentGroup struct {
+	ID              int               `json:"id"`
+	Name            string            `json:"name"`
+	Position        int               `json:"position"`
+	GroupWeight     float64           `json:"group_weight"`
+	SisSourceID     string            `json:"sis_source_id"`
+	IntegrationData map[string]string `json:"integration_data"`
+	Rules           GradingRules      `json:"rules"`
+
+	// Assignments is only populated when the "assignments"
+	// include option is used.
+	Assignments []*Assignment `json:"assignments"`
+}
+
+// GradingRules are the rules for dropping grades
+// in an assignment group.
+type GradingRules struct {
+	// DropLowest is the number of the lowest scores to drop
+	DropLowest int `json:"drop_lowest"`
+	// DropHighest is the number of the highest scores to drop
+	DropHighest int `json:"drop_highest"`
+	// NeverDrop is a list of assignment ids that should never be dropped
+	NeverDrop []int `json:"never_drop"`
+}
+
+// AssignmentGroups will list the course's assignment groups. Use
+// IncludeOpt("assignments", "submission") to get the group's
+// assignments along with the current user's submissions.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups.index
+func (c *Course) AssignmentGroups(opts ...Option) ([]*AssignmentGroup, error) {
+	ch := make(chan *AssignmentGroup)
+	errs := newPaginatedList(
+		c.client, c.id("/courses/%d/assignment_groups"),
+		func(r io.Reader) error {
+			return streamArray(r, func(dec decoder) error {
+				g := &AssignmentGroup{}
+				if err := dec.Decode(g); err != nil {
+					return err
+				}
+				for _, a := range g.Assignments {
+					a.client = c.client
+					a.courseCode = c.CourseCode
+				}
+				ch <- g
+				return nil
+			})
+		}, opts,
+	).start()
+	groups := make([]*AssignmentGroup, 0)
+	var errl []error
+	for {
+		select {
+		case g := <-ch:
+			groups = append(groups, g)
+		case err, ok := <-errs:
+			if !ok {
+				return groups, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// AssignmentGroup will get an assignment group by id.
+//
+// https://canvas.instructure.com/doc/api/assignment_groups.html#method.assignment_groups_api.show
+func (c *Course) AssignmentGroup(id int, opts ...Option) (*AssignmentGroup, error) {
+	g := &AssignmentGroup{}
+	err := getjson(c.client, g, optEnc(opts), "/courses/%d/assignment_groups/%d", c.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range g.Assignments {
+		a.client = c.client
+		a.courseCode = c.CourseCode
+	}
+	return g, nil
+}
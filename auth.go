@@ -1,36 +1,49 @@
 package canvas
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
-
-	"github.com/harrybrwn/errs"
+	"time"
 )
 
 var (
-	// ErrRateLimitExceeded is returned when the api rate limit has been reached.
-	ErrRateLimitExceeded = errors.New("403 Forbidden (Rate Limit Exceeded)")
+	// ErrRateLimited is matched by the errors returned when canvas
+	// throttles a request. Use errors.As with a *RateLimitError to
+	// find out how long to wait before trying again.
+	ErrRateLimited = errors.New("403 Forbidden (Rate Limit Exceeded)")
+
+	// ErrRateLimitExceeded is the same as ErrRateLimited.
+	//
+	// Deprecated: use ErrRateLimited.
+	ErrRateLimitExceeded = ErrRateLimited
 
 	apiPath = "/api/v1"
 )
 
-// IsRateLimit returns true if the error
-// given is a rate limit error.
+// IsRateLimit returns true if the error given is a rate limit
+// error, including an *APIError from canvas throttling a request.
 func IsRateLimit(e error) bool {
-	if e == ErrRateLimitExceeded {
-		return true
-	}
-	return false
+	return errors.Is(e, ErrRateLimited)
 }
 
 type client struct {
 	http.Client
 	host string
+
+	retries      int
+	retryWait    time.Duration
+	maxRetryWait time.Duration
+
+	rate  *rateLimit
+	hooks Hooks
+	codec Codec
+	// concurrency is the max number of pages that a
+	// paginated listing fetches at once.
+	concurrency int
 }
 
 func (c *client) Do(r *http.Request) (*http.Response, error) {
@@ -38,7 +51,20 @@ func (c *client) Do(r *http.Request) (*http.Response, error) {
 		r.Host = c.host
 		r.URL.Host = c.host
 	}
-	return c.Client.Do(r)
+	resp, err := withTimeout(r, c.send)
+	if resp != nil && c.codec != nil {
+		resp.Body = &codecBody{ReadCloser: resp.Body, codec: c.codec}
+	}
+	return resp, err
+}
+
+func (c *client) send(r *http.Request) (*http.Response, error) {
+	if c.retries > 0 {
+		return c.doRetry(r)
+	}
+	resp, err := c.Client.Do(r)
+	c.observe(resp)
+	return resp, err
 }
 
 type doer interface {
@@ -50,24 +76,17 @@ func do(d doer, req *http.Request) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
+	return checkResponse(resp)
+}
 
-	var e error
+// checkResponse turns error responses into an *APIError. The
+// response body is closed when an error is returned.
+func checkResponse(resp *http.Response) (*http.Response, error) {
 	switch resp.StatusCode {
-	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
-		return resp, err
-	case http.StatusForbidden:
-		resp.Body.Close()
-		return nil, ErrRateLimitExceeded
-	case http.StatusUnprocessableEntity:
-		return nil, errs.Pair(resp.Body.Close(), errs.New(resp.Status))
-	case http.StatusNotFound, http.StatusUnauthorized:
-		e = &AuthError{}
-	case http.StatusBadRequest, http.StatusInternalServerError:
-		e = &Error{Status: resp.Status}
-	default:
-		e = &Error{Status: resp.Status}
-	}
-	return nil, errs.Chain(e, json.NewDecoder(resp.Body).Decode(&e), resp.Body.Close())
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent, http.StatusPartialContent:
+		return resp, nil
+	}
+	return nil, newAPIError(resp)
 }
 
 func get(c doer, endpoint string, vals encoder) (*http.Response, error) {
@@ -91,7 +110,20 @@ func newreq(method, urlpath string, query encoder) *http.Request {
 	if query != nil {
 		q = query.Encode()
 	}
-	return newV1Req(method, urlpath, q)
+	req := newV1Req(method, urlpath, q)
+	if opts, ok := query.(optEnc); ok {
+		req = applyRequestOptions(req, opts)
+	}
+	return req
+}
+
+func applyRequestOptions(req *http.Request, opts []Option) *http.Request {
+	for _, o := range opts {
+		if ro, ok := o.(*requestOption); ok {
+			req = ro.apply(req)
+		}
+	}
+	return req
 }
 
 func newV1Req(method, urlpath, query string) *http.Request {
@@ -118,7 +150,7 @@ func getjson(
 		return err
 	}
 	defer resp.Body.Close()
-	return json.NewDecoder(resp.Body).Decode(obj)
+	return decodeJSON(resp.Body, obj)
 }
 
 func authorize(c *http.Client, token, host string) {
@@ -194,6 +226,9 @@ type AuthError struct {
 }
 
 func (ae *AuthError) Error() string {
+	if len(ae.Errors) == 0 {
+		return ae.Status
+	}
 	if ae.Status == "" {
 		return checkErrors(ae.Errors)
 	}
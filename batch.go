@@ -0,0 +1,147 @@
+package canvas
+
+import (
+	"context"
+	"sync"
+)
+
+// Batch is a queue of GET requests for objects of the same type that
+// are sent concurrently. It is useful for fetching the details of many
+// objects at once, like every assignment of a list of courses.
+//
+//	b := canvas.NewBatch[*canvas.Assignment](c)
+//	for _, id := range ids {
+//		b.Get(fmt.Sprintf("/courses/%d/assignments/%d", courseID, id))
+//	}
+//	b.Progress = func(done, total int) { log.Printf("%d/%d", done, total) }
+//	results, err := b.Do(ctx)
+//
+// Requests are sent by four workers, or by the number given to
+// WithConcurrency, and the workers wait for the client's rate limit quota
+// the same way paginated listings do.
+type Batch[T any] struct {
+	// Workers is the number of requests sent at once. The
+	// client's default is used when it is zero.
+	Workers int
+	// Progress is called after each request finishes with the number
+	// of requests that are done. It is never called concurrently and
+	// can be nil.
+	Progress func(done, total int)
+
+	d     doer
+	items []batchItem
+}
+
+// BatchResult is the result of one request in a Batch.
+type BatchResult[T any] struct {
+	// Path is the path that was requested.
+	Path  string
+	Value T
+	// Err is any error from the request, the value is
+	// the zero value when it is set.
+	Err error
+}
+
+type batchItem struct {
+	path string
+	opts []Option
+}
+
+// NewBatch creates an empty batch of requests.
+func NewBatch[T any](c *Canvas) *Batch[T] {
+	return &Batch[T]{d: c.client}
+}
+
+// Get adds a request to the batch. The path is relative to the api
+// root and the options are sent with the request.
+func (b *Batch[T]) Get(path string, opts ...Option) {
+	b.items = append(b.items, batchItem{path: path, opts: opts})
+}
+
+// Len returns the number of requests in the batch.
+func (b *Batch[T]) Len() int {
+	return len(b.items)
+}
+
+// Do sends every request in the batch and returns the results in the
+// order the requests were added. A request failing does not stop the
+// others, each result has its own error and the returned error joins
+// the errors of every request that failed. Cancelling the context stops
+// the requests that have not been sent, their results have the
+// context's error.
+func (b *Batch[T]) Do(ctx context.Context) ([]*BatchResult[T], error) {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = defaultWorkers(b.d)
+	}
+	cli, _ := unwrapDoer(b.d).(*client)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		done    int
+		results = make([]*BatchResult[T], len(b.items))
+		sem     = make(chan struct{}, workers)
+	)
+	finish := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if b.Progress != nil {
+			b.Progress(done, len(results))
+		}
+	}
+	for i, item := range b.items {
+		res := &BatchResult[T]{Path: item.path}
+		results[i] = res
+		if res.Err = ctx.Err(); res.Err != nil {
+			finish()
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			res.Err = ctx.Err()
+			finish()
+			continue
+		}
+		wg.Add(1)
+		go func(item batchItem) {
+			defer func() { <-sem; wg.Done() }()
+			defer finish()
+			if cli != nil {
+				if res.Err = cli.waitForQuota(ctx, item.path, 0); res.Err != nil {
+					return
+				}
+			}
+			res.Value, res.Err = b.get(ctx, item)
+		}(item)
+	}
+	wg.Wait()
+	var errl []error
+	for _, res := range results {
+		if res.Err != nil && res.Err != ctx.Err() {
+			errl = append(errl, res.Err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		errl = append(errl, err)
+	}
+	return results, joinErrs(errl)
+}
+
+func (b *Batch[T]) get(ctx context.Context, item batchItem) (T, error) {
+	var v T
+	resp, err := do(b.d, newreq("GET", item.path, optEnc(item.opts)).WithContext(ctx))
+	if err != nil {
+		return v, err
+	}
+	defer resp.Body.Close()
+	if err = decodeJSON(resp.Body, &v); err != nil {
+		var zero T
+		return zero, err
+	}
+	if sc, ok := any(v).(interface{ setclient(doer) }); ok {
+		sc.setclient(b.d)
+	}
+	return v, nil
+}
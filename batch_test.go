@@ -0,0 +1,81 @@
+package canvas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestBatch(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	var inflight, most int32
+	mux.HandleFunc("/api/v1/courses/1/assignments/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		var id int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/api/v1/courses/1/assignments/"), "%d", &id)
+		if id == 3 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"message":"The specified resource does not exist."}]}`)
+			return
+		}
+		if r.URL.Query().Get("include[]") != "overrides" {
+			t.Error("options not sent")
+		}
+		fmt.Fprintf(w, `{"id":%d,"course_id":1,"name":"a%d"}`, id, id)
+	})
+	c := &Canvas{client: &client{Client: *httpClient, concurrency: 3}}
+
+	b := NewBatch[*Assignment](c)
+	for i := 1; i <= 8; i++ {
+		b.Get(fmt.Sprintf("/courses/1/assignments/%d", i), IncludeOpt("overrides"))
+	}
+	is.Equal(b.Len(), 8)
+	var progress []int
+	b.Progress = func(done, total int) {
+		is.Equal(total, 8)
+		progress = append(progress, done)
+	}
+	results, err := b.Do(context.Background())
+	is.True(err != nil)
+	is.True(IsNotFound(err))
+	is.Equal(len(results), 8)
+	for i, res := range results {
+		if i == 2 {
+			is.True(res.Err != nil)
+			is.True(res.Value == nil)
+			continue
+		}
+		is.NoErr(res.Err)
+		is.Equal(res.Value.ID, i+1)
+		is.Equal(res.Value.Name, fmt.Sprintf("a%d", i+1))
+		is.True(res.Value.client != nil)
+	}
+	is.Equal(results[4].Path, "/courses/1/assignments/5")
+	is.Equal(progress, []int{1, 2, 3, 4, 5, 6, 7, 8})
+	is.Equal(atomic.LoadInt32(&most), int32(3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = b.Do(ctx)
+	is.True(errors.Is(err, context.Canceled))
+	for _, res := range results {
+		is.True(errors.Is(res.Err, context.Canceled))
+	}
+}
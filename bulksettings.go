@@ -0,0 +1,124 @@
+package canvas
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// bulkWorkers is the default number of courses
+// updated at once by UpdateCoursesSettings.
+const bulkWorkers = 4
+
+// CourseSettingsUpdate is a change made to many
+// courses by Account.UpdateCoursesSettings.
+type CourseSettingsUpdate struct {
+	// Settings is called with a copy of each course's current settings
+	// and should change the ones that need updating. Courses are only
+	// updated if their settings were changed.
+	Settings func(*CourseSettings)
+	// Course is a list of course fields to update
+	// like Opt("course[default_view]", "modules").
+	Course []Option
+	// DryRun will report the changes that would be
+	// made without changing anything on canvas.
+	DryRun bool
+	// Workers is the number of courses updated at once.
+	Workers int
+}
+
+// CourseSettingsResult is the outcome of a bulk
+// settings update for one course.
+type CourseSettingsResult struct {
+	Course *Course
+	// Before are the course's settings before the update and After are
+	// the settings that were sent, or would have been sent for a dry run.
+	// Both are nil when the update has no Settings function.
+	Before, After *CourseSettings
+	// Changed is true when the settings were different after the update.
+	Changed bool
+	// Err is any error from updating the course.
+	Err error
+}
+
+// UpdateCoursesSettings will update the settings of every course in the
+// account that the filter matches. A nil filter matches every course and
+// opts are used to list the account's courses, e.g. narrowing them down
+// to one term with Opt("enrollment_term_id", id).
+//
+// Courses are updated concurrently and a course failing to update does
+// not stop the others. A result is returned for every matching course
+// along with an error that joins the errors of every course that failed.
+//
+//	results, err := account.UpdateCoursesSettings(nil, canvas.CourseSettingsUpdate{
+//		Settings: func(s *canvas.CourseSettings) { s.HideDistributionGraphs = true },
+//		DryRun:   true,
+//	}, canvas.Opt("enrollment_term_id", 12))
+func (a *Account) UpdateCoursesSettings(
+	filter func(*Course) bool,
+	update CourseSettingsUpdate,
+	opts ...Option,
+) ([]*CourseSettingsResult, error) {
+	courses, err := a.Courses(opts...)
+	if err != nil {
+		return nil, err
+	}
+	workers := update.Workers
+	if workers <= 0 {
+		workers = bulkWorkers
+	}
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errl    []error
+		results []*CourseSettingsResult
+		sem     = make(chan struct{}, workers)
+	)
+	for _, c := range courses {
+		if filter != nil && !filter(c) {
+			continue
+		}
+		res := &CourseSettingsResult{Course: c}
+		results = append(results, res)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			if res.Err = update.apply(res); res.Err != nil {
+				mu.Lock()
+				errl = append(errl, fmt.Errorf("course %d: %w", res.Course.ID, res.Err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	sort.Slice(errl, func(i, j int) bool { return errl[i].Error() < errl[j].Error() })
+	return results, joinErrs(errl)
+}
+
+func (u *CourseSettingsUpdate) apply(res *CourseSettingsResult) error {
+	c := res.Course
+	if u.Settings != nil {
+		before, err := c.Settings()
+		if err != nil {
+			return err
+		}
+		after := *before
+		u.Settings(&after)
+		res.Before, res.After = before, &after
+		res.Changed = after != *before
+		if res.Changed && !u.DryRun {
+			if res.After, err = c.UpdateSettings(&after); err != nil {
+				return err
+			}
+		}
+	}
+	if len(u.Course) == 0 || u.DryRun {
+		return nil
+	}
+	resp, err := put(c.client, c.id("/courses/%d"), optEnc(u.Course))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
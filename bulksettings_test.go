@@ -0,0 +1,92 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestUpdateCoursesSettings(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var updates, courseUpdates int32
+	mux.HandleFunc("/api/v1/accounts/1/courses", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("enrollment_term_id") != "5" {
+			t.Error("course list options not used")
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[{"id":1,"name":"math"},{"id":2,"name":"art"},{"id":3,"name":"bio"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings") && r.Method == "GET":
+			if r.URL.Path == "/api/v1/courses/3/settings" {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"message":"oops"}`)
+				return
+			}
+			// course 2 already has the setting
+			fmt.Fprintf(w, `{"hide_final_grades":%t}`, r.URL.Path == "/api/v1/courses/2/settings")
+		case strings.HasSuffix(r.URL.Path, "/settings") && r.Method == "PUT":
+			atomic.AddInt32(&updates, 1)
+			if r.URL.Query().Get("hide_final_grades") != "true" {
+				t.Error("setting not sent")
+			}
+			fmt.Fprint(w, `{"hide_final_grades":true}`)
+		case r.Method == "PUT":
+			atomic.AddInt32(&courseUpdates, 1)
+			if r.URL.Query().Get("course[default_view]") != "modules" {
+				t.Error("course fields not sent")
+			}
+			fmt.Fprint(w, `{}`)
+		}
+	})
+	account := &Account{ID: 1, cli: client}
+	update := CourseSettingsUpdate{
+		Settings: func(s *CourseSettings) { s.HideFinalGrades = true },
+		Course:   []Option{Opt("course[default_view]", "modules")},
+		DryRun:   true,
+	}
+	filter := func(c *Course) bool { return c.Name != "art" }
+
+	results, err := account.UpdateCoursesSettings(filter, update, Opt("enrollment_term_id", 5))
+	is.True(err != nil) // course 3 fails
+	is.Equal(len(results), 2)
+	is.True(results[0].Changed)
+	is.True(results[0].After.HideFinalGrades)
+	is.True(!results[0].Before.HideFinalGrades)
+	is.True(results[1].Err != nil)
+	is.Equal(atomic.LoadInt32(&updates), int32(0))
+	is.Equal(atomic.LoadInt32(&courseUpdates), int32(0))
+
+	update.DryRun = false
+	results, err = account.UpdateCoursesSettings(nil, update, Opt("enrollment_term_id", 5))
+	is.True(err != nil)
+	is.Equal(len(results), 3)
+	is.True(!results[1].Changed)
+	is.Equal(atomic.LoadInt32(&updates), int32(1))
+	is.Equal(atomic.LoadInt32(&courseUpdates), int32(2))
+}
+
+func TestUpdateSettingsPartial(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/2/settings", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PUT")
+		is.Equal(r.URL.RawQuery, "grading_standard_id=0&hide_final_grades=false")
+		fmt.Fprint(w, `{"hide_final_grades":false,"lock_all_announcements":true}`)
+	})
+	c := &Course{ID: 2, client: client}
+	settings, err := c.UpdateSettingsPartial(CourseSettingsPatch{
+		HideFinalGrades:   Bool(false),
+		GradingStandardID: Int(0),
+	})
+	is.NoErr(err)
+	is.True(settings.LockAllAnnouncements)
+}
@@ -0,0 +1,147 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/harrybrwn/go-canvas/store"
+)
+
+// maxCachedBody is the largest response body that is cached,
+// bigger responses like file downloads are never stored.
+const maxCachedBody = 4 << 20
+
+// WithCache will cache GET responses in the store's etags bucket and
+// revalidate them with canvas using their ETag or Last-Modified headers.
+// Canvas still gets a request for every call but unchanged resources are
+// answered with 304 Not Modified and no body. Use store.NewMemory for an
+// in memory cache or store.Open to keep the cache on disk between runs.
+// Responses are cached separately for each access token. Use the NoCache
+// option to skip the cache for a single call.
+//
+//	s, err := store.Open("/var/cache/canvas")
+//	if err != nil {
+//		return err
+//	}
+//	c := canvas.New(token, canvas.WithCache(s))
+func WithCache(s store.Store) ClientOption {
+	return func(cc *clientConfig) {
+		cc.cache = s
+	}
+}
+
+// NoCache is an Option that sends the requests of a call without reading
+// from or writing to the cache set with WithCache.
+var NoCache Option = &requestOption{apply: func(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), noCacheKey{}, true))
+}}
+
+type noCacheKey struct{}
+
+// cachedResponse is a response saved in the cache.
+type cachedResponse struct {
+	Status     string      `json:"status"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// cacheTransport is a round tripper that caches responses that
+// have validators and sends conditional requests for them.
+type cacheTransport struct {
+	rt    http.RoundTripper
+	store store.Store
+}
+
+func (ct *cacheTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != "GET" || r.Header.Get("Range") != "" {
+		return ct.rt.RoundTrip(r)
+	}
+	if skip, _ := r.Context().Value(noCacheKey{}).(bool); skip {
+		return ct.rt.RoundTrip(r)
+	}
+	key := cacheKey(r)
+	var cached *cachedResponse
+	if err := store.GetJSON(ct.store, store.ETags, key, &cached); err == nil && cached != nil {
+		r = r.Clone(r.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		if mod := cached.Header.Get("Last-Modified"); mod != "" {
+			r.Header.Set("If-Modified-Since", mod)
+		}
+	} else {
+		cached = nil
+	}
+	resp, err := ct.rt.RoundTrip(r)
+	if err != nil {
+		return resp, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		return cached.response(r, resp.Header), nil
+	case resp.StatusCode != http.StatusOK:
+		return resp, nil
+	case resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "":
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBody {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	// failing to cache the response is not an error
+	store.PutJSON(ct.store, store.ETags, key, &cachedResponse{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	})
+	return resp, nil
+}
+
+// response rebuilds the cached response. The headers from the not
+// modified response are used so that things like the rate limit
+// headers are up to date.
+func (cr *cachedResponse) response(r *http.Request, notModified http.Header) *http.Response {
+	h := cr.Header.Clone()
+	for k, v := range notModified {
+		h[k] = v
+	}
+	h.Set("Content-Length", strconv.Itoa(len(cr.Body)))
+	return &http.Response{
+		Status:        cr.Status,
+		StatusCode:    cr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          ioutil.NopCloser(bytes.NewReader(cr.Body)),
+		ContentLength: int64(len(cr.Body)),
+		Request:       r,
+	}
+}
+
+// cacheKey is the request's url prefixed by a hash of its
+// authorization so that users never see each other's responses.
+func cacheKey(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:8]) + " " + r.URL.String()
+}
@@ -0,0 +1,77 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/harrybrwn/go-canvas/store"
+	"github.com/matryer/is"
+)
+
+func TestCache(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	var requests, notModified int32
+	var name atomic.Value
+	name.Store("one")
+	mux.HandleFunc("/api/v1/courses/1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		etag := `W/"` + name.Load().(string) + `"`
+		w.Header().Set("X-Rate-Limit-Remaining", fmt.Sprint(700-atomic.LoadInt32(&requests)))
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"id":1,"name":%q}`, name.Load())
+	})
+	mux.HandleFunc("/api/v1/courses/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			t.Error("responses without validators should not be cached")
+		}
+		fmt.Fprint(w, `{"id":2}`)
+	})
+
+	s := store.NewMemory()
+	rt := httpClient.Transport.(*auth).rt
+	c := WithHost("token", DefaultHost, WithTransport(rt), WithCache(s))
+	for i := 0; i < 3; i++ {
+		course, err := c.GetCourse(1)
+		is.NoErr(err)
+		is.Equal(course.Name, "one")
+	}
+	is.Equal(atomic.LoadInt32(&requests), int32(3))
+	is.Equal(atomic.LoadInt32(&notModified), int32(2))
+	// the rate limit headers come from the latest response
+	is.Equal(c.client.(*client).rate.remaining, 697.0)
+
+	name.Store("two")
+	course, err := c.GetCourse(1)
+	is.NoErr(err)
+	is.Equal(course.Name, "two")
+
+	_, err = c.GetCourse(1)
+	is.NoErr(err)
+	is.Equal(atomic.LoadInt32(&notModified), int32(3))
+	_, err = c.GetCourse(1, NoCache)
+	is.NoErr(err)
+	is.Equal(atomic.LoadInt32(&notModified), int32(3))
+
+	// other tokens do not share cached responses
+	other := WithHost("other", DefaultHost, WithTransport(rt), WithCache(s))
+	_, err = other.GetCourse(1)
+	is.NoErr(err)
+	is.Equal(atomic.LoadInt32(&notModified), int32(3))
+
+	for i := 0; i < 2; i++ {
+		_, err = c.GetCourse(2)
+		is.NoErr(err)
+	}
+	keys, err := s.Keys(store.ETags)
+	is.NoErr(err)
+	is.Equal(len(keys), 2)
+}
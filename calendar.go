@@ -0,0 +1,332 @@
+package canvas
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalendarEventsBetween will get the calendar events that happen between
+// start and end for the given context codes, like "course_123" or
+// "user_456". If no context codes are given then the current user's
+// calendar is used.
+//
+// https://canvas.instructure.com/doc/api/calendar_events.html#method.calendar_events_api.index
+func (c *Canvas) CalendarEventsBetween(start, end time.Time, contextCodes ...string) ([]*CalendarEvent, error) {
+	return c.CalendarEventsFor(contextCodes, start, end, "")
+}
+
+// CalendarEventsBetween will get the calendar events that happen
+// between start and end for the given context codes.
+func CalendarEventsBetween(start, end time.Time, contextCodes ...string) ([]*CalendarEvent, error) {
+	return ca.CalendarEventsBetween(start, end, contextCodes...)
+}
+
+// CalendarEventType is the type of calendar event to list.
+type CalendarEventType string
+
+// Calendar event types.
+const (
+	CalendarEventTypeEvent      CalendarEventType = "event"
+	CalendarEventTypeAssignment CalendarEventType = "assignment"
+)
+
+// maxContextCodes is the most context codes that canvas
+// will take in one calendar events request.
+const maxContextCodes = 10
+
+// ContextCodes is a list of context codes, like "course_123" or
+// "user_456", that is built up with its methods.
+//
+//	codes := canvas.ContextCodes{}.Courses(1, 2).Groups(3).Users(4)
+type ContextCodes []string
+
+// Courses adds the context codes of the courses with the given ids.
+func (cc ContextCodes) Courses(ids ...int) ContextCodes {
+	return cc.add("course", ids)
+}
+
+// Groups adds the context codes of the groups with the given ids.
+func (cc ContextCodes) Groups(ids ...int) ContextCodes {
+	return cc.add("group", ids)
+}
+
+// Users adds the context codes of the users with the given ids.
+func (cc ContextCodes) Users(ids ...int) ContextCodes {
+	return cc.add("user", ids)
+}
+
+// Of adds the context codes of courses, groups, or users.
+func (cc ContextCodes) Of(contexts ...interface{ ContextCode() string }) ContextCodes {
+	for _, c := range contexts {
+		cc = append(cc, c.ContextCode())
+	}
+	return cc
+}
+
+func (cc ContextCodes) add(kind string, ids []int) ContextCodes {
+	for _, id := range ids {
+		cc = append(cc, fmt.Sprintf("%s_%d", kind, id))
+	}
+	return cc
+}
+
+// batches splits the context codes into groups of at most n
+// codes leaving out any duplicates.
+func (cc ContextCodes) batches(n int) [][]string {
+	var (
+		batches [][]string
+		seen    = make(map[string]bool, len(cc))
+	)
+	for _, code := range cc {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		if len(batches) == 0 || len(batches[len(batches)-1]) == n {
+			batches = append(batches, make([]string, 0, n))
+		}
+		batches[len(batches)-1] = append(batches[len(batches)-1], code)
+	}
+	return batches
+}
+
+// CalendarEventsFor will get the calendar events of type typ that happen
+// between start and end for all of the given contexts. Canvas only takes
+// ten context codes at a time so the events are requested in batches and
+// returned together sorted by their start time. A zero start or end time
+// and an empty type are not sent. If no context codes are given then the
+// current user's calendar is used.
+//
+// https://canvas.instructure.com/doc/api/calendar_events.html#method.calendar_events_api.index
+func (c *Canvas) CalendarEventsFor(
+	codes ContextCodes,
+	start, end time.Time,
+	typ CalendarEventType,
+	opts ...Option,
+) ([]*CalendarEvent, error) {
+	base := make([]Option, 0, len(opts)+3)
+	if !start.IsZero() {
+		base = append(base, DateOpt("start_date", start))
+	}
+	if !end.IsZero() {
+		base = append(base, DateOpt("end_date", end))
+	}
+	if typ != "" {
+		base = append(base, Opt("type", string(typ)))
+	}
+	base = append(base, opts...)
+	batches := codes.batches(maxContextCodes)
+	if len(batches) == 0 {
+		return c.CalendarEvents(base...)
+	}
+	var (
+		events []*CalendarEvent
+		errl   []error
+	)
+	for _, batch := range batches {
+		evs, err := c.CalendarEvents(append([]Option{ArrayOpt("context_codes", batch...)}, base...)...)
+		if err != nil {
+			errl = append(errl, err)
+		}
+		events = append(events, evs...)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartAt.Before(events[j].StartAt)
+	})
+	return events, joinErrs(errl)
+}
+
+// CalendarEventsFor will get the calendar events for all of the
+// given contexts in batches.
+func CalendarEventsFor(codes ContextCodes, start, end time.Time, typ CalendarEventType, opts ...Option) ([]*CalendarEvent, error) {
+	return ca.CalendarEventsFor(codes, start, end, typ, opts...)
+}
+
+// ReserveTimeSlot will reserve a time slot in an appointment group for
+// the current user. Use Opt("participant_id", id) to reserve it for
+// someone else and Opt("cancel_existing", true) to cancel any other
+// reservations in the same appointment group. The reservation that was
+// made is returned and can be cancelled with CancelReservation.
+//
+// https://canvas.instructure.com/doc/api/calendar_events.html#method.calendar_events_api.reserve
+func (c *Canvas) ReserveTimeSlot(event *CalendarEvent, opts ...Option) (*CalendarEvent, error) {
+	resp, err := post(c.client, fmt.Sprintf("/calendar_events/%d/reservations", event.ID), optEnc(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	res := &CalendarEvent{}
+	return res, decodeJSON(resp.Body, res)
+}
+
+// ReserveTimeSlot will reserve a time slot in an appointment group.
+func ReserveTimeSlot(event *CalendarEvent, opts ...Option) (*CalendarEvent, error) {
+	return ca.ReserveTimeSlot(event, opts...)
+}
+
+// CancelReservation will cancel a reservation made with
+// ReserveTimeSlot which frees up the time slot.
+func (c *Canvas) CancelReservation(reservation *CalendarEvent) error {
+	_, err := c.DeleteCalendarEventByID(reservation.ID)
+	return err
+}
+
+// CancelReservation will cancel a reservation made with ReserveTimeSlot.
+func CancelReservation(reservation *CalendarEvent) error {
+	return ca.CancelReservation(reservation)
+}
+
+// CalendarFeed will download the course's ics calendar feed and
+// parse it into calendar events. The feed includes assignment due
+// dates as events along with the regular calendar events.
+func (c *Course) CalendarFeed() ([]CalendarEvent, error) {
+	if c.Calendar.ICSDownload == "" {
+		return nil, errors.New("course has no calendar feed")
+	}
+	u, err := url.Parse(c.Calendar.ICSDownload)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(c.client, &http.Request{
+		Method: "GET",
+		Proto:  "HTTP/1.1",
+		URL:    u,
+		Header: http.Header{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	events, err := ParseICS(resp.Body)
+	for i := range events {
+		events[i].ContextCode = c.ContextCode()
+	}
+	return events, err
+}
+
+var icsEventID = regexp.MustCompile(`^event-calendar-event-(\d+)$`)
+
+// ParseICS will parse the events in an ics calendar like the feeds that
+// canvas provides. Only the fields found in the feed are set. Events
+// that came from a canvas calendar event have their ID set.
+func ParseICS(r io.Reader) ([]CalendarEvent, error) {
+	var (
+		events []CalendarEvent
+		event  *CalendarEvent
+		err    error
+	)
+	for _, line := range unfoldICS(r) {
+		name, params, value := splitICSLine(line)
+		switch name {
+		case "BEGIN":
+			if value == "VEVENT" {
+				event = &CalendarEvent{}
+			}
+			continue
+		case "END":
+			if value == "VEVENT" && event != nil {
+				events = append(events, *event)
+				event = nil
+			}
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		switch name {
+		case "UID":
+			if m := icsEventID.FindStringSubmatch(value); m != nil {
+				event.ID, _ = strconv.Atoi(m[1])
+			}
+		case "SUMMARY":
+			event.Title = unescapeICS(value)
+		case "DESCRIPTION":
+			event.Description = unescapeICS(value)
+		case "LOCATION":
+			event.LocationName = unescapeICS(value)
+		case "URL":
+			event.HTMLURL = value
+		case "DTSTART":
+			event.StartAt, err = parseICSTime(value, params)
+			if params["VALUE"] == "DATE" {
+				event.AllDay = true
+				event.AllDayDate = event.StartAt.Format("2006-01-02")
+			}
+		case "DTEND":
+			event.EndAt, err = parseICSTime(value, params)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ics: %s: %w", name, err)
+		}
+	}
+	return events, nil
+}
+
+// unfoldICS splits the calendar into lines and joins
+// lines that were folded onto more than one line.
+func unfoldICS(r io.Reader) []string {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitICSLine splits a line like "DTSTART;TZID=America/Denver:20200901T100000"
+// into its name, parameters, and value.
+func splitICSLine(line string) (name string, params map[string]string, value string) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return line, nil, ""
+	}
+	value = line[i+1:]
+	parts := strings.Split(line[:i], ";")
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if kv := strings.SplitN(p, "=", 2); len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return name, params, value
+}
+
+func parseICSTime(value string, params map[string]string) (time.Time, error) {
+	if params["VALUE"] == "DATE" {
+		return time.Parse("20060102", value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+	loc := time.UTC
+	if tz, ok := params["TZID"]; ok {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+	return time.ParseInLocation("20060102T150405", value, loc)
+}
+
+var icsUnescaper = strings.NewReplacer(
+	`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`,
+)
+
+func unescapeICS(s string) string {
+	return icsUnescaper.Replace(s)
+}
@@ -0,0 +1,110 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/feeds/calendars/course_abc.ics", func(w http.ResponseWriter, r *http.Request) {
+		writeTestFile(t, "calendar.ics", w)
+	})
+	c := &Course{ID: 1, client: client}
+	c.Calendar.ICSDownload = fmt.Sprintf("https://%s/feeds/calendars/course_abc.ics", DefaultHost)
+	events, err := c.CalendarFeed()
+	is.NoErr(err)
+	is.Equal(len(events), 3)
+
+	lecture := events[0]
+	is.Equal(lecture.ID, 42)
+	is.Equal(lecture.Title, "Lecture [Intro to Go]")
+	is.Equal(lecture.Description, "Bring your laptop, and a charger.\nRoom changed.")
+	is.Equal(lecture.LocationName, "Room 101")
+	is.Equal(lecture.HTMLURL, "https://canvas.instructure.com/calendar?event_id=42&include_contexts=course_1")
+	is.Equal(lecture.StartAt, time.Date(2020, 9, 3, 17, 0, 0, 0, time.UTC))
+	is.Equal(lecture.EndAt.Sub(lecture.StartAt), time.Hour)
+	is.Equal(lecture.ContextCode, "course_1")
+
+	hw := events[1]
+	is.Equal(hw.ID, 0)
+	is.True(hw.AllDay)
+	is.Equal(hw.AllDayDate, "2020-09-10")
+
+	denver, err := time.LoadLocation("America/Denver")
+	is.NoErr(err)
+	is.True(events[2].StartAt.Equal(time.Date(2020, 9, 15, 10, 0, 0, 0, denver)))
+}
+
+func TestReserveTimeSlot(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: client}
+	mux.HandleFunc("/api/v1/calendar_events/5/reservations", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("cancel_existing") != "true" {
+			t.Error("wrong reservation request")
+		}
+		fmt.Fprint(w, `{"id":6,"parent_event_id":5}`)
+	})
+	var cancelled bool
+	mux.HandleFunc("/api/v1/calendar_events/6", func(w http.ResponseWriter, r *http.Request) {
+		cancelled = r.Method == "DELETE"
+		fmt.Fprint(w, `{"id":6}`)
+	})
+	res, err := c.ReserveTimeSlot(&CalendarEvent{ID: 5}, Opt("cancel_existing", true))
+	is.NoErr(err)
+	is.Equal(res.ID, 6)
+	is.NoErr(c.CancelReservation(res))
+	is.True(cancelled)
+}
+
+func TestCalendarEventsFor(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var (
+		mu      sync.Mutex
+		batches [][]string
+	)
+	mux.HandleFunc("/api/v1/calendar_events", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("start_date") != "2026-09-01T00:00:00Z" || q.Get("end_date") != "2026-10-01T00:00:00Z" || q.Get("type") != "assignment" {
+			t.Errorf("wrong calendar options: %v", q)
+		}
+		codes := q["context_codes[]"]
+		mu.Lock()
+		batches = append(batches, codes)
+		mu.Unlock()
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/calendar_events?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprintf(w, `[{"id":%d,"start_at":"2026-09-%02dT00:00:00Z","context_code":%q}]`,
+			len(codes), 30-len(codes), codes[0])
+	})
+	c := &Canvas{client: client}
+	ids := make([]int, 12)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	codes := ContextCodes{}.Courses(ids...).Of(&User{ID: 9}).Groups(3).Courses(1)
+	is.Equal(len(codes), 15)
+	events, err := c.CalendarEventsFor(
+		codes,
+		time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		CalendarEventTypeAssignment,
+	)
+	is.NoErr(err)
+	is.Equal(len(batches), 2)
+	is.Equal(len(batches[0]), 10)
+	is.Equal(batches[1], []string{"course_11", "course_12", "user_9", "group_3"}) // duplicates are dropped
+	is.Equal(len(events), 2)
+	is.Equal(events[0].ID, 10) // sorted by start time
+	is.Equal(events[1].ID, 4)
+}
@@ -4,11 +4,10 @@
 package canvas
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -53,16 +52,27 @@ func SetHost(host string) error { return ca.SetHost(host) }
 
 // New will create a Canvas struct from an api token.
 // New uses the default host.
-func New(token string) *Canvas {
-	return WithHost(token, DefaultHost)
+func New(token string, opts ...ClientOption) *Canvas {
+	return WithHost(token, DefaultHost, opts...)
 }
 
 // WithHost will create a canvas object that uses a
 // different hostname.
-func WithHost(token, host string) *Canvas {
-	c := http.Client{}
+func WithHost(token, host string, opts ...ClientOption) *Canvas {
+	conf := newClientConfig(opts)
+	c := conf.baseClient()
 	authorize(&c, token, host)
-	return &Canvas{&client{Client: c, host: host}}
+	return &Canvas{&client{
+		Client:       c,
+		host:         host,
+		retries:      conf.retries,
+		retryWait:    conf.retryWait,
+		maxRetryWait: conf.maxRetryWait,
+		rate:         &rateLimit{threshold: conf.rateLimitThreshold},
+		hooks:        conf.hooks,
+		codec:        conf.codec,
+		concurrency:  conf.concurrency,
+	}}
 }
 
 // Canvas is the main api entry point.
@@ -100,7 +110,7 @@ func getCourses(c doer, path string, opts optEnc) (crs []*Course, err error) {
 	pager := newPaginatedList(
 		c, path, func(r io.Reader) error {
 			list := make([]*Course, 0)
-			if err := json.NewDecoder(r).Decode(&list); err != nil {
+			if err := decodeJSON(r, &list); err != nil {
 				return err
 			}
 			for _, course := range list {
@@ -112,12 +122,16 @@ func getCourses(c doer, path string, opts optEnc) (crs []*Course, err error) {
 		}, opts,
 	)
 	errs := pager.start()
+	var errl []error
 	for {
 		select {
 		case course := <-ch:
 			crs = append(crs, course)
-		case err := <-errs:
-			return crs, err
+		case err, ok := <-errs:
+			if !ok {
+				return crs, joinErrs(errl)
+			}
+			errl = append(errl, err)
 		}
 	}
 }
@@ -133,7 +147,7 @@ func (c *Canvas) CoursesChan(opts ...Option) <-chan *Course {
 	pager := newPaginatedList(
 		c.client, "/courses", func(r io.Reader) error {
 			list := make([]*Course, 0)
-			if err := json.NewDecoder(r).Decode(&list); err != nil {
+			if err := decodeJSON(r, &list); err != nil {
 				return err
 			}
 			for _, course := range list {
@@ -143,7 +157,7 @@ func (c *Canvas) CoursesChan(opts ...Option) <-chan *Course {
 			}
 			return nil
 		}, opts)
-	go handleErrs(pager.start(), ch, ConcurrentErrorHandler)
+	go handleErrs(pager, ch, ConcurrentErrorHandler)
 	return ch
 }
 
@@ -299,8 +313,14 @@ func CreateFolder(path string, opts ...Option) (*Folder, error) {
 
 // UploadFile uploads a file to the current user's files.
 func (c *Canvas) UploadFile(filename string, r io.Reader, opts ...Option) (*File, error) {
+	return c.UploadFileContext(context.Background(), filename, r, opts...)
+}
+
+// UploadFileContext uploads a file to the current user's files and
+// will abort the upload if the context is cancelled.
+func (c *Canvas) UploadFileContext(ctx context.Context, filename string, r io.Reader, opts ...Option) (*File, error) {
 	return uploadFile(
-		c.client, r, "/users/self/files",
+		ctx, c.client, r, "/users/self/files",
 		newFileUploadParams(filename, opts),
 	)
 }
@@ -310,6 +330,12 @@ func UploadFile(filename string, r io.Reader, opts ...Option) (*File, error) {
 	return ca.UploadFile(filename, r, opts...)
 }
 
+// UploadFileContext uploads a file to the current user's files and
+// will abort the upload if the context is cancelled.
+func UploadFileContext(ctx context.Context, filename string, r io.Reader, opts ...Option) (*File, error) {
+	return ca.UploadFileContext(ctx, filename, r, opts...)
+}
+
 // CurrentAccount will get the current account.
 func (c *Canvas) CurrentAccount() (a *Account, err error) {
 	a = &Account{cli: c.client}
@@ -362,7 +388,10 @@ type Account struct {
 	cli doer
 }
 
-// Courses returns the account's list of courses
+// Courses returns the account's list of courses. Use CourseStateFilter,
+// EnrollmentTypeFilter, and WithEnrollments to filter the list.
+//
+// https://canvas.instructure.com/doc/api/accounts.html#method.accounts.courses_api
 func (a *Account) Courses(opts ...Option) (courses []*Course, err error) {
 	return getCourses(a.cli, fmt.Sprintf("/accounts/%d/courses", a.ID), optEnc(opts))
 }
@@ -380,25 +409,33 @@ func SearchAccounts(term string, opts ...Option) ([]Account, error) {
 	return ca.SearchAccounts(term, opts...)
 }
 
-// Announcements will get the announcements
+// Announcements will get the announcements for the courses with the given
+// context codes. Use AnnouncementsSince, AnnouncementsUntil, ActiveAnnouncements,
+// and LatestAnnouncements to narrow down the list. Each announcement has its
+// CourseID set from its context code.
+//
 // https://canvas.instructure.com/doc/api/all_resources.html#method.announcements_api.index
 func (c *Canvas) Announcements(
 	contextCodes []string,
 	opts ...Option,
 ) (arr []*DiscussionTopic, err error) {
-	opts = append(opts, Opt("context_codes[]", contextCodes))
+	opts = append(opts, ArrayOpt("context_codes", contextCodes...))
 	ch := make(chan *DiscussionTopic)
 	pager := newPaginatedList(
 		c.client, "/announcements",
-		sendDiscussionTopicFunc(ch), opts)
+		sendDiscussionTopicFunc(c.client, "", ch), opts)
 	arr = make([]*DiscussionTopic, 0)
 	errs := pager.start()
+	var errl []error
 	for {
 		select {
 		case an := <-ch:
 			arr = append(arr, an)
-		case err := <-errs:
-			return arr, err
+		case err, ok := <-errs:
+			if !ok {
+				return arr, joinErrs(errl)
+			}
+			errl = append(errl, err)
 		}
 	}
 }
@@ -452,6 +489,16 @@ type DiscussionTopic struct {
 	AllowRating        bool `json:"allow_rating"`
 	OnlyGradersCanRate bool `json:"only_graders_can_rate"`
 	SortByRating       bool `json:"sort_by_rating"`
+	// ContextCode is only sent with announcements.
+	ContextCode string `json:"context_code"`
+	// CourseID is the id of the course that the topic
+	// belongs to, it is zero for group topics.
+	CourseID int `json:"-"`
+
+	// context is the api path of the course or group
+	// that the topic belongs to.
+	context string
+	client  doer
 }
 
 // CalendarEvents makes a call to get calendar events.
@@ -459,7 +506,7 @@ func (c *Canvas) CalendarEvents(opts ...Option) (cal []*CalendarEvent, err error
 	ch := make(chan *CalendarEvent)
 	pager := newPaginatedList(c.client, "/calendar_events", func(r io.Reader) error {
 		evs := make([]*CalendarEvent, 0)
-		if err := json.NewDecoder(r).Decode(&evs); err != nil {
+		if err := decodeJSON(r, &evs); err != nil {
 			return err
 		}
 		for _, e := range evs {
@@ -469,12 +516,16 @@ func (c *Canvas) CalendarEvents(opts ...Option) (cal []*CalendarEvent, err error
 	}, opts)
 	errs := pager.start()
 	events := make([]*CalendarEvent, 0)
+	var errl []error
 	for {
 		select {
 		case event := <-ch:
 			events = append(events, event)
-		case err := <-errs:
-			return events, err
+		case err, ok := <-errs:
+			if !ok {
+				return events, joinErrs(errl)
+			}
+			errl = append(errl, err)
 		}
 	}
 }
@@ -507,7 +558,7 @@ func (c *Canvas) CreateCalendarEvent(event *CalendarEvent) (*CalendarEvent, erro
 	}
 	defer resp.Body.Close()
 	cal := &CalendarEvent{}
-	return cal, json.NewDecoder(resp.Body).Decode(cal)
+	return cal, decodeJSON(resp.Body, cal)
 }
 
 // CreateCalendarEvent will send a calendar event to canvas to be created.
@@ -529,7 +580,7 @@ func (c *Canvas) UpdateCalendarEvent(event *CalendarEvent) error {
 		return err
 	}
 	defer resp.Body.Close()
-	return json.NewDecoder(resp.Body).Decode(event)
+	return decodeJSON(resp.Body, event)
 }
 
 // UpdateCalendarEvent will update a calendar event. This operation will change
@@ -548,7 +599,7 @@ func (c *Canvas) DeleteCalendarEventByID(id int, opts ...Option) (*CalendarEvent
 	}
 	defer resp.Body.Close()
 	e := &CalendarEvent{}
-	return e, json.NewDecoder(resp.Body).Decode(e)
+	return e, decodeJSON(resp.Body, e)
 }
 
 // DeleteCalendarEventByID will delete a calendar event given its ID.
@@ -604,36 +655,6 @@ type CalendarEvent struct {
 	Group                      interface{} `json:"group" url:"-"`
 }
 
-// Conversations returns a list of conversations
-func (c *Canvas) Conversations(opts ...Option) (conversations []Conversation, err error) {
-	return conversations, getjson(c.client, &conversations, optEnc(opts), "/conversations")
-}
-
-// Conversations returns a list of conversations
-func Conversations(opts ...Option) ([]Conversation, error) {
-	return ca.Conversations(opts...)
-}
-
-// Conversation is a conversation.
-type Conversation struct {
-	ID               int         `json:"id"`
-	Subject          string      `json:"subject"`
-	WorkflowState    string      `json:"workflow_state"`
-	LastMessage      string      `json:"last_message"`
-	StartAt          time.Time   `json:"start_at"`
-	MessageCount     int         `json:"message_count"`
-	Subscribed       bool        `json:"subscribed"`
-	Private          bool        `json:"private"`
-	Starred          bool        `json:"starred"`
-	Properties       interface{} `json:"properties"`
-	Audience         interface{} `json:"audience"`
-	AudienceContexts interface{} `json:"audience_contexts"`
-	AvatarURL        string      `json:"avatar_url"`
-	Participants     interface{} `json:"participants"`
-	Visible          bool        `json:"visible"`
-	ContextName      string      `json:"context_name"`
-}
-
 // Bookmarks will get the current user's bookmarks.
 func (c *Canvas) Bookmarks(opts ...Option) (b []Bookmark, err error) {
 	return b, getjson(c.client, &b, optEnc(opts), "/users/self/bookmarks")
@@ -710,13 +731,20 @@ func getAccounts(d doer, path string, opts []Option) (accts []Account, err error
 	return
 }
 
-func sendDiscussionTopicFunc(ch chan *DiscussionTopic) sendFunc {
+// sendDiscussionTopicFunc sends the topics in a response. If context is
+// empty then it is found from each topic's context code.
+func sendDiscussionTopicFunc(client doer, context string, ch chan *DiscussionTopic) sendFunc {
 	return func(r io.Reader) error {
 		discs := make([]*DiscussionTopic, 0)
-		if err := json.NewDecoder(r).Decode(&discs); err != nil {
+		if err := decodeJSON(r, &discs); err != nil {
 			return err
 		}
 		for _, d := range discs {
+			d.client, d.context = client, context
+			if context == "" {
+				d.context = contextPath(d.ContextCode)
+			}
+			d.CourseID = contextCourseID(d.context)
 			ch <- d
 		}
 		return nil
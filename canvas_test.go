@@ -1,7 +1,9 @@
 package canvas
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -519,9 +521,11 @@ func TestRateLimitErr(t *testing.T) {
 	mux.HandleFunc("/api/v1/accounts/self", func(w http.ResponseWriter, r *http.Request) {
 		assertMethod(t, r, "GET")
 		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "403 Forbidden (Rate Limit Exceeded)\n")
 	})
 	mux.HandleFunc("/api/v1/accounts", func(w http.ResponseWriter, r *http.Request) {
 		assertMethod(t, r, "GET")
+		w.Header().Set("X-Rate-Limit-Remaining", "0")
 		w.WriteHeader(http.StatusForbidden)
 	})
 	mux.HandleFunc("/api/v1/folders/123/copy_file", func(w http.ResponseWriter, r *http.Request) {
@@ -604,6 +608,40 @@ func TestOptions(t *testing.T) {
 	o = UserOpt("key", "value")
 	is.Equal(o.Name(), "user[key]")
 	is.Equal(o.Value(), []string{"value"})
+
+	o = CourseIncludes(IncludeTerm, IncludeTotalScores, IncludeTerm)
+	is.Equal(o.Name(), "include[]")
+	is.Equal(o.Value(), []string{"term", "total_scores", "current_grading_period_scores"})
+	o = AssignmentIncludes(IncludeSubmission, IncludeScoreStatistics)
+	is.Equal(o.Value(), []string{"submission", "score_statistics"})
+	o = AssignmentIncludes(IncludeScoreStatistics, IncludeSubmission)
+	is.Equal(o.Value(), []string{"submission", "score_statistics"})
+	is.Equal(UserIncludes(IncludeEmail, IncludeLastLogin).Value(), []string{"email", "last_login"})
+	p := params{}
+	p.Add([]Option{IncludeOpt("one"), IncludeOpt("two"), Opt("a", 1), Opt("a", 2), IncludeOpt("one", "three")})
+	is.Equal(p["include[]"], []string{"one", "two", "three"}) // no duplicates
+	is.Equal(p["a"], []string{"2"})
+
+	o = EnrollmentTypeFilter(TeacherEnrollment)
+	is.Equal(o.Name(), "enrollment_type")
+	is.Equal(o.Value(), []string{"teacher"})
+	o = EnrollmentTypeFilter(TAEnrollment, StudentViewEnrollment)
+	is.Equal(o.Name(), "enrollment_type[]")
+	is.Equal(o.Value(), []string{"ta", "student_view"})
+	is.Equal(ActiveCourses.Value(), []string{"active"})
+
+	o = CourseStateFilter(WorkflowAvailable, WorkflowCompleted)
+	is.Equal(o.Name(), "state[]")
+	is.Equal(o.Value(), []string{"available", "completed"})
+	is.True((&Course{WorkflowState: string(WorkflowAvailable)}).IsActive())
+	is.True(!(&Course{WorkflowState: string(WorkflowUnpublished)}).IsPublished())
+	is.Equal((&Assignment{WorkflowState: "deleted", Published: true}).Workflow(), WorkflowDeleted)
+	is.Equal((&Assignment{Published: true}).Workflow(), WorkflowPublished)
+	is.True(!(&Assignment{}).IsPublished())
+	is.Equal((&Module{WorkflowState: "active"}).Workflow(), WorkflowActive)
+	is.Equal((&Module{}).Workflow(), WorkflowUnpublished)
+	is.Equal((&Page{Published: true}).Workflow(), WorkflowActive)
+	is.Equal((&Page{}).Workflow(), WorkflowUnpublished)
 }
 
 func TestTodos(t *testing.T) {
@@ -743,3 +781,328 @@ func TestCurrentUser(t *testing.T) {
 		t.Error("didn't pass the client along")
 	}
 }
+
+func TestClientOptions(t *testing.T) {
+	is := is.New(t)
+	c := New("token", WithMaxIdleConnsPerHost(32), WithIdleConnTimeout(time.Minute), WithHTTP2(false))
+	a := c.client.(*client).Transport.(*auth)
+	tr, ok := a.rt.(*http.Transport)
+	is.True(ok)
+	is.True(tr != http.DefaultTransport)
+	is.Equal(tr.MaxIdleConnsPerHost, 32)
+	is.Equal(tr.IdleConnTimeout, time.Minute)
+	is.True(!tr.ForceAttemptHTTP2)
+	is.True(tr.TLSNextProto != nil)
+
+	c = New("token")
+	a = c.client.(*client).Transport.(*auth)
+	is.True(a.rt == http.DefaultTransport)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestWithTransport(t *testing.T) {
+	is := is.New(t)
+	_, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/self", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Error("request was not authorized")
+		}
+		if r.Header.Get("X-Trace") != "1" {
+			t.Error("middleware was not used")
+		}
+		writeTestFile(t, "user.json", w)
+	})
+	base := &TestingTransport{&http.Transport{
+		Proxy: func(r *http.Request) (*url.URL, error) { return url.Parse(server.URL) },
+	}}
+	tracing := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r.Header.Set("X-Trace", "1")
+		return base.RoundTrip(r)
+	})
+	u, err := New("token", WithTransport(tracing)).CurrentUser()
+	is.NoErr(err)
+	is.Equal(u.ID, 2)
+
+	hc := &http.Client{Transport: tracing, Timeout: time.Minute}
+	c := New("token", WithClient(hc), WithMaxIdleConns(3))
+	is.Equal(c.client.(*client).Timeout, time.Minute)
+	is.True(hc.Transport != nil)
+	_, ok := hc.Transport.(*auth)
+	is.True(!ok) // the client given should not be changed
+	u, err = c.CurrentUser()
+	is.NoErr(err)
+	is.Equal(u.ID, 2)
+}
+
+func TestHistory(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/2/history", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/users/2/history?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{
+			"asset_code": "assignment_123",
+			"asset_name": "Test Assignment",
+			"context_type": "Course",
+			"context_id": 1,
+			"visited_url": "https://canvas.example.com/courses/1/assignments/123",
+			"visited_at": "2020-09-01T12:00:00Z",
+			"interaction_seconds": 90.5
+		}]`)
+	})
+	u := &User{ID: 2, client: client}
+	history, err := u.History()
+	is.NoErr(err)
+	is.Equal(len(history), 1)
+	is.Equal(history[0].AssetName, "Test Assignment")
+	is.Equal(history[0].ContextID, 1)
+	is.Equal(history[0].Interaction(), 90500*time.Millisecond)
+}
+
+func TestExternalFeeds(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/external_feeds", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/external_feeds?page=1>; rel="last"`, DefaultHost))
+			fmt.Fprint(w, `[{"id":3,"display_name":"Department News","url":"https://example.com/rss","verbosity":"full"}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("url") != "https://example.com/rss" || q.Get("verbosity") != FeedLinkOnly {
+				t.Error("wrong query parameters")
+			}
+			if _, ok := q["header_match"]; ok {
+				t.Error("empty header_match should not be sent")
+			}
+			fmt.Fprint(w, `{"id":4,"url":"https://example.com/rss","verbosity":"link_only"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/external_feeds/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE; got %s", r.Method)
+		}
+		fmt.Fprint(w, `{"id":3}`)
+	})
+	c := &Course{ID: 1, client: client}
+	feeds, err := c.ExternalFeeds()
+	is.NoErr(err)
+	is.Equal(len(feeds), 1)
+	is.Equal(feeds[0].DisplayName, "Department News")
+	feed, err := c.CreateExternalFeed(ExternalFeed{URL: "https://example.com/rss", Verbosity: FeedLinkOnly})
+	is.NoErr(err)
+	is.Equal(feed.ID, 4)
+	feed, err = c.DeleteExternalFeed(3)
+	is.NoErr(err)
+	is.Equal(feed.ID, 3)
+}
+
+func TestAssignmentExtensions(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/extensions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != "POST" || q.Get("assignment_extensions[][user_id]") != "7" ||
+			q.Get("assignment_extensions[][extra_attempts]") != "2" {
+			t.Error("wrong extensions request")
+		}
+		fmt.Fprint(w, `{"assignment_extensions":[{"assignment_id":2,"user_id":7,"extra_attempts":2}]}`)
+	})
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	ext, err := a.SetExtensions(7, 2)
+	is.NoErr(err)
+	is.Equal(*ext, AssignmentExtension{AssignmentID: 2, UserID: 7, ExtraAttempts: 2})
+}
+
+func TestScoreStatistics(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.RawQuery, "score_statistics") {
+			t.Error("should include score statistics")
+		}
+		fmt.Fprint(w, `{"id":2,"course_id":1}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/analytics/assignments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"assignment_id":1,"max_score":5},
+			{"assignment_id":2,"max_score":10,"min_score":2,"first_quartile":5,"median":7,"third_quartile":9}
+		]`)
+	})
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	stats, err := a.ScoreStatistics()
+	is.NoErr(err)
+	is.Equal(*stats, ScoreStatistics{Min: 2, Max: 10, LowerQ: 5, Median: 7, UpperQ: 9})
+
+	a = &Assignment{Statistics: &ScoreStatistics{Mean: 8}}
+	stats, err = a.ScoreStatistics()
+	is.NoErr(err)
+	is.Equal(stats.Mean, 8.0)
+}
+
+func TestUserSplit(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/5/split", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST; got %s", r.Method)
+		}
+		fmt.Fprint(w, `[{"id":5,"name":"Jo"},{"id":6,"name":"Jo (old)"}]`)
+	})
+	mux.HandleFunc("/api/v1/accounts/1/users/6", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE; got %s", r.Method)
+		}
+		fmt.Fprint(w, `{"id":6,"name":"Jo (old)"}`)
+	})
+	u := &User{ID: 5, client: client}
+	users, err := u.Split()
+	is.NoErr(err)
+	is.Equal(len(users), 2)
+	is.Equal(users[1].ID, 6)
+	is.True(users[1].client != nil)
+	c := &Canvas{client: client}
+	deleted, err := c.DeleteUserFromAccount(1, 6)
+	is.NoErr(err)
+	is.Equal(deleted.Name, "Jo (old)")
+}
+
+func TestUserProgress(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/users/7/progress", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"requirement_count":10,"requirement_completed_count":4,
+			"next_requirement_url":"https://canvas.instructure.com/courses/1/modules/items/3","completed_at":null}`)
+	})
+	c := &Course{ID: 1, client: client}
+	p, err := c.UserProgress(7)
+	is.NoErr(err)
+	is.Equal(p.RequirementCount, 10)
+	is.Equal(p.RequirementCompletedCount, 4)
+	is.True(p.CompletedAt.IsZero())
+}
+
+func TestDuplicateAssignment(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	defer func(d time.Duration) { progressPollInterval = d }(progressPollInterval)
+	progressPollInterval = time.Millisecond
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/duplicate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST; got %s", r.Method)
+		}
+		fmt.Fprint(w, `{"id":3,"course_id":1,"name":"quiz copy","workflow_state":"duplicating"}`)
+	})
+	var polls int
+	mux.HandleFunc("/api/v1/courses/1/assignments/3", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		state := "duplicating"
+		if polls > 1 {
+			state = "unpublished"
+		}
+		fmt.Fprintf(w, `{"id":3,"course_id":1,"name":"quiz copy","workflow_state":%q}`, state)
+	})
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	dup, err := a.Duplicate(context.Background())
+	is.NoErr(err)
+	is.Equal(dup.ID, 3)
+	is.Equal(dup.Workflow(), WorkflowUnpublished)
+	is.Equal(polls, 2)
+}
+
+func TestPoll(t *testing.T) {
+	is := is.New(t)
+	defer func(d time.Duration) { progressPollInterval = d }(progressPollInterval)
+	progressPollInterval = 5 * time.Millisecond
+	var (
+		calls int
+		times []time.Time
+	)
+	err := poll(context.Background(), func() bool { return calls == 3 }, func() error {
+		calls++
+		times = append(times, time.Now())
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(calls, 3)
+	is.True(times[2].Sub(times[1]) >= 10*time.Millisecond) // the wait doubles
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = poll(ctx, func() bool { return false }, func() error { return nil })
+	is.Equal(err, context.Canceled)
+
+	testErr := errors.New("refresh failed")
+	err = poll(context.Background(), func() bool { return false }, func() error { return testErr })
+	is.Equal(err, testErr)
+}
+
+func TestProgressPoll(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: client}
+	var calls int
+	mux.HandleFunc("/api/v1/progress/5", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			fmt.Fprint(w, `{"id":5,"workflow_state":"queued","completion":0}`)
+		case 2:
+			fmt.Fprint(w, `{"id":5,"workflow_state":"running","completion":50}`)
+		default:
+			fmt.Fprint(w, `{"id":5,"workflow_state":"failed","completion":75,"message":"migration failed"}`)
+		}
+	})
+	p, err := c.Progress(5)
+	is.NoErr(err)
+	is.Equal(p.WorkflowState, ProgressQueued)
+	var seen []float64
+	err = p.Poll(context.Background(), time.Millisecond, func(completion float64) {
+		seen = append(seen, completion)
+	})
+	is.True(err != nil)
+	is.Equal(err.Error(), "migration failed")
+	is.Equal(seen, []float64{0, 50, 75})
+	is.True(p.Done())
+}
+
+func TestExternalToolAssignment(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("assignment[submission_types][]") != "external_tool" {
+			t.Errorf("wrong submission types: %v", q)
+		}
+		if q.Get("assignment[external_tool_tag_attributes][url]") != "https://tool.example.com/launch" ||
+			q.Get("assignment[external_tool_tag_attributes][new_tab]") != "true" {
+			t.Errorf("wrong external tool tag: %v", q)
+		}
+		fmt.Fprint(w, `{"id":2,"submission_types":["external_tool"],"external_tool_tag_attributes":{
+			"url":"https://tool.example.com/launch","new_tab":true,"resource_link_id":"abc","content_id":4,"content_type":"ContextExternalTool"}}`)
+	})
+	c := &Course{ID: 1, client: client}
+	a, err := c.CreateAssignment(Assignment{
+		Name:            "Lab 1",
+		SubmissionTypes: []string{"external_tool"},
+		ExternalToolTagAttributes: &ExternalToolTag{
+			URL:    "https://tool.example.com/launch",
+			NewTab: true,
+		},
+	})
+	is.NoErr(err)
+	is.Equal(a.ExternalToolTagAttributes.ContentID, 4)
+	is.Equal(a.ExternalToolTagAttributes.ResourceLinkID, "abc")
+}
@@ -0,0 +1,120 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Codec decodes the json that canvas responds with. The standard
+// library compatible configs of most fast json packages satisfy it,
+// like jsoniter.ConfigCompatibleWithStandardLibrary or sonic.ConfigStd.
+type Codec interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// WithCodec will decode responses using codec instead of encoding/json.
+// Decoding large listings of courses and assignments can take up most
+// of the time spent syncing, which a faster codec can cut down on.
+// Responses are read into memory one page at a time before they are
+// decoded so listings are no longer decoded as they stream in.
+func WithCodec(codec Codec) ClientOption {
+	return func(cc *clientConfig) {
+		cc.codec = codec
+	}
+}
+
+// codecBody is a response body that remembers the
+// codec that should be used to decode it.
+type codecBody struct {
+	io.ReadCloser
+	codec Codec
+}
+
+// codecOf returns the codec that r should be decoded with
+// or nil if encoding/json should be used.
+func codecOf(r io.Reader) Codec {
+	switch b := r.(type) {
+	case *codecBody:
+		return b.codec
+	case *pagereader:
+		return codecOf(b.body)
+	}
+	return nil
+}
+
+// decodeJSON decodes one json value from r.
+func decodeJSON(r io.Reader, v interface{}) error {
+	codec := codecOf(r)
+	if codec == nil {
+		return json.NewDecoder(r).Decode(v)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(b, v)
+}
+
+// decoder decodes json values one at a time.
+type decoder interface {
+	Decode(v interface{}) error
+}
+
+// arrayDecoder reads the elements of a json array one at a time.
+type arrayDecoder struct {
+	dec   *json.Decoder
+	codec Codec
+	raw   []json.RawMessage
+}
+
+func newArrayDecoder(r io.Reader) (*arrayDecoder, error) {
+	if codec := codecOf(r); codec != nil {
+		ad := &arrayDecoder{codec: codec}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		if err = codec.Unmarshal(b, &ad.raw); err != nil {
+			return nil, fmt.Errorf("expected a json array: %w", err)
+		}
+		return ad, nil
+	}
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected a json array; got %v", tok)
+	}
+	return &arrayDecoder{dec: dec}, nil
+}
+
+// More returns true if there is another element in the array.
+func (ad *arrayDecoder) More() bool {
+	if ad.dec != nil {
+		return ad.dec.More()
+	}
+	return len(ad.raw) > 0
+}
+
+// Decode decodes the next element of the array into v.
+func (ad *arrayDecoder) Decode(v interface{}) error {
+	if ad.dec != nil {
+		return ad.dec.Decode(v)
+	}
+	raw := ad.raw[0]
+	ad.raw = ad.raw[1:]
+	return ad.codec.Unmarshal(raw, v)
+}
+
+// end reads the end of the array.
+func (ad *arrayDecoder) end() error {
+	if ad.dec == nil {
+		return nil
+	}
+	// consume the closing bracket
+	_, err := ad.dec.Token()
+	return err
+}
@@ -0,0 +1,77 @@
+package canvas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type countingCodec struct{ n int32 }
+
+func (cc *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	atomic.AddInt32(&cc.n, 1)
+	return json.Unmarshal(data, v)
+}
+
+func TestCodec(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":1,"name":"one"},{"id":2,"name":"two"}]`)
+	})
+	mux.HandleFunc("/api/v1/accounts/1/sub_accounts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/accounts/1/sub_accounts?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":2},{"id":3}]`)
+	})
+	mux.HandleFunc("/api/v1/users/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"name":"jo"}`)
+	})
+	mux.HandleFunc("/api/v1/users/6", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"bad request"}`)
+	})
+	codec := &countingCodec{}
+	c := &Canvas{client: &client{Client: *httpClient, codec: codec}}
+
+	courses, err := c.Courses()
+	is.NoErr(err)
+	is.Equal(len(courses), 2)
+	is.Equal(atomic.LoadInt32(&codec.n), int32(1))
+
+	// listings are decoded one element at a time
+	atomic.StoreInt32(&codec.n, 0)
+	a := &Account{ID: 1, cli: c.client}
+	accounts, err := a.SubAccounts(false)
+	is.NoErr(err)
+	is.Equal(len(accounts), 2)
+	is.Equal(atomic.LoadInt32(&codec.n), int32(3))
+
+	atomic.StoreInt32(&codec.n, 0)
+	var names []string
+	it := Paginate[*Course](c, "/courses")
+	for it.Next() {
+		names = append(names, it.Value().Name)
+	}
+	is.NoErr(it.Err())
+	is.Equal(names, []string{"one", "two"})
+	is.Equal(atomic.LoadInt32(&codec.n), int32(3))
+
+	atomic.StoreInt32(&codec.n, 0)
+	u, err := c.GetUser(5)
+	is.NoErr(err)
+	is.Equal(u.Name, "jo")
+	_, err = c.GetUser(6)
+	var e *Error
+	is.True(errors.As(err, &e))
+	is.Equal(e.Message, "bad request")
+	is.Equal(atomic.LoadInt32(&codec.n), int32(2))
+
+	is.True(newClientConfig([]ClientOption{WithCodec(codec)}).codec == codec)
+}
@@ -0,0 +1,269 @@
+package canvas
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/harrybrwn/errs"
+)
+
+// DiffKind is the kind of difference found between two courses.
+type DiffKind int
+
+const (
+	// DiffMissing means the item is in the source course
+	// but not in the target course.
+	DiffMissing DiffKind = iota
+	// DiffExtra means the item is in the target course
+	// but not in the source course.
+	DiffExtra
+	// DiffChanged means the item is in both courses but
+	// some of its fields are different.
+	DiffChanged
+)
+
+func (dk DiffKind) String() string {
+	switch dk {
+	case DiffMissing:
+		return "missing"
+	case DiffExtra:
+		return "extra"
+	case DiffChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("DiffKind(%d)", int(dk))
+	}
+}
+
+// FieldChange is a single field that differs between
+// two matching course items.
+type FieldChange struct {
+	Field  string
+	Source string
+	Target string
+}
+
+// DiffEntry is one difference found when comparing two courses.
+type DiffEntry struct {
+	Kind DiffKind
+	// Name is the name used to match the items in both courses.
+	Name string
+	// Index is the position of the item among the items in the same
+	// course that share its name. It is only non-zero when a course
+	// has duplicate names.
+	Index int
+	// SourceID and TargetID are the ids of the items in each
+	// course, one of them will be zero if the item is missing.
+	SourceID int
+	TargetID int
+	Changes  []FieldChange
+}
+
+// CourseDiff is the structured difference between two courses.
+type CourseDiff struct {
+	Source, Target int
+
+	Assignments []DiffEntry
+	Pages       []DiffEntry
+	Modules     []DiffEntry
+	Files       []DiffEntry
+}
+
+// Empty returns true if there were no differences found.
+func (cd *CourseDiff) Empty() bool {
+	return len(cd.Assignments) == 0 &&
+		len(cd.Pages) == 0 &&
+		len(cd.Modules) == 0 &&
+		len(cd.Files) == 0
+}
+
+// CompareCourses will concurrently fetch the assignments, pages, modules,
+// and files from both courses and return all of the differences found.
+// Items are matched up by name, so this works best for comparing a
+// blueprint course with one of its child courses or a course with its copy.
+// Items that share a name are matched in the order that canvas lists them.
+func CompareCourses(source, target *Course) (*CourseDiff, error) {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errl []error
+		diff = &CourseDiff{Source: source.ID, Target: target.ID}
+	)
+	collect := func(dst *[]DiffEntry, list func(*Course) (diffables, error)) {
+		defer wg.Done()
+		var src, tgt diffables
+		var srcErr, tgtErr error
+		var inner sync.WaitGroup
+		inner.Add(2)
+		go func() { defer inner.Done(); src, srcErr = list(source) }()
+		go func() { defer inner.Done(); tgt, tgtErr = list(target) }()
+		inner.Wait()
+		if err := errs.Pair(srcErr, tgtErr); err != nil {
+			mu.Lock()
+			errl = append(errl, err)
+			mu.Unlock()
+			return
+		}
+		*dst = diffItems(src, tgt)
+	}
+	wg.Add(4)
+	go collect(&diff.Assignments, assignmentDiffables)
+	go collect(&diff.Pages, pageDiffables)
+	go collect(&diff.Modules, moduleDiffables)
+	go collect(&diff.Files, fileDiffables)
+	wg.Wait()
+	return diff, errs.Chain(errl...)
+}
+
+// diffable is a course item reduced to the fields we care about comparing.
+type diffable struct {
+	id     int
+	fields []FieldChange // Source holds the value, Target is unused
+}
+
+// diffables holds the items of one kind in a course by name. Names are
+// not unique in canvas so each name can have more than one item.
+type diffables map[string][]diffable
+
+func (d diffables) add(name string, item diffable) {
+	d[name] = append(d[name], item)
+}
+
+func diffItems(src, tgt diffables) []DiffEntry {
+	entries := make([]DiffEntry, 0)
+	for name, srcItems := range src {
+		tgtItems := tgt[name]
+		for i, s := range srcItems {
+			if i >= len(tgtItems) {
+				entries = append(entries, DiffEntry{Kind: DiffMissing, Name: name, Index: i, SourceID: s.id})
+				continue
+			}
+			if e, ok := diffItem(name, i, s, tgtItems[i]); ok {
+				entries = append(entries, e)
+			}
+		}
+	}
+	for name, tgtItems := range tgt {
+		for i := len(src[name]); i < len(tgtItems); i++ {
+			entries = append(entries, DiffEntry{Kind: DiffExtra, Name: name, Index: i, TargetID: tgtItems[i].id})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Index < entries[j].Index
+	})
+	return entries
+}
+
+func diffItem(name string, index int, s, t diffable) (DiffEntry, bool) {
+	var changes []FieldChange
+	for i, f := range s.fields {
+		if i >= len(t.fields) || f.Source == t.fields[i].Source {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Field:  f.Field,
+			Source: f.Source,
+			Target: t.fields[i].Source,
+		})
+	}
+	if len(changes) == 0 {
+		return DiffEntry{}, false
+	}
+	return DiffEntry{
+		Kind:     DiffChanged,
+		Name:     name,
+		Index:    index,
+		SourceID: s.id,
+		TargetID: t.id,
+		Changes:  changes,
+	}, true
+}
+
+func field(name string, v interface{}) FieldChange {
+	var s string
+	switch val := v.(type) {
+	case time.Time:
+		if !val.IsZero() {
+			s = val.UTC().Format(dateFormat)
+		}
+	default:
+		s = fmt.Sprintf("%v", val)
+	}
+	return FieldChange{Field: name, Source: s}
+}
+
+func assignmentDiffables(c *Course) (diffables, error) {
+	asses, err := c.ListAssignments(InOrder)
+	if err != nil {
+		return nil, err
+	}
+	m := make(diffables, len(asses))
+	for _, a := range asses {
+		m.add(a.Name, diffable{id: a.ID, fields: []FieldChange{
+			field("due_at", a.DueAt),
+			field("unlock_at", a.UnlockAt),
+			field("lock_at", a.LockAt),
+			field("points_possible", a.PointsPossible),
+			field("grading_type", a.GradingType),
+			field("published", a.Published),
+		}})
+	}
+	return m, nil
+}
+
+func fileDiffables(c *Course) (diffables, error) {
+	files, err := c.ListFiles(InOrder)
+	if err != nil {
+		return nil, err
+	}
+	m := make(diffables, len(files))
+	for _, f := range files {
+		m.add(f.DisplayName, diffable{id: f.ID, fields: []FieldChange{
+			field("size", f.Size),
+			field("content-type", f.ContentType),
+			field("hidden", f.Hidden),
+			field("locked", f.Locked),
+		}})
+	}
+	return m, nil
+}
+
+func pageDiffables(c *Course) (diffables, error) {
+	pages, err := c.Pages()
+	if err != nil {
+		return nil, err
+	}
+	m := make(diffables, len(pages))
+	for _, p := range pages {
+		m.add(p.Title, diffable{id: p.ID, fields: []FieldChange{
+			field("url", p.URL),
+			field("published", p.Published),
+			field("front_page", p.FrontPage),
+		}})
+	}
+	return m, nil
+}
+
+func moduleDiffables(c *Course) (diffables, error) {
+	modules, err := c.ListModules()
+	if err != nil {
+		return nil, err
+	}
+	m := make(diffables, len(modules))
+	for _, mod := range modules {
+		m.add(mod.Name, diffable{id: mod.ID, fields: []FieldChange{
+			field("position", mod.Position),
+			field("items_count", mod.ItemsCount),
+			field("published", mod.Published),
+		}})
+	}
+	return m, nil
+}
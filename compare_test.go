@@ -0,0 +1,103 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDiffItems(t *testing.T) {
+	is := is.New(t)
+	now := time.Now()
+	src := diffables{
+		"hw1": {{id: 1, fields: []FieldChange{field("due_at", now), field("points_possible", 10.0)}}},
+		"hw2": {{id: 2, fields: []FieldChange{field("due_at", now)}}},
+	}
+	tgt := diffables{
+		"hw1": {{id: 11, fields: []FieldChange{field("due_at", now.Add(time.Hour)), field("points_possible", 10.0)}}},
+		"hw3": {{id: 13, fields: []FieldChange{field("due_at", now)}}},
+	}
+	entries := diffItems(src, tgt)
+	is.Equal(len(entries), 3)
+	is.Equal(entries[0].Kind, DiffMissing)
+	is.Equal(entries[0].Name, "hw2")
+	is.Equal(entries[1].Kind, DiffExtra)
+	is.Equal(entries[1].TargetID, 13)
+	is.Equal(entries[2].Kind, DiffChanged)
+	is.Equal(len(entries[2].Changes), 1)
+	is.Equal(entries[2].Changes[0].Field, "due_at")
+	is.Equal(entries[2].SourceID, 1)
+	is.Equal(entries[2].TargetID, 11)
+	is.Equal(len(diffItems(src, src)), 0)
+}
+
+func TestDiffItemsDuplicateNames(t *testing.T) {
+	is := is.New(t)
+	src := make(diffables)
+	src.add("Quiz", diffable{id: 1, fields: []FieldChange{field("points_possible", 5.0)}})
+	src.add("Quiz", diffable{id: 2, fields: []FieldChange{field("points_possible", 10.0)}})
+	tgt := make(diffables)
+	tgt.add("Quiz", diffable{id: 11, fields: []FieldChange{field("points_possible", 5.0)}})
+	tgt.add("Quiz", diffable{id: 12, fields: []FieldChange{field("points_possible", 20.0)}})
+	tgt.add("Quiz", diffable{id: 13, fields: []FieldChange{field("points_possible", 5.0)}})
+	entries := diffItems(src, tgt)
+	is.Equal(len(entries), 2)
+	is.Equal(entries[0].Kind, DiffExtra)
+	is.Equal(entries[0].Index, 2)
+	is.Equal(entries[0].TargetID, 13)
+	is.Equal(entries[1].Kind, DiffChanged)
+	is.Equal(entries[1].Index, 1)
+	is.Equal(entries[1].SourceID, 2)
+	is.Equal(entries[1].TargetID, 12)
+}
+
+func TestDiffablesOrder(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	// every page has an item named "Quiz" and the earlier pages are
+	// slower so that they would arrive last if they were not in order
+	paged := func(path string, item func(page int) string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			page, err := strconv.Atoi(r.URL.Query().Get("page"))
+			if err != nil {
+				page = 1
+			}
+			time.Sleep(time.Duration(4-page) * 10 * time.Millisecond)
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=3>; rel="last"`, DefaultHost, path))
+			fmt.Fprintf(w, `[%s,%s]`, item(page), item(page+10))
+		})
+	}
+	paged("/api/v1/courses/1/assignments", func(page int) string {
+		if page > 10 {
+			return fmt.Sprintf(`{"id":%d,"name":"hw%d"}`, page, page)
+		}
+		return fmt.Sprintf(`{"id":%d,"name":"Quiz"}`, page)
+	})
+	paged("/api/v1/courses/1/files", func(page int) string {
+		if page > 10 {
+			return fmt.Sprintf(`{"id":%d,"display_name":"f%d.txt"}`, page, page)
+		}
+		return fmt.Sprintf(`{"id":%d,"display_name":"notes.txt"}`, page)
+	})
+	ids := func(items []diffable) []int {
+		res := make([]int, len(items))
+		for i, item := range items {
+			res[i] = item.id
+		}
+		return res
+	}
+	c := &Course{ID: 1, client: client}
+	for i := 0; i < 3; i++ {
+		asses, err := assignmentDiffables(c)
+		is.NoErr(err)
+		is.Equal(ids(asses["Quiz"]), []int{1, 2, 3})
+		files, err := fileDiffables(c)
+		is.NoErr(err)
+		is.Equal(ids(files["notes.txt"]), []int{1, 2, 3})
+	}
+}
@@ -0,0 +1,183 @@
+package canvas
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/harrybrwn/go-canvas/store"
+)
+
+// ClientOption is used to configure a Canvas object when it is
+// created with New or WithHost.
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	// transport is nil when the default transport should be used
+	transport *http.Transport
+	// httpClient and roundTripper are set by
+	// the user and are never modified
+	httpClient   *http.Client
+	roundTripper http.RoundTripper
+	retries      int
+	retryWait    time.Duration
+	maxRetryWait time.Duration
+
+	rateLimitThreshold float64
+	hooks              Hooks
+	codec              Codec
+	timeout            time.Duration
+	concurrency        int
+	cache              store.Store
+}
+
+func newClientConfig(opts []ClientOption) *clientConfig {
+	conf := &clientConfig{
+		retryWait:          defaultRetryWait,
+		maxRetryWait:       defaultMaxRetryWait,
+		rateLimitThreshold: defaultRateLimitThreshold,
+	}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	return conf
+}
+
+// baseClient returns the http client that canvas requests are
+// sent with before authorization is added.
+func (cc *clientConfig) baseClient() http.Client {
+	var c http.Client
+	if cc.httpClient != nil {
+		c = *cc.httpClient
+	}
+	switch {
+	case cc.roundTripper != nil:
+		c.Transport = cc.roundTripper
+	case c.Transport == nil && cc.transport != nil:
+		c.Transport = cc.transport
+	}
+	if cc.timeout > 0 {
+		c.Timeout = cc.timeout
+	}
+	if cc.cache != nil {
+		rt := c.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		c.Transport = &cacheTransport{rt: rt, store: cc.cache}
+	}
+	return c
+}
+
+// WithClient will send requests using a copy of the given http client.
+// The client's transport, timeout, cookie jar, and redirect policy are
+// all used. Canvas objects created with the same client share its
+// connection pool. The client itself is never modified.
+//
+// The connection tuning options like WithMaxIdleConns only apply when
+// the client has no transport.
+func WithClient(c *http.Client) ClientOption {
+	return func(cc *clientConfig) {
+		cc.httpClient = c
+	}
+}
+
+// WithTransport will send requests using the given round tripper. This
+// can be used to add proxies, custom TLS configuration, or middleware
+// like tracing and logging. Canvas authorization is added on top of the
+// round tripper. WithTransport takes precedence over the transport of a
+// client given with WithClient and the connection tuning options have
+// no effect when it is used.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(cc *clientConfig) {
+		cc.roundTripper = rt
+	}
+}
+
+// tunedTransport returns a transport that is safe to modify. The
+// http.DefaultTransport is cloned the first time this is called so
+// that tuning one Canvas object does not change any others.
+func (cc *clientConfig) tunedTransport() *http.Transport {
+	if cc.transport == nil {
+		cc.transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	return cc.transport
+}
+
+// WithMaxIdleConns sets the maximum number of idle (keep-alive)
+// connections across all hosts. Zero means no limit.
+func WithMaxIdleConns(n int) ClientOption {
+	return func(cc *clientConfig) {
+		cc.tunedTransport().MaxIdleConns = n
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle (keep-alive)
+// connections kept for the canvas host. High volume services that
+// make lots of concurrent requests should raise this from the
+// default of 2.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(cc *clientConfig) {
+		cc.tunedTransport().MaxIdleConnsPerHost = n
+	}
+}
+
+// WithMaxConnsPerHost limits the total number of connections to
+// the canvas host, including those in use. Zero means no limit.
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(cc *clientConfig) {
+		cc.tunedTransport().MaxConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets the maximum amount of time an idle connection
+// will remain in the pool before closing itself.
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(cc *clientConfig) {
+		cc.tunedTransport().IdleConnTimeout = d
+	}
+}
+
+// WithDialTimeout sets the maximum amount of time
+// spent opening a connection to canvas.
+func WithDialTimeout(d time.Duration) ClientOption {
+	return func(cc *clientConfig) {
+		cc.tunedTransport().DialContext = (&net.Dialer{
+			Timeout:   d,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+}
+
+// WithTLSHandshakeTimeout sets the maximum amount of
+// time spent on the TLS handshake with canvas.
+func WithTLSHandshakeTimeout(d time.Duration) ClientOption {
+	return func(cc *clientConfig) {
+		cc.tunedTransport().TLSHandshakeTimeout = d
+	}
+}
+
+// WithResponseHeaderTimeout sets the maximum amount of time spent
+// waiting for canvas to start responding after a request is sent. It
+// does not limit the time spent reading the response body.
+func WithResponseHeaderTimeout(d time.Duration) ClientOption {
+	return func(cc *clientConfig) {
+		cc.tunedTransport().ResponseHeaderTimeout = d
+	}
+}
+
+// WithHTTP2 will enable or disable HTTP/2 for connections to canvas.
+// HTTP/2 is enabled by default.
+func WithHTTP2(enabled bool) ClientOption {
+	return func(cc *clientConfig) {
+		t := cc.tunedTransport()
+		t.ForceAttemptHTTP2 = enabled
+		if enabled {
+			t.TLSNextProto = nil
+		} else {
+			// A non-nil empty map disables HTTP/2
+			t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/harrybrwn/errs"
+)
+
+// Content migration types.
+const (
+	MigrationCourseCopy = "course_copy_importer"
+	MigrationZipFile    = "zip_file_importer"
+	MigrationCommonCart = "common_cartridge_importer"
+	MigrationCanvasCart = "canvas_cartridge_importer"
+	MigrationMoodle     = "moodle_converter"
+	MigrationQTI        = "qti_converter"
+)
+
+// MigrationWaitingForSelect is the workflow state of a selective
+// migration that is waiting for content to be selected.
+const MigrationWaitingForSelect = "waiting_for_select"
+
+// MigrationSourceCourse is an Option for course copy migrations
+// that sets the course that content is copied from.
+func MigrationSourceCourse(courseID int) Option {
+	return Opt("settings[source_course_id]", courseID)
+}
+
+// MigrationFolder is an Option for zip file migrations that sets
+// the folder that the zip file is unpacked into.
+func MigrationFolder(folderID int) Option {
+	return Opt("settings[folder_id]", folderID)
+}
+
+// SelectiveImport is an Option that stops a migration after its
+// content is read so that only part of it can be imported using
+// ContentMigration.SelectiveData and ContentMigration.Select.
+var SelectiveImport Option = Opt("selective_import", true)
+
+// ContentMigrations will list the course's content migrations.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.content_migrations.index
+func (c *Course) ContentMigrations(opts ...Option) (migrations []*ContentMigration, err error) {
+	ch := make(chan *ContentMigration)
+	errs := newPaginatedList(c.client, c.id("/courses/%d/content_migrations"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			m := &ContentMigration{client: c.client, courseID: c.ID}
+			if err := dec.Decode(m); err != nil {
+				return err
+			}
+			ch <- m
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case m := <-ch:
+			migrations = append(migrations, m)
+		case err, ok := <-errs:
+			if !ok {
+				return migrations, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// ContentMigration will get one of the course's content migrations.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.content_migrations.show
+func (c *Course) ContentMigration(id int) (*ContentMigration, error) {
+	m := &ContentMigration{client: c.client, courseID: c.ID}
+	return m, getjson(c.client, m, nil, "/courses/%d/content_migrations/%d", c.ID, id)
+}
+
+// CreateContentMigration will start a content migration into the course.
+// Settings are given as options like MigrationSourceCourse. Migrations
+// that need a file, like MigrationZipFile, should use
+// UploadContentMigration instead.
+//
+//	m, err := course.CreateContentMigration(
+//		canvas.MigrationCourseCopy,
+//		canvas.MigrationSourceCourse(1234),
+//	)
+//	err = m.Wait(ctx)
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.content_migrations.create
+func (c *Course) CreateContentMigration(migrationType string, settings ...Option) (*ContentMigration, error) {
+	q := params{"migration_type": {migrationType}}
+	q.Add(settings)
+	resp, err := post(c.client, c.id("/courses/%d/content_migrations"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	m := &ContentMigration{client: c.client, courseID: c.ID}
+	return m, decodeJSON(resp.Body, m)
+}
+
+// CopyCourse will copy all of the content from another course into
+// this one. The copy runs in the background.
+func (c *Course) CopyCourse(sourceCourseID int, opts ...Option) (*ContentMigration, error) {
+	return c.CreateContentMigration(MigrationCourseCopy, append([]Option{MigrationSourceCourse(sourceCourseID)}, opts...)...)
+}
+
+// UploadContentMigration will start a content migration that imports a
+// file, like a zip file or a course export. The file is uploaded to
+// canvas once the migration is created and the migration starts after
+// the upload finishes.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.content_migrations.create
+func (c *Course) UploadContentMigration(
+	ctx context.Context,
+	migrationType string,
+	filename string,
+	r io.Reader,
+	settings ...Option,
+) (*ContentMigration, error) {
+	if filename == "" {
+		return nil, errors.New("empty filename")
+	}
+	// the size must be sent before the file
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	up := newFileUploadParams(path.Base(filename), settings)
+	up.Size = len(b)
+	q := params{
+		"migration_type":       {migrationType},
+		"pre_attachment[name]": {up.Name},
+		"pre_attachment[size]": {strconv.Itoa(up.Size)},
+	}
+	if up.ContentType != "" {
+		q.Set("pre_attachment[content_type]", up.ContentType)
+	}
+	for _, o := range settings {
+		if _, ok := o.(*uploadOption); !ok {
+			q.Add([]Option{o})
+		}
+	}
+	resp, err := do(c.client, newreq("POST", c.id("/courses/%d/content_migrations"), q).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	m := &ContentMigration{client: c.client, courseID: c.ID}
+	var pre struct {
+		Attachment json.RawMessage `json:"pre_attachment"`
+	}
+	if err = errs.Pair(json.Unmarshal(body, m), json.Unmarshal(body, &pre)); err != nil {
+		return nil, err
+	}
+	if len(pre.Attachment) == 0 {
+		return nil, errors.New("no pre_attachment returned for content migration")
+	}
+	var preErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(pre.Attachment, &preErr) == nil && preErr.Message != "" {
+		return nil, fmt.Errorf("content migration upload: %s", preErr.Message)
+	}
+	uploader, err := decodeUploader(bytes.NewReader(pre.Attachment))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = uploader.upload(ctx, c.client, up, bytes.NewReader(b), ""); err != nil {
+		return nil, err
+	}
+	return m, m.Refresh()
+}
+
+// ContentMigration is an import of content into a course.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html
+type ContentMigration struct {
+	ID                 int       `json:"id"`
+	MigrationType      string    `json:"migration_type"`
+	MigrationTypeTitle string    `json:"migration_type_title"`
+	MigrationIssuesURL string    `json:"migration_issues_url"`
+	ProgressURL        string    `json:"progress_url"`
+	UserID             int       `json:"user_id"`
+	WorkflowState      string    `json:"workflow_state"`
+	StartedAt          time.Time `json:"started_at"`
+	FinishedAt         time.Time `json:"finished_at"`
+	Attachment         *struct {
+		URL string `json:"url"`
+	} `json:"attachment"`
+
+	client   doer
+	courseID int
+}
+
+// Refresh will get the latest state of the migration.
+func (m *ContentMigration) Refresh() error {
+	return getjson(m.client, m, nil, "%s", m.path(""))
+}
+
+// Progress returns the progress of the migration.
+//
+// https://canvas.instructure.com/doc/api/progress.html#method.progress.show
+func (m *ContentMigration) Progress() (*Progress, error) {
+	if m.ProgressURL == "" {
+		return nil, errors.New("content migration has no progress url")
+	}
+	id, err := strconv.Atoi(path.Base(m.ProgressURL))
+	if err != nil {
+		return nil, fmt.Errorf("bad progress url %q: %w", m.ProgressURL, err)
+	}
+	p := &Progress{ID: id, client: m.client}
+	return p, p.Refresh()
+}
+
+// Wait will block until the migration is finished or the context
+// is cancelled. An error is returned if the migration failed.
+// Selective imports are finished once their content can be
+// selected.
+func (m *ContentMigration) Wait(ctx context.Context) error {
+	p, err := m.Progress()
+	if err != nil {
+		return err
+	}
+	err = poll(ctx, func() bool {
+		return p.Done() || m.WorkflowState == MigrationWaitingForSelect
+	}, func() error {
+		return errs.Pair(p.Refresh(), m.Refresh())
+	})
+	if err != nil {
+		return err
+	}
+	return p.Err()
+}
+
+// Issues will list the problems that were found while
+// importing the migration's content.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.migration_issues.index
+func (m *ContentMigration) Issues(opts ...Option) (issues []*MigrationIssue, err error) {
+	ch := make(chan *MigrationIssue)
+	errs := newPaginatedList(m.client, m.path("/migration_issues"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			issue := &MigrationIssue{client: m.client, path: m.path("/migration_issues")}
+			if err := dec.Decode(issue); err != nil {
+				return err
+			}
+			ch <- issue
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case issue := <-ch:
+			issues = append(issues, issue)
+		case err, ok := <-errs:
+			if !ok {
+				return issues, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// SelectiveData will list the content that can be imported by
+// a selective import. Options like Opt("type", "assignments")
+// will list the items of one type.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.content_migrations.content_list
+func (m *ContentMigration) SelectiveData(opts ...Option) ([]*MigrationItem, error) {
+	items := make([]*MigrationItem, 0)
+	return items, getjson(m.client, &items, optEnc(opts), "%s", m.path("/selective_data"))
+}
+
+// Select will import the given items of a selective import.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.content_migrations.update
+func (m *ContentMigration) Select(items ...*MigrationItem) error {
+	q := params{}
+	for _, item := range items {
+		q.Set(item.Property, "1")
+	}
+	resp, err := put(m.client, m.path(""), q)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, m)
+}
+
+func (m *ContentMigration) path(s string) string {
+	return fmt.Sprintf("/courses/%d/content_migrations/%d%s", m.courseID, m.ID, s)
+}
+
+// MigrationItem is content that can be imported by a
+// selective content migration.
+type MigrationItem struct {
+	Type string `json:"type"`
+	// Property is the parameter used to select the item,
+	// for example "copy[all_assignments]".
+	Property    string           `json:"property"`
+	Title       string           `json:"title"`
+	Count       int              `json:"count"`
+	SubItemsURL string           `json:"sub_items_url"`
+	SubItems    []*MigrationItem `json:"sub_items"`
+	MigrationID string           `json:"migration_id"`
+}
+
+// MigrationIssue is a problem found while importing content.
+type MigrationIssue struct {
+	ID                  int       `json:"id"`
+	Description         string    `json:"description"`
+	WorkflowState       string    `json:"workflow_state"`
+	FixIssueHTMLURL     string    `json:"fix_issue_html_url"`
+	IssueType           string    `json:"issue_type"`
+	ErrorReportHTMLURL  string    `json:"error_report_html_url"`
+	ErrorMessage        string    `json:"error_message"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	ContentMigrationURL string    `json:"content_migration_url"`
+
+	client doer
+	path   string
+}
+
+// Resolve will mark the issue as resolved.
+//
+// https://canvas.instructure.com/doc/api/content_migrations.html#method.migration_issues.update
+func (mi *MigrationIssue) Resolve() error {
+	resp, err := put(mi.client, fmt.Sprintf("%s/%d", mi.path, mi.ID), params{
+		"workflow_state": {"resolved"},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, mi)
+}
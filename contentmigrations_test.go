@@ -0,0 +1,125 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestContentMigrations(t *testing.T) {
+	is := is.New(t)
+	defer func(d time.Duration) { progressPollInterval = d }(progressPollInterval)
+	progressPollInterval = time.Millisecond
+	client, mux, server := testServer()
+	defer server.Close()
+	course := &Course{ID: 1, client: client}
+	var (
+		uploaded string
+		polls    int
+	)
+	mux.HandleFunc("/api/v1/courses/1/content_migrations", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/content_migrations?page=1>; rel="last"`, DefaultHost))
+			fmt.Fprint(w, `[{"id":2,"migration_type":"course_copy_importer","workflow_state":"completed"}]`)
+		case "POST":
+			switch q.Get("migration_type") {
+			case MigrationCourseCopy:
+				if q.Get("settings[source_course_id]") != "9" || q.Get("selective_import") != "true" {
+					t.Errorf("wrong course copy: %v", q)
+				}
+				fmt.Fprint(w, `{"id":2,"migration_type":"course_copy_importer","workflow_state":"running",
+					"progress_url":"https://canvas.instructure.com/api/v1/progress/5"}`)
+			case MigrationZipFile:
+				if q.Get("pre_attachment[name]") != "content.zip" || q.Get("pre_attachment[size]") != "4" ||
+					q.Get("settings[folder_id]") != "3" {
+					t.Errorf("wrong zip import: %v", q)
+				}
+				fmt.Fprint(w, `{"id":3,"migration_type":"zip_file_importer","workflow_state":"pre_processing",
+					"pre_attachment":{"upload_url":"https://uploads.example.com/migration_upload","file_param":"file","upload_params":{"key":"value"}}}`)
+			default:
+				t.Errorf("wrong migration type: %v", q)
+			}
+		}
+	})
+	mux.HandleFunc("/migration_upload", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("key") != "value" {
+			t.Error("upload params not sent")
+		}
+		f, _, err := r.FormFile("file")
+		is.NoErr(err)
+		b, _ := ioutil.ReadAll(f)
+		uploaded = string(b)
+		fmt.Fprint(w, `{"id":10,"display_name":"content.zip"}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/content_migrations/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"migration_type":"zip_file_importer","workflow_state":"running"}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/content_migrations/2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":2,"workflow_state":"waiting_for_select","progress_url":"https://canvas.instructure.com/api/v1/progress/5"}`)
+		case "PUT":
+			q := r.URL.Query()
+			if q.Get("copy[all_assignments]") != "1" || q.Get("copy[all_quizzes]") != "1" {
+				t.Errorf("wrong selection: %v", q)
+			}
+			fmt.Fprint(w, `{"id":2,"workflow_state":"running","progress_url":"https://canvas.instructure.com/api/v1/progress/5"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/progress/5", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		fmt.Fprint(w, `{"id":5,"workflow_state":"running","completion":10}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/content_migrations/2/selective_data", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"type":"assignments","property":"copy[all_assignments]","title":"Assignments","count":4},
+			{"type":"quizzes","property":"copy[all_quizzes]","title":"Quizzes","count":1}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/content_migrations/2/migration_issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/content_migrations/2/migration_issues?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":7,"description":"missing file","workflow_state":"active","issue_type":"warning"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/content_migrations/2/migration_issues/7", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PUT")
+		fmt.Fprintf(w, `{"id":7,"workflow_state":%q}`, r.URL.Query().Get("workflow_state"))
+	})
+
+	migrations, err := course.ContentMigrations()
+	is.NoErr(err)
+	is.Equal(len(migrations), 1)
+
+	m, err := course.CopyCourse(9, SelectiveImport)
+	is.NoErr(err)
+	is.Equal(m.ID, 2)
+	is.NoErr(m.Wait(context.Background()))
+	is.Equal(m.WorkflowState, MigrationWaitingForSelect)
+	is.True(polls > 0)
+
+	items, err := m.SelectiveData()
+	is.NoErr(err)
+	is.Equal(len(items), 2)
+	is.NoErr(m.Select(items...))
+	is.Equal(m.WorkflowState, "running")
+
+	issues, err := m.Issues()
+	is.NoErr(err)
+	is.Equal(len(issues), 1)
+	is.NoErr(issues[0].Resolve())
+	is.Equal(issues[0].WorkflowState, "resolved")
+
+	m, err = course.UploadContentMigration(
+		context.Background(), MigrationZipFile, "content.zip",
+		strings.NewReader("data"), MigrationFolder(3),
+	)
+	is.NoErr(err)
+	is.Equal(uploaded, "data")
+	is.Equal(m.ID, 3)
+	is.Equal(m.WorkflowState, "running")
+}
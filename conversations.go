@@ -0,0 +1,290 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Conversation scopes.
+const (
+	ConversationsUnread   = "unread"
+	ConversationsStarred  = "starred"
+	ConversationsArchived = "archived"
+	ConversationsSent     = "sent"
+)
+
+// ConversationScope is an Option that will only list conversations in
+// the scope given, one of ConversationsUnread, ConversationsStarred,
+// ConversationsArchived, or ConversationsSent.
+func ConversationScope(scope string) Option {
+	return Opt("scope", scope)
+}
+
+// ConversationFilter is an Option that will only list conversations
+// for the given courses, groups, or users. Filters are context codes
+// like "course_123" or "user_456".
+func ConversationFilter(contextCodes ...string) Option {
+	return ArrayOpt("filter", contextCodes...)
+}
+
+// Conversations returns a list of conversations in the current
+// user's inbox. Use ConversationScope and ConversationFilter to
+// narrow down the list.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.index
+func (c *Canvas) Conversations(opts ...Option) (conversations []Conversation, err error) {
+	ch := make(chan *Conversation)
+	errs := newPaginatedList(c.client, "/conversations", func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			conv := &Conversation{client: c.client}
+			if err := dec.Decode(conv); err != nil {
+				return err
+			}
+			ch <- conv
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case conv := <-ch:
+			conversations = append(conversations, *conv)
+		case err, ok := <-errs:
+			if !ok {
+				return conversations, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// Conversations returns a list of conversations
+func Conversations(opts ...Option) ([]Conversation, error) {
+	return ca.Conversations(opts...)
+}
+
+// Conversation will get a conversation along with its messages. Getting
+// a conversation marks it as read unless Opt("auto_mark_as_read", false)
+// is used.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.show
+func (c *Canvas) Conversation(id int, opts ...Option) (*Conversation, error) {
+	conv := &Conversation{client: c.client}
+	return conv, getjson(c.client, conv, optEnc(opts), "/conversations/%d", id)
+}
+
+// SendMessage will start a new conversation. Recipients can be user
+// ids or context codes like "course_123" or "group_456". Canvas may
+// start more than one conversation when there are many recipients
+// and Opt("group_conversation", false) is used.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.create
+func (c *Canvas) SendMessage(recipients []string, subject, body string, opts ...Option) ([]Conversation, error) {
+	q := params{
+		"recipients[]": recipients,
+		"body":         {body},
+	}
+	if subject != "" {
+		q.Set("subject", subject)
+	}
+	q.Add(opts)
+	resp, err := post(c.client, "/conversations", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var convs []Conversation
+	if err = decodeJSON(resp.Body, &convs); err != nil {
+		return nil, err
+	}
+	for i := range convs {
+		convs[i].client = c.client
+	}
+	return convs, nil
+}
+
+// SendMessage will start a new conversation.
+func SendMessage(recipients []string, subject, body string, opts ...Option) ([]Conversation, error) {
+	return ca.SendMessage(recipients, subject, body, opts...)
+}
+
+// UnreadCount returns the number of unread conversations
+// for the current user.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.unread_count
+func (c *Canvas) UnreadCount() (int, error) {
+	var res struct {
+		// canvas sends the count as a string
+		Count json.Number `json:"unread_count"`
+	}
+	if err := getjson(c.client, &res, nil, "/conversations/unread_count"); err != nil {
+		return 0, err
+	}
+	n, err := res.Count.Int64()
+	return int(n), err
+}
+
+// UnreadCount returns the number of unread conversations
+// for the current user.
+func UnreadCount() (int, error) {
+	return ca.UnreadCount()
+}
+
+// Conversation batch update events.
+const (
+	ConversationMarkAsRead   = "mark_as_read"
+	ConversationMarkAsUnread = "mark_as_unread"
+	ConversationStar         = "star"
+	ConversationUnstar       = "unstar"
+	ConversationArchive      = "archive"
+	ConversationDestroy      = "destroy"
+)
+
+// BatchUpdateConversations will apply an event like ConversationArchive
+// to many conversations at once. Canvas does this in the background and
+// the returned Progress can be used to wait for it.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.batch_update
+func (c *Canvas) BatchUpdateConversations(event string, ids ...int) (*Progress, error) {
+	q := params{"event": {event}}
+	for _, id := range ids {
+		q["conversation_ids[]"] = append(q["conversation_ids[]"], strconv.Itoa(id))
+	}
+	resp, err := put(c.client, "/conversations", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	p := &Progress{client: c.client}
+	return p, decodeJSON(resp.Body, p)
+}
+
+// BatchUpdateConversations will apply an event to many conversations at once.
+func BatchUpdateConversations(event string, ids ...int) (*Progress, error) {
+	return ca.BatchUpdateConversations(event, ids...)
+}
+
+// DeleteConversations will delete many conversations at once.
+func (c *Canvas) DeleteConversations(ids ...int) (*Progress, error) {
+	return c.BatchUpdateConversations(ConversationDestroy, ids...)
+}
+
+// Conversation is a conversation.
+type Conversation struct {
+	ID               int                   `json:"id"`
+	Subject          string                `json:"subject"`
+	WorkflowState    string                `json:"workflow_state"`
+	LastMessage      string                `json:"last_message"`
+	StartAt          time.Time             `json:"start_at"`
+	MessageCount     int                   `json:"message_count"`
+	Subscribed       bool                  `json:"subscribed"`
+	Private          bool                  `json:"private"`
+	Starred          bool                  `json:"starred"`
+	Properties       interface{}           `json:"properties"`
+	Audience         interface{}           `json:"audience"`
+	AudienceContexts interface{}           `json:"audience_contexts"`
+	AvatarURL        string                `json:"avatar_url"`
+	Participants     interface{}           `json:"participants"`
+	Visible          bool                  `json:"visible"`
+	ContextName      string                `json:"context_name"`
+	Messages         []ConversationMessage `json:"messages"`
+
+	client doer
+}
+
+// ConversationMessage is a message in a conversation.
+type ConversationMessage struct {
+	ID                int                   `json:"id"`
+	CreatedAt         time.Time             `json:"created_at"`
+	Body              string                `json:"body"`
+	AuthorID          int                   `json:"author_id"`
+	Generated         bool                  `json:"generated"`
+	MediaComment      interface{}           `json:"media_comment"`
+	ForwardedMessages []ConversationMessage `json:"forwarded_messages"`
+	Attachments       []*File               `json:"attachments"`
+}
+
+// ListMessages will get the conversation's messages, newest first.
+// This marks the conversation as read.
+func (c *Conversation) ListMessages(opts ...Option) ([]ConversationMessage, error) {
+	conv := &Conversation{}
+	err := getjson(c.client, conv, optEnc(opts), "/conversations/%d", c.ID)
+	if err != nil {
+		return nil, err
+	}
+	c.Messages = conv.Messages
+	return conv.Messages, nil
+}
+
+// AddMessage will add a message to the conversation. The
+// conversation returned only has the new message.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.add_message
+func (c *Conversation) AddMessage(body string, opts ...Option) (*Conversation, error) {
+	q := params{"body": {body}}
+	q.Add(opts)
+	resp, err := post(c.client, fmt.Sprintf("/conversations/%d/add_message", c.ID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	conv := &Conversation{client: c.client}
+	return conv, decodeJSON(resp.Body, conv)
+}
+
+// MarkRead will mark the conversation as read.
+func (c *Conversation) MarkRead() error {
+	return c.update(Opt("conversation[workflow_state]", "read"))
+}
+
+// MarkUnread will mark the conversation as unread.
+func (c *Conversation) MarkUnread() error {
+	return c.update(Opt("conversation[workflow_state]", "unread"))
+}
+
+// Archive will archive the conversation.
+func (c *Conversation) Archive() error {
+	return c.update(Opt("conversation[workflow_state]", "archived"))
+}
+
+// Star will star the conversation.
+func (c *Conversation) Star() error {
+	return c.update(Opt("conversation[starred]", true))
+}
+
+// Unstar will remove the star from the conversation.
+func (c *Conversation) Unstar() error {
+	return c.update(Opt("conversation[starred]", false))
+}
+
+// Delete will delete all of the conversation's messages
+// for the current user.
+//
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.destroy
+func (c *Conversation) Delete() error {
+	resp, err := delete(c.client, fmt.Sprintf("/conversations/%d", c.ID), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// https://canvas.instructure.com/doc/api/conversations.html#method.conversations.update
+func (c *Conversation) update(opts ...Option) error {
+	resp, err := put(c.client, fmt.Sprintf("/conversations/%d", c.ID), optEnc(opts))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	messages := c.Messages
+	if err = decodeJSON(resp.Body, c); err != nil {
+		return err
+	}
+	if c.Messages == nil {
+		c.Messages = messages
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestConversations(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: client}
+	mux.HandleFunc("/api/v1/conversations", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			if q.Get("scope") != ConversationsUnread || q.Get("filter[]") != "course_1" {
+				t.Errorf("wrong filters: %v", q)
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/conversations?page=1>; rel="last"`, DefaultHost))
+			fmt.Fprint(w, `[{"id":1,"subject":"hi","workflow_state":"unread"}]`)
+		case "POST":
+			if len(q["recipients[]"]) != 2 || q.Get("subject") != "Reminder" || q.Get("body") != "hw is due" {
+				t.Errorf("wrong message: %v", q)
+			}
+			fmt.Fprint(w, `[{"id":2,"subject":"Reminder"}]`)
+		case "PUT":
+			if q.Get("event") != ConversationDestroy || len(q["conversation_ids[]"]) != 2 {
+				t.Errorf("wrong batch update: %v", q)
+			}
+			fmt.Fprint(w, `{"id":9,"workflow_state":"queued"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/conversations/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":1,"messages":[{"id":3,"body":"second"},{"id":2,"body":"first"}]}`)
+		case "PUT":
+			q := r.URL.Query()
+			if q.Get("conversation[workflow_state]") == "archived" {
+				fmt.Fprint(w, `{"id":1,"workflow_state":"archived"}`)
+				return
+			}
+			if q.Get("conversation[starred]") != "true" {
+				t.Errorf("wrong update: %v", q)
+			}
+			fmt.Fprint(w, `{"id":1,"workflow_state":"archived","starred":true}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/conversations/1/add_message", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":1,"messages":[{"id":4,"body":%q}]}`, r.URL.Query().Get("body"))
+	})
+	mux.HandleFunc("/api/v1/conversations/unread_count", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"unread_count":"7"}`)
+	})
+
+	convs, err := c.Conversations(ConversationScope(ConversationsUnread), ConversationFilter("course_1"))
+	is.NoErr(err)
+	is.Equal(len(convs), 1)
+	conv := &convs[0]
+	msgs, err := conv.ListMessages()
+	is.NoErr(err)
+	is.Equal(len(msgs), 2)
+	is.Equal(msgs[1].Body, "first")
+
+	reply, err := conv.AddMessage("thanks")
+	is.NoErr(err)
+	is.Equal(reply.Messages[0].Body, "thanks")
+
+	is.NoErr(conv.Archive())
+	is.Equal(conv.WorkflowState, "archived")
+	is.NoErr(conv.Star())
+	is.True(conv.Starred)
+	is.Equal(len(conv.Messages), 2) // messages are kept after updates
+
+	sent, err := c.SendMessage([]string{"7", "8"}, "Reminder", "hw is due")
+	is.NoErr(err)
+	is.Equal(sent[0].ID, 2)
+
+	n, err := c.UnreadCount()
+	is.NoErr(err)
+	is.Equal(n, 7)
+	p, err := c.DeleteConversations(1, 2)
+	is.NoErr(err)
+	is.Equal(p.ID, 9)
+	is.Equal(p.WorkflowState, ProgressQueued)
+}
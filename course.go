@@ -1,12 +1,14 @@
 package canvas
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/harrybrwn/errs"
@@ -91,6 +93,28 @@ type Course struct {
 	errorHandler errorHandlerFunc
 }
 
+// IsActive returns true if the course is published and has not
+// been concluded or deleted.
+func (c *Course) IsActive() bool {
+	return c.Workflow() == WorkflowAvailable
+}
+
+// IsPublished returns true if the course has been published.
+func (c *Course) IsPublished() bool {
+	return c.Workflow().Published()
+}
+
+// IsConcluded returns true if the course has been concluded.
+func (c *Course) IsConcluded() bool {
+	return c.Workflow() == WorkflowCompleted
+}
+
+// Workflow returns the course's workflow state so that it
+// can be compared with the WorkflowState constants.
+func (c *Course) Workflow() WorkflowState {
+	return WorkflowState(c.WorkflowState)
+}
+
 // ContextCode will return the context code for this specific course.
 func (c *Course) ContextCode() string {
 	return fmt.Sprintf("course_%d", c.ID)
@@ -215,7 +239,8 @@ type Permissions struct {
 }
 
 // UpdateSettings will update a user's settings based on a given settings struct and
-// will return the updated settings struct.
+// will return the updated settings struct. Every setting is sent, including the
+// ones left as false or zero, use UpdateSettingsPartial to only change some of them.
 func (c *Course) UpdateSettings(settings *CourseSettings) (*CourseSettings, error) {
 	m := make(map[string]interface{})
 	raw, err := json.Marshal(settings)
@@ -232,9 +257,49 @@ func (c *Course) UpdateSettings(settings *CourseSettings) (*CourseSettings, erro
 	}
 	defer resp.Body.Close()
 	s := CourseSettings{}
-	return &s, json.NewDecoder(resp.Body).Decode(&s)
+	return &s, decodeJSON(resp.Body, &s)
 }
 
+// UpdateSettingsPartial will only change the settings that are set in the
+// patch and will return the updated settings. Unlike UpdateSettings, the
+// settings that are left as nil are not sent.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.update_settings
+func (c *Course) UpdateSettingsPartial(changes CourseSettingsPatch) (*CourseSettings, error) {
+	q, err := query.Values(&changes)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := put(c.client, c.id("/courses/%d/settings"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	s := CourseSettings{}
+	return &s, decodeJSON(resp.Body, &s)
+}
+
+// CourseSettingsPatch is a set of changes to a course's settings. Only
+// the fields that are not nil are changed. Use Bool and Int to set them.
+type CourseSettingsPatch struct {
+	AllowStudentDiscussionTopics  *bool `url:"allow_student_discussion_topics,omitempty"`
+	AllowStudentForumAttachments  *bool `url:"allow_student_forum_attachments,omitempty"`
+	AllowStudentDiscussionEditing *bool `url:"allow_student_discussion_editing,omitempty"`
+	GradingStandardEnabled        *bool `url:"grading_standard_enabled,omitempty"`
+	GradingStandardID             *int  `url:"grading_standard_id,omitempty"`
+	AllowStudentOrganizedGroups   *bool `url:"allow_student_organized_groups,omitempty"`
+	HideFinalGrades               *bool `url:"hide_final_grades,omitempty"`
+	HideDistributionGraphs        *bool `url:"hide_distribution_graphs,omitempty"`
+	LockAllAnnouncements          *bool `url:"lock_all_announcements,omitempty"`
+	UsageRightsRequired           *bool `url:"usage_rights_required,omitempty"`
+}
+
+// Bool returns a pointer to b.
+func Bool(b bool) *bool { return &b }
+
+// Int returns a pointer to i.
+func Int(i int) *int { return &i }
+
 // CourseSettings is a json struct for a course's settings.
 type CourseSettings struct {
 	AllowStudentDiscussionTopics  bool `json:"allow_student_discussion_topics"`
@@ -266,6 +331,15 @@ func (c *Course) User(id int, opts ...Option) (*User, error) {
 	return u, getjson(c.client, u, optEnc(opts), "/courses/%d/users/%d", c.ID, id)
 }
 
+// UserProgress will get a user's progress through the course's module
+// requirements. Courses without module requirements have no progress.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.user_progress
+func (c *Course) UserProgress(userID int) (*CourseProgress, error) {
+	p := &CourseProgress{}
+	return p, getjson(c.client, p, nil, "/courses/%d/users/%d/progress", c.ID, userID)
+}
+
 // Assignment will get an assignment from the course given an id.
 //
 // https://canvas.instructure.com/doc/api/assignments.html#method.assignments_api.index
@@ -280,21 +354,32 @@ func (c *Course) Assignment(id int, opts ...Option) (ass *Assignment, err error)
 func (c *Course) Assignments(opts ...Option) <-chan *Assignment {
 	ch := make(assignmentChan)
 	pages := c.assignmentspager(ch, opts)
-	go handleErrs(pages.start(), ch, c.errorHandler)
+	go handleErrs(pages, ch, c.errorHandler)
 	return ch
 }
 
 // ListAssignments will get all the course assignments and put them in a slice.
 func (c *Course) ListAssignments(opts ...Option) (asses []*Assignment, err error) {
+	return c.listAssignments(context.Background(), opts)
+}
+
+// listAssignments lists the assignments and stops
+// when the context is cancelled.
+func (c *Course) listAssignments(ctx context.Context, opts []Option) (asses []*Assignment, err error) {
 	ch := make(assignmentChan)
 	pages := c.assignmentspager(ch, opts)
+	pages.ctx = ctx
 	errs := pages.start()
+	var errl []error
 	for {
 		select {
 		case as := <-ch:
 			asses = append(asses, as)
-		case err = <-errs:
-			return asses, err
+		case err, ok := <-errs:
+			if !ok {
+				return asses, joinErrs(errl)
+			}
+			errl = append(errl, err)
 		}
 	}
 }
@@ -311,7 +396,7 @@ func (c *Course) CreateAssignment(a Assignment, opts ...Option) (*Assignment, er
 	}
 	defer resp.Body.Close()
 	as := &Assignment{}
-	return as, json.NewDecoder(resp.Body).Decode(as)
+	return as, decodeJSON(resp.Body, as)
 }
 
 // DeleteAssignment will delete an assignment
@@ -327,7 +412,7 @@ func (c *Course) DeleteAssignmentByID(id int) (*Assignment, error) {
 	}
 	defer resp.Body.Close()
 	a := &Assignment{}
-	return a, json.NewDecoder(resp.Body).Decode(&a)
+	return a, decodeJSON(resp.Body, &a)
 }
 
 // EditAssignment will edit the assignment given. Returns the new edited assignment.
@@ -344,7 +429,7 @@ func (c *Course) EditAssignment(a *Assignment) (*Assignment, error) {
 	}
 	defer resp.Body.Close()
 	newas := &Assignment{}
-	return newas, json.NewDecoder(resp.Body).Decode(newas)
+	return newas, decodeJSON(resp.Body, newas)
 }
 
 // GradingType is a grading type
@@ -365,6 +450,57 @@ const (
 	NotGraded GradingType = "not_graded"
 )
 
+// WorkflowState is the lifecycle state of a course, assignment,
+// page, or module. The WorkflowState fields are plain strings, use
+// the Workflow methods to get their states as a WorkflowState.
+type WorkflowState string
+
+const (
+	// WorkflowUnpublished is the state of unpublished objects.
+	WorkflowUnpublished WorkflowState = "unpublished"
+	// WorkflowClaimed is the state of a course that has been created
+	// but not yet published.
+	WorkflowClaimed WorkflowState = "claimed"
+	// WorkflowAvailable is the state of a published course.
+	WorkflowAvailable WorkflowState = "available"
+	// WorkflowPublished is the state of a published assignment.
+	WorkflowPublished WorkflowState = "published"
+	// WorkflowActive is the state of published pages and modules.
+	WorkflowActive WorkflowState = "active"
+	// WorkflowCompleted is the state of a concluded course.
+	WorkflowCompleted WorkflowState = "completed"
+	// WorkflowDeleted is the state of a deleted object.
+	WorkflowDeleted WorkflowState = "deleted"
+)
+
+// Published returns true if the workflow state is one that
+// students are able to see.
+func (ws WorkflowState) Published() bool {
+	switch ws {
+	case WorkflowAvailable, WorkflowPublished, WorkflowActive, WorkflowCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Deleted returns true if the object has been deleted.
+func (ws WorkflowState) Deleted() bool {
+	return ws == WorkflowDeleted
+}
+
+// workflow converts a workflow_state field to a WorkflowState and
+// falls back to the published field if the state is empty.
+func workflow(state string, published bool, publishedState WorkflowState) WorkflowState {
+	if state != "" {
+		return WorkflowState(state)
+	}
+	if published {
+		return publishedState
+	}
+	return WorkflowUnpublished
+}
+
 type assignmentOptions struct {
 	Assignment `url:"assignment"`
 }
@@ -391,7 +527,7 @@ type Assignment struct {
 	VericiteEnabled                bool              `json:"vericite_enabled" url:"vericite_enabled,omitempty"`
 	TurnitinSettings               *TurnitinSettings `json:"turnitin_settings" url:"turnitin_settings,omitempty"`
 	GradeGroupStudentsIndividually bool              `json:"grade_group_students_individually" url:"grade_group_students_individually,omitempty"`
-	ExternalToolTagAttributes      interface{}       `json:"external_tool_tag_attributes" url:"external_tool_tag_attributes,omitempty"`
+	ExternalToolTagAttributes      *ExternalToolTag  `json:"external_tool_tag_attributes" url:"external_tool_tag_attributes,omitempty"`
 	PeerReviews                    bool              `json:"peer_reviews" url:"peer_reviews,omitempty"`
 	AutomaticPeerReviews           bool              `json:"automatic_peer_reviews" url:"automatic_peer_reviews,omitempty"`
 	GroupCategoryID                int               `json:"group_category_id" url:"group_category_id,omitempty"`
@@ -400,22 +536,23 @@ type Assignment struct {
 	IntegrationData                map[string]string `json:"integration_data" url:"integration_data,omitempty"`
 	NotifyOfUpdate                 bool              `json:"notify_of_update,omitempty" url:"notify_of_update,omitempty"`
 	PointsPossible                 float64           `json:"points_possible" url:"points_possible,omitempty"`
-	SubmissionTypes                []string          `json:"submission_types" url:"submission_types,brakets,omitempty"`
+	SubmissionTypes                []string          `json:"submission_types" url:"submission_types,brackets,omitempty"`
 	GradingType                    GradingType       `json:"grading_type" url:"grading_type,omitempty"`
 	GradingStandardID              interface{}       `json:"grading_standard_id" url:"grading_standard_id,omitempty"`
 	Published                      bool              `json:"published" url:"published,omitempty"`
 	SisAssignmentID                string            `json:"sis_assignment_id" url:"sis_assignment_id,omitempty"`
-
-	PeerReviewCount            int         `json:"peer_review_count" url:"-"`
-	AllDates                   interface{} `json:"all_dates" url:"-"`
-	CourseID                   int         `json:"course_id" url:"-"`
-	HTMLURL                    string      `json:"html_url" url:"-"`
-	SubmissionsDownloadURL     string      `json:"submissions_download_url" url:"-"`
-	DueDateRequired            bool        `json:"due_date_required" url:"-"`
-	MaxNameLength              int         `json:"max_name_length" url:"-"`
-	PeerReviewsAssignAt        time.Time   `json:"peer_reviews_assign_at" url:"-"`
-	IntraGroupPeerReviews      bool        `json:"intra_group_peer_reviews" url:"-"`
-	NeedsGradingCount          int         `json:"needs_grading_count" url:"-"`
+	WorkflowState                  string            `json:"workflow_state" url:"-"`
+
+	PeerReviewCount            int              `json:"peer_review_count" url:"-"`
+	AllDates                   []AssignmentDate `json:"all_dates" url:"-"`
+	CourseID                   int              `json:"course_id" url:"-"`
+	HTMLURL                    string           `json:"html_url" url:"-"`
+	SubmissionsDownloadURL     string           `json:"submissions_download_url" url:"-"`
+	DueDateRequired            bool             `json:"due_date_required" url:"-"`
+	MaxNameLength              int              `json:"max_name_length" url:"-"`
+	PeerReviewsAssignAt        time.Time        `json:"peer_reviews_assign_at" url:"-"`
+	IntraGroupPeerReviews      bool             `json:"intra_group_peer_reviews" url:"-"`
+	NeedsGradingCount          int              `json:"needs_grading_count" url:"-"`
 	NeedsGradingCountBySection []struct {
 		SectionID         string `json:"section_id" url:"-"`
 		NeedsGradingCount int    `json:"needs_grading_count" url:"-"`
@@ -438,6 +575,9 @@ type Assignment struct {
 	Rubric                  []RubricCriteria `json:"rubric" url:"-"`
 	AssignmentVisibility    []int            `json:"assignment_visibility" url:"-"`
 	PostManually            bool             `json:"post_manually" url:"-"`
+	// Statistics is only set when the assignment is fetched
+	// with IncludeScoreStatistics, see ScoreStatistics.
+	Statistics *ScoreStatistics `json:"score_statistics" url:"-"`
 
 	OmitFromFinalGrade              bool `json:"omit_from_final_grade" url:"omit_from_final_grade,omitempty"`
 	ModeratedGrading                bool `json:"moderated_grading" url:"moderated_grading,omitempty"`
@@ -453,11 +593,33 @@ type Assignment struct {
 	client     doer
 }
 
+// IsPublished returns true if the assignment has been published.
+func (a *Assignment) IsPublished() bool {
+	return a.Workflow().Published()
+}
+
+// Workflow returns the assignment's workflow state. When the state
+// was not sent it is found using Assignment.Published.
+func (a *Assignment) Workflow() WorkflowState {
+	return workflow(a.WorkflowState, a.Published, WorkflowPublished)
+}
+
+func (a *Assignment) setclient(d doer) {
+	a.client = d
+}
+
 // SubmitFile will submit the contents of an io.Reader as
 // a file to the assignment.
 //
 // https://canvas.instructure.com/doc/api/submissions.html#method.submissions.create
 func (a *Assignment) SubmitFile(filename string, r io.Reader, opts ...Option) (*File, error) {
+	return a.SubmitFileContext(context.Background(), filename, r, opts...)
+}
+
+// SubmitFileContext is the same as SubmitFile except that the upload
+// is aborted if the context is cancelled. Use UploadProgress to keep
+// track of how much of the file has been sent.
+func (a *Assignment) SubmitFileContext(ctx context.Context, filename string, r io.Reader, opts ...Option) (*File, error) {
 	if filename == "" {
 		if named, ok := r.(interface{ Name() string }); ok {
 			filename = named.Name()
@@ -476,7 +638,7 @@ func (a *Assignment) SubmitFile(filename string, r io.Reader, opts ...Option) (*
 		}
 	}
 	endpoint := fmt.Sprintf("/courses/%d/assignments/%d/submissions/self/files", a.CourseID, a.ID)
-	return uploadFile(a.client, r, endpoint, &params)
+	return uploadFile(ctx, a.client, r, endpoint, &params)
 }
 
 // SubmitOsFile is the same as SubmitFile except it takes advantage of
@@ -499,20 +661,23 @@ type TurnitinSettings struct {
 
 // RubricCriteria has the rubric information for an assignment.
 type RubricCriteria struct {
-	Points            float64 `json:"points"`
-	ID                string  `json:"id"`
-	LearningOutcomeID string  `json:"learning_outcome_id"`
-	VendorGUID        string  `json:"vendor_guid"`
-	Description       string  `json:"description"`
-	LongDescription   string  `json:"long_description"`
-	CriterionUseRange bool    `json:"criterion_use_range"`
-	Ratings           []struct {
-		ID              string  `json:"id"`
-		Description     string  `json:"description"`
-		LongDescription string  `json:"long_description"`
-		Points          float64 `json:"points"`
-	} `json:"ratings"`
-	IgnoreForScoring bool `json:"ignore_for_scoring"`
+	Points            float64        `json:"points"`
+	ID                string         `json:"id"`
+	LearningOutcomeID string         `json:"learning_outcome_id"`
+	VendorGUID        string         `json:"vendor_guid"`
+	Description       string         `json:"description"`
+	LongDescription   string         `json:"long_description"`
+	CriterionUseRange bool           `json:"criterion_use_range"`
+	Ratings           []RubricRating `json:"ratings"`
+	IgnoreForScoring  bool           `json:"ignore_for_scoring"`
+}
+
+// RubricRating is one of the ratings for a rubric criterion.
+type RubricRating struct {
+	ID              string  `json:"id"`
+	Description     string  `json:"description"`
+	LongDescription string  `json:"long_description"`
+	Points          float64 `json:"points"`
 }
 
 // LockInfo is a struct containing assignment lock status.
@@ -540,26 +705,47 @@ type AssignmentOverride struct {
 	AllDayDate   time.Time `json:"all_day_date" url:"-"`
 }
 
+// AssignmentDate is one of the sets of dates for an assignment. Canvas
+// only sends them when the assignment is fetched with OptAllDates.
+type AssignmentDate struct {
+	ID int `json:"id"`
+	// Base is true for the dates that apply to everyone
+	// without an override.
+	Base     bool      `json:"base"`
+	Title    string    `json:"title"`
+	DueAt    time.Time `json:"due_at"`
+	UnlockAt time.Time `json:"unlock_at"`
+	LockAt   time.Time `json:"lock_at"`
+}
+
 // DiscussionTopics return a list of the course discussion topics.
 func (c *Course) DiscussionTopics(opts ...Option) ([]*DiscussionTopic, error) {
+	return listDiscussionTopics(c.client, c.id("/courses/%d/discussion_topics"), opts)
+}
+
+func listDiscussionTopics(d doer, path string, opts []Option) ([]*DiscussionTopic, error) {
 	ch := make(chan *DiscussionTopic)
-	pager := newPaginatedList(
-		c.client, fmt.Sprintf("/courses/%d/discussion_topics", c.ID),
-		sendDiscussionTopicFunc(ch), opts,
-	)
+	context := strings.TrimSuffix(path, "/discussion_topics")
+	pager := newPaginatedList(d, path, sendDiscussionTopicFunc(d, context, ch), opts)
 	topics := make([]*DiscussionTopic, 0)
 	errs := pager.start()
+	var errl []error
 	for {
 		select {
 		case disc := <-ch:
 			topics = append(topics, disc)
-		case err := <-errs:
-			return topics, err
+		case err, ok := <-errs:
+			if !ok {
+				return topics, joinErrs(errl)
+			}
+			errl = append(errl, err)
 		}
 	}
 }
 
-// Activity returns a course's activity data
+// Activity returns a course's activity data.
+//
+// Deprecated: use ActivityAnalytics.
 func (c *Course) Activity() (res interface{}, err error) {
 	return res, getjson(c.client, &res, nil, "/courses/%d/analytics/activity", c.ID)
 }
@@ -585,7 +771,7 @@ func (c *Course) ListFiles(opts ...Option) ([]*File, error) {
 func (c *Course) Folders(opts ...Option) <-chan *Folder {
 	ch := make(folderChan)
 	pager := c.folderspager(ch, opts)
-	go handleErrs(pager.start(), ch, c.errorHandler)
+	go handleErrs(pager, ch, c.errorHandler)
 	return ch
 }
 
@@ -627,9 +813,15 @@ func (c *Course) CreateFolder(path string, opts ...Option) (*Folder, error) {
 // UploadFile will upload a file to the course.
 // https://canvas.instructure.com/doc/api/courses.html#method.courses.create_file
 func (c *Course) UploadFile(filename string, r io.Reader, opts ...Option) (*File, error) {
+	return c.UploadFileContext(context.Background(), filename, r, opts...)
+}
+
+// UploadFileContext will upload a file to the course and
+// will abort the upload if the context is cancelled.
+func (c *Course) UploadFileContext(ctx context.Context, filename string, r io.Reader, opts ...Option) (*File, error) {
 	p := fileUploadParams{Name: filename}
 	p.setOptions(opts)
-	return uploadFile(c.client, r, c.id("/courses/%d/files"), &p)
+	return uploadFile(ctx, c.client, r, c.id("/courses/%d/files"), &p)
 }
 
 // SetErrorHandler will set a error handling callback that is
@@ -645,6 +837,9 @@ func (c *Course) SetErrorHandler(f errorHandlerFunc) {
 
 func (c *Course) setclient(d doer) {
 	c.client = d
+	if c.errorHandler == nil {
+		c.errorHandler = ConcurrentErrorHandler
+	}
 }
 
 // Term is a school term. One school year.
@@ -672,9 +867,13 @@ type Enrollment struct {
 	CourseSectionID      int    `json:"course_section_id"`
 	SectionIntegrationID string `json:"section_integration_id"`
 
-	EnrollmentState                string `json:"enrollment_state"`
-	Role                           string `json:"role"`
-	RoleID                         int    `json:"role_id"`
+	// EnrollmentState can be compared with the
+	// EnrollmentState constants, e.g. string(EnrollmentActive).
+	EnrollmentState string `json:"enrollment_state"`
+	Role            string `json:"role"`
+	RoleID          int    `json:"role_id"`
+	// Type can be compared with the EnrollmentType
+	// constants, e.g. string(StudentEnrollment).
 	Type                           string `json:"type"`
 	LimitPrivilegesToCourseSection bool   `json:"limit_privileges_to_course_section"`
 	UserID                         int    `json:"user_id"`
@@ -687,6 +886,9 @@ type Enrollment struct {
 	SisImportID      int         `json:"sis_import_id"`
 	RootAccountID    int         `json:"root_account_id"`
 	AssociatedUserID interface{} `json:"associated_user_id"`
+	// ObservedUser is the student being observed, only set for observer
+	// enrollments when using the IncludeObservedUsers preset.
+	ObservedUser *User `json:"observed_user"`
 
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
@@ -724,6 +926,16 @@ type Enrollment struct {
 	CurrentPeriodUnpostedFinalScore   float64 `json:"current_period_unposted_final_score"`
 	CurrentPeriodUnpostedCurrentGrade string  `json:"current_period_unposted_current_grade"`
 	CurrentPeriodUnpostedFinalGrade   string  `json:"current_period_unposted_final_grade"`
+
+	// TemporaryEnrollmentSourceUserID is the provider
+	// of a temporary enrollment.
+	TemporaryEnrollmentSourceUserID int `json:"temporary_enrollment_source_user_id"`
+	TemporaryEnrollmentPairingID    int `json:"temporary_enrollment_pairing_id"`
+	// TemporaryEnrollmentProviders is only set when using
+	// IncludeOpt("temporary_enrollment_providers").
+	TemporaryEnrollmentProviders []*User `json:"temporary_enrollment_providers"`
+
+	client doer
 }
 
 // Quizzes will get all the course quizzes
@@ -737,10 +949,17 @@ func (c *Course) Quiz(id int, opts ...Option) (*Quiz, error) {
 }
 
 func getQuizzes(client doer, courseID int, opts []Option) (qs []*Quiz, err error) {
-	return qs, getjson(client, &qs, optEnc(opts), "courses/%d/quizzes", courseID)
+	if err = getjson(client, &qs, optEnc(opts), "courses/%d/quizzes", courseID); err != nil {
+		return nil, err
+	}
+	for _, q := range qs {
+		q.courseID, q.client = courseID, client
+	}
+	return qs, nil
 }
 
 func getQuiz(client doer, course, quiz int, opts []Option) (q *Quiz, err error) {
+	q = &Quiz{courseID: course, client: client}
 	return q, getjson(client, q, optEnc(opts), "courses/%d/quizzes/%d", course, quiz)
 }
 
@@ -786,6 +1005,9 @@ type Quiz struct {
 	VersionNumber                 int             `json:"version_number"`
 	QuestionTypes                 []string        `json:"question_types"`
 	AnonymousSubmissions          bool            `json:"anonymous_submissions"`
+
+	courseID int
+	client   doer
 }
 
 // QuizPermissions is the permissions for a quiz.
@@ -820,7 +1042,7 @@ func (c *Course) assignmentspager(ch chan *Assignment, params []Option) *paginat
 		c.client, c.id("/courses/%d/assignments"),
 		func(r io.Reader) error {
 			asses := make([]*Assignment, 0, 10)
-			err := json.NewDecoder(r).Decode(&asses)
+			err := decodeJSON(r, &asses)
 			if err != nil {
 				return err
 			}
@@ -834,66 +1056,68 @@ func (c *Course) assignmentspager(ch chan *Assignment, params []Option) *paginat
 	)
 }
 
-func (c *Course) collectUsers(path string, opts []Option) (users []*User, err error) {
+func (c *Course) collectUsers(path string, opts []Option) ([]*User, error) {
+	return collectUsers(c.client, fmt.Sprintf(path, c.ID), opts)
+}
+
+func collectUsers(d doer, path string, opts []Option) (users []*User, err error) {
 	ch := make(chan *User)
-	errs := newPaginatedList(
-		c.client, fmt.Sprintf(path, c.ID),
-		sendUserFunc(c.client, ch), opts,
-	).start()
+	errs := newPaginatedList(d, path, sendUserFunc(d, ch), opts).start()
+	var errl []error
 	for {
 		select {
 		case u := <-ch:
 			users = append(users, u)
-		case err := <-errs:
-			return users, err
+		case err, ok := <-errs:
+			if !ok {
+				return users, joinErrs(errl)
+			}
+			errl = append(errl, err)
 		}
 	}
 }
 
 func sendFilesFunc(d doer, ch chan *File, folder *Folder) func(io.Reader) error {
 	return func(r io.Reader) error {
-		files := make([]*File, 0, defaultPerPage)
-		err := json.NewDecoder(r).Decode(&files)
-		if err != nil {
-			return err
-		}
-		for _, f := range files {
+		return streamArray(r, func(dec decoder) error {
+			f := &File{}
+			if err := dec.Decode(f); err != nil {
+				return err
+			}
 			f.setclient(d)
 			f.folder = folder
 			ch <- f
-		}
-		return nil
+			return nil
+		})
 	}
 }
 
 func sendFoldersFunc(d doer, ch chan *Folder, parent *Folder) sendFunc {
 	return func(r io.Reader) error {
-		folders := make([]*Folder, 0, defaultPerPage)
-		err := json.NewDecoder(r).Decode(&folders)
-		if err != nil {
-			return err
-		}
-		for _, f := range folders {
+		return streamArray(r, func(dec decoder) error {
+			f := &Folder{}
+			if err := dec.Decode(f); err != nil {
+				return err
+			}
 			f.setclient(d)
 			f.parent = parent
 			ch <- f
-		}
-		return nil
+			return nil
+		})
 	}
 }
 
 func sendUserFunc(d doer, ch chan *User) sendFunc {
 	return func(r io.Reader) error {
-		list := make([]*User, 0, defaultPerPage)
-		err := json.NewDecoder(r).Decode(&list)
-		if err != nil {
-			return err
-		}
-		for _, u := range list {
+		return streamArray(r, func(dec decoder) error {
+			u := &User{}
+			if err := dec.Decode(u); err != nil {
+				return err
+			}
 			u.client = d
 			ch <- u
-		}
-		return nil
+			return nil
+		})
 	}
 }
 
@@ -0,0 +1,226 @@
+package canvas
+
+import (
+	"sort"
+	"time"
+)
+
+// EffectiveDueAt returns the due date that applies to a specific student
+// in the given sections and groups. Assignment overrides must be included
+// when the assignment is fetched (see IncludeOverrides) for the overrides
+// to be taken into account and if the assignment was also fetched with
+// OptAllDates then the dates are taken from all_dates.
+//
+// When more than one student, section, or group override applies, the
+// latest due date is used just like canvas does. A zero time means the
+// student has no due date.
+func (a *Assignment) EffectiveDueAt(userID int, sectionIDs, groupIDs []int) time.Time {
+	var (
+		due   time.Time
+		found bool
+	)
+	for _, o := range a.Overrides {
+		if !o.appliesTo(userID, sectionIDs, groupIDs) {
+			continue
+		}
+		d := a.overrideDueAt(&o)
+		if d.IsZero() {
+			// no due date is the most lenient date
+			return time.Time{}
+		}
+		if !found || d.After(due) {
+			due = d
+		}
+		found = true
+	}
+	if found {
+		return due
+	}
+	if a.OnlyVisibleToOverrides {
+		return time.Time{}
+	}
+	if base, ok := a.BaseDate(); ok {
+		return base.DueAt
+	}
+	return a.DueAt
+}
+
+// overrideDueAt returns the override's due date from all_dates
+// if it is there and from the override otherwise.
+func (a *Assignment) overrideDueAt(o *AssignmentOverride) time.Time {
+	for _, d := range a.AllDates {
+		if !d.Base && d.ID == o.ID {
+			return d.DueAt
+		}
+	}
+	return o.DueAt
+}
+
+func (o *AssignmentOverride) appliesTo(userID int, sectionIDs, groupIDs []int) bool {
+	switch {
+	case containsInt(o.StudentIds, userID):
+		return true
+	case o.CourseSectionID != 0 && containsInt(sectionIDs, o.CourseSectionID):
+		return true
+	case o.GroupID != 0 && containsInt(groupIDs, o.GroupID):
+		return true
+	default:
+		return false
+	}
+}
+
+// NextDue returns the earliest due date after t for any student in
+// the course. It takes the assignment overrides and all_dates into
+// account if they were included when fetching the assignment.
+func (a *Assignment) NextDue(t time.Time) (time.Time, bool) {
+	var (
+		next  time.Time
+		found bool
+	)
+	check := func(due time.Time) {
+		if due.IsZero() || !due.After(t) {
+			return
+		}
+		if !found || due.Before(next) {
+			next = due
+			found = true
+		}
+	}
+	if !a.OnlyVisibleToOverrides {
+		check(a.DueAt)
+	}
+	for _, o := range a.Overrides {
+		check(o.DueAt)
+	}
+	for _, d := range a.allDueDates() {
+		check(d)
+	}
+	return next, found
+}
+
+func (a *Assignment) allDueDates() []time.Time {
+	times := make([]time.Time, len(a.AllDates))
+	for i, d := range a.AllDates {
+		times[i] = d.DueAt
+	}
+	return times
+}
+
+// BaseDate returns the dates that apply to students without an
+// override. It is only found if the assignment was fetched with
+// OptAllDates.
+func (a *Assignment) BaseDate() (AssignmentDate, bool) {
+	for _, d := range a.AllDates {
+		if d.Base {
+			return d, true
+		}
+	}
+	return AssignmentDate{}, false
+}
+
+// NextDueAssignments returns the next n assignments that are due in
+// the course ordered by due date. If n is less than one then all
+// upcoming assignments are returned.
+func (c *Course) NextDueAssignments(n int, opts ...Option) ([]*Assignment, error) {
+	opts = append(opts, AssignmentIncludes(IncludeOverrides))
+	asses, err := c.ListAssignments(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return nextDue(asses, time.Now(), n), nil
+}
+
+func nextDue(asses []*Assignment, now time.Time, n int) []*Assignment {
+	type upcoming struct {
+		a   *Assignment
+		due time.Time
+	}
+	list := make([]upcoming, 0, len(asses))
+	for _, a := range asses {
+		if due, ok := a.NextDue(now); ok {
+			list = append(list, upcoming{a, due})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].due.Before(list[j].due)
+	})
+	if n > 0 && n < len(list) {
+		list = list[:n]
+	}
+	result := make([]*Assignment, len(list))
+	for i, u := range list {
+		result[i] = u.a
+	}
+	return result
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// Location returns the course's time zone. If the course has no
+// time zone or the time zone cannot be loaded then UTC is returned.
+func (c *Course) Location() *time.Location {
+	return location(c.TimeZone)
+}
+
+// Location returns the user's time zone. If the user has no
+// time zone or the time zone cannot be loaded then UTC is returned.
+func (u *User) Location() *time.Location {
+	return location(u.TimeZone)
+}
+
+// DueAtIn returns the assignment due date in the given location.
+func (a *Assignment) DueAtIn(loc *time.Location) time.Time {
+	return timeIn(a.DueAt, loc)
+}
+
+// LockAtIn returns the assignment lock date in the given location.
+func (a *Assignment) LockAtIn(loc *time.Location) time.Time {
+	return timeIn(a.LockAt, loc)
+}
+
+// UnlockAtIn returns the assignment unlock date in the given location.
+func (a *Assignment) UnlockAtIn(loc *time.Location) time.Time {
+	return timeIn(a.UnlockAt, loc)
+}
+
+// DueAtIn returns the quiz due date in the given location.
+func (q *Quiz) DueAtIn(loc *time.Location) time.Time {
+	return timeIn(q.DueAt, loc)
+}
+
+// LockAtIn returns the quiz lock date in the given location.
+func (q *Quiz) LockAtIn(loc *time.Location) time.Time {
+	return timeIn(q.LockAt, loc)
+}
+
+// UnlockAtIn returns the quiz unlock date in the given location.
+func (q *Quiz) UnlockAtIn(loc *time.Location) time.Time {
+	return timeIn(q.UnlockAt, loc)
+}
+
+func location(tz string) *time.Location {
+	if tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// timeIn converts t to loc but leaves zero times alone
+// so that a missing date is still a missing date.
+func timeIn(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() || loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
@@ -0,0 +1,93 @@
+package canvas
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestEffectiveDueAt(t *testing.T) {
+	is := is.New(t)
+	base := time.Date(2020, 9, 1, 23, 59, 0, 0, time.UTC)
+	a := &Assignment{
+		DueAt: base,
+		Overrides: []AssignmentOverride{
+			{ID: 1, StudentIds: []int{7}, DueAt: base.Add(72 * time.Hour)},
+			{ID: 2, CourseSectionID: 1, DueAt: base.Add(24 * time.Hour)},
+			{ID: 3, CourseSectionID: 2, DueAt: base.Add(48 * time.Hour)},
+			{ID: 4, GroupID: 5, DueAt: base.Add(96 * time.Hour)},
+			{ID: 5, StudentIds: []int{9}, DueAt: base.Add(12 * time.Hour)},
+		},
+	}
+	is.Equal(a.EffectiveDueAt(7, []int{1}, nil), base.Add(72*time.Hour))
+	is.Equal(a.EffectiveDueAt(8, []int{1}, nil), base.Add(24*time.Hour))
+	is.Equal(a.EffectiveDueAt(8, []int{1, 2}, nil), base.Add(48*time.Hour))
+	is.Equal(a.EffectiveDueAt(8, []int{3}, nil), base)
+	// conflicting overrides use the latest date
+	is.Equal(a.EffectiveDueAt(7, []int{1}, []int{5}), base.Add(96*time.Hour))
+	is.Equal(a.EffectiveDueAt(9, []int{2}, nil), base.Add(48*time.Hour))
+	is.Equal(a.EffectiveDueAt(8, nil, []int{5}), base.Add(96*time.Hour))
+	a.Overrides = append(a.Overrides, AssignmentOverride{ID: 6, StudentIds: []int{9}})
+	is.True(a.EffectiveDueAt(9, []int{2}, nil).IsZero())
+
+	// all_dates take precedence over the override and assignment dates
+	a.AllDates = []AssignmentDate{
+		{Base: true, DueAt: base.Add(time.Hour)},
+		{ID: 2, DueAt: base.Add(36 * time.Hour)},
+	}
+	is.Equal(a.EffectiveDueAt(8, []int{1}, nil), base.Add(36*time.Hour))
+	is.Equal(a.EffectiveDueAt(8, []int{1, 2}, nil), base.Add(48*time.Hour))
+	is.Equal(a.EffectiveDueAt(8, []int{3}, nil), base.Add(time.Hour))
+	a.OnlyVisibleToOverrides = true
+	is.True(a.EffectiveDueAt(8, []int{3}, nil).IsZero())
+
+	now := base.Add(-time.Hour)
+	asses := []*Assignment{
+		{ID: 1, DueAt: base.Add(time.Hour)},
+		{ID: 2, DueAt: now.Add(-time.Hour)}, // already due
+		{ID: 3, DueAt: now.Add(-time.Hour), Overrides: []AssignmentOverride{{DueAt: base}}},
+		{ID: 4, AllDates: []AssignmentDate{{DueAt: base.Add(-30 * time.Minute)}}},
+	}
+	next := nextDue(asses, now, 0)
+	is.Equal(len(next), 3)
+	is.Equal(next[0].ID, 4)
+	is.Equal(next[1].ID, 3)
+	is.Equal(next[2].ID, 1)
+	is.Equal(len(nextDue(asses, now, 1)), 1)
+}
+
+func TestDueAtIn(t *testing.T) {
+	is := is.New(t)
+	c := &Course{TimeZone: "America/Denver"}
+	loc := c.Location()
+	is.Equal(loc.String(), "America/Denver")
+	is.Equal((&Course{}).Location(), time.UTC)
+	is.Equal((&User{TimeZone: "Not/AZone"}).Location(), time.UTC)
+
+	a := &Assignment{DueAt: time.Date(2020, 9, 2, 5, 59, 0, 0, time.UTC)}
+	due := a.DueAtIn(loc)
+	is.Equal(due.Location(), loc)
+	is.Equal(due.Hour(), 23)
+	is.Equal(due.Day(), 1)
+	is.True(due.Equal(a.DueAt))
+	is.True(a.LockAtIn(loc).IsZero())
+}
+
+func TestAssignmentDates(t *testing.T) {
+	is := is.New(t)
+	a := &Assignment{}
+	is.NoErr(json.Unmarshal([]byte(`{"id":1,"all_dates":[
+		{"id":3,"title":"Section 2","due_at":"2020-09-02T23:59:00Z"},
+		{"base":true,"title":"Everyone else","due_at":"2020-09-01T23:59:00Z","lock_at":"2020-09-03T23:59:00Z"}
+	]}`), a))
+	is.Equal(len(a.AllDates), 2)
+	is.Equal(a.AllDates[0].Title, "Section 2")
+	base, ok := a.BaseDate()
+	is.True(ok)
+	is.Equal(base.DueAt, time.Date(2020, 9, 1, 23, 59, 0, 0, time.UTC))
+	is.Equal(base.LockAt, time.Date(2020, 9, 3, 23, 59, 0, 0, time.UTC))
+	_, ok = (&Assignment{}).BaseDate()
+	is.True(!ok)
+}
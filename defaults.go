@@ -0,0 +1,189 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WithDefaults returns a copy of the course where every request made
+// through it, or through anything it returns, will include the options
+// given. Options passed to a method take precedence over the defaults.
+//
+//	c = c.WithDefaults(canvas.Opt("per_page", 50), canvas.IncludeOpt("syllabus_body"))
+//
+// Pager options like WithPrefetch and InOrder can also be used
+// as defaults for every paginated listing.
+func (c *Course) WithDefaults(opts ...Option) *Course {
+	course := *c
+	course.client = withDefaults(c.client, opts)
+	return &course
+}
+
+// WithDefaults returns a copy of the Canvas object where every request
+// made through it, or through anything it returns, will include the
+// options given. Options passed to a method take precedence over the
+// defaults.
+func (c *Canvas) WithDefaults(opts ...Option) *Canvas {
+	return &Canvas{client: withDefaults(c.client, opts)}
+}
+
+func withDefaults(d doer, opts []Option) doer {
+	if dd, ok := d.(*defaultsDoer); ok {
+		return &defaultsDoer{
+			d:    dd.d,
+			opts: append(append([]Option{}, dd.opts...), opts...),
+		}
+	}
+	return &defaultsDoer{d: d, opts: opts}
+}
+
+// defaultsDoer adds default options to every api request. When
+// options have the same name the last one is used.
+type defaultsDoer struct {
+	d    doer
+	opts []Option
+}
+
+func (dd *defaultsDoer) Do(r *http.Request) (*http.Response, error) {
+	if isAPIRequest(dd.d, r, apiPath) {
+		r = r.Clone(r.Context())
+		q := r.URL.Query()
+		addDefaults(q, dd.opts)
+		r.URL.RawQuery = q.Encode()
+	}
+	return dd.d.Do(r)
+}
+
+func (dd *defaultsDoer) unwrap() doer {
+	return dd.d
+}
+
+// addDefaults adds the options to the query
+// if they have not already been set.
+func addDefaults(q url.Values, opts []Option) {
+	// go backwards so that later options win
+	for i := len(opts) - 1; i >= 0; i-- {
+		o := opts[i]
+		name := o.Name()
+		if name == "" {
+			continue
+		}
+		if !strings.HasSuffix(name, "[]") {
+			if _, ok := q[name]; !ok {
+				q[name] = o.Value()
+			}
+			continue
+		}
+		for _, v := range o.Value() {
+			if !containsString(q[name], v) {
+				q[name] = append(q[name], v)
+			}
+		}
+	}
+}
+
+// AsUser returns a copy of the Canvas object that makes every request as
+// another user. The id can be a user id or an sis id like
+// "sis_user_id:jdoe". Courses, assignments, files, and everything else
+// returned by the copy also act as the user and files uploaded through
+// them are owned by the user. The api token must belong to an admin
+// with permission to masquerade.
+//
+// https://canvas.instructure.com/doc/api/file.masquerading.html
+func (c *Canvas) AsUser(id interface{}) *Canvas {
+	return &Canvas{client: &masqueradeDoer{d: c.client, userID: fmt.Sprint(id)}}
+}
+
+// AsUser returns a copy of the default Canvas object that
+// makes every request as another user.
+func AsUser(id interface{}) *Canvas { return ca.AsUser(id) }
+
+// masqueradeDoer sets as_user_id on every api request. Unlike
+// defaults, it cannot be overridden by the options of one request.
+type masqueradeDoer struct {
+	d      doer
+	userID string
+}
+
+func (md *masqueradeDoer) Do(r *http.Request) (*http.Response, error) {
+	// graphql is also under /api
+	if isAPIRequest(md.d, r, "/api/") {
+		r = r.Clone(r.Context())
+		q := r.URL.Query()
+		q.Set("as_user_id", md.userID)
+		r.URL.RawQuery = q.Encode()
+	}
+	return md.d.Do(r)
+}
+
+func (md *masqueradeDoer) unwrap() doer {
+	return md.d
+}
+
+// isAPIRequest returns true if the request is for a path under prefix
+// on the canvas host that d sends requests to. Options are only added to
+// api requests, other requests like file uploads go to urls that would
+// reject them or should not see them.
+func isAPIRequest(d doer, r *http.Request, prefix string) bool {
+	if r.URL == nil || !strings.HasPrefix(r.URL.Path, prefix) {
+		return false
+	}
+	if r.URL.Host == "" {
+		// the client fills in its own host
+		return true
+	}
+	hosts := apiHosts(d)
+	if len(hosts) == 0 {
+		return true
+	}
+	for _, h := range hosts {
+		if strings.EqualFold(r.URL.Host, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiHosts returns the hosts that d sends api requests to, it is
+// empty if they are not known. Both the client's host and the host
+// given to SetHost are used.
+func apiHosts(d doer) (hosts []string) {
+	var hc *http.Client
+	switch c := unwrapDoer(d).(type) {
+	case *client:
+		hc = &c.Client
+		if c.host != "" {
+			hosts = append(hosts, c.host)
+		}
+	case *http.Client:
+		hc = c
+	default:
+		return nil
+	}
+	if a, ok := hc.Transport.(*auth); ok && a.host != "" {
+		hosts = append(hosts, a.host)
+	}
+	return hosts
+}
+
+// unwrapDoer returns the innermost doer.
+func unwrapDoer(d doer) doer {
+	for {
+		w, ok := d.(interface{ unwrap() doer })
+		if !ok {
+			return d
+		}
+		d = w.unwrap()
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
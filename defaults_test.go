@@ -0,0 +1,103 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWithDefaults(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("as_user_id") != "5" {
+			t.Error("should have the default as_user_id")
+		}
+		if fmt.Sprint(q["include[]"]) != "[overrides submission]" {
+			t.Errorf("wrong includes: %v", q["include[]"])
+		}
+		fmt.Fprint(w, `{"id":2}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("per_page") != "50" {
+			t.Errorf("expected default per_page; got %q", q.Get("per_page"))
+		}
+		if q.Get("as_user_id") != "6" {
+			t.Errorf("explicit options should win over defaults; got %q", q.Get("as_user_id"))
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":2}]`)
+	})
+
+	course := &Course{ID: 1, client: client, errorHandler: defaultErrorHandler}
+	scoped := course.WithDefaults(Opt("as_user_id", 4), IncludeOpt("submission"))
+	scoped = scoped.WithDefaults(Opt("as_user_id", 5), Opt("per_page", 50))
+	is.True(course.client == client) // the original is unchanged
+
+	_, err := scoped.Assignment(2, IncludeOpt("overrides"))
+	is.NoErr(err)
+	asses, err := scoped.ListAssignments(Opt("as_user_id", 6))
+	is.NoErr(err)
+	is.Equal(len(asses), 1)
+	is.True(asses[0].client == scoped.client)
+
+	c := (&Canvas{client: client}).WithDefaults(Opt("per_page", 50))
+	_, ok := c.client.(*defaultsDoer)
+	is.True(ok)
+}
+
+func TestAsUser(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var requests []string
+	mux.HandleFunc("/api/v1/courses/1", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query().Get("as_user_id"))
+		fmt.Fprint(w, `{"id":1}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments/2", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query().Get("as_user_id"))
+		fmt.Fprint(w, `{"id":2}`)
+	})
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["as_user_id"]; ok {
+			t.Error("as_user_id should only be sent to the api")
+		}
+	})
+	mux.HandleFunc("/api/v1/other", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Host+" "+r.URL.Query().Get("as_user_id"))
+	})
+	c := (&Canvas{client: client}).AsUser("sis_user_id:jdoe")
+	course, err := c.GetCourse(1)
+	is.NoErr(err)
+	_, err = course.Assignment(2, Opt("as_user_id", 9))
+	is.NoErr(err)
+	is.Equal(requests, []string{"sis_user_id:jdoe", "sis_user_id:jdoe"})
+	resp, err := course.client.Do(&http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "uploads.example.com", Path: "/upload"},
+		Header: http.Header{},
+	})
+	is.NoErr(err)
+	resp.Body.Close()
+
+	// only the canvas host gets as_user_id, even for api paths
+	requests = nil
+	for _, host := range []string{"uploads.example.com", DefaultHost} {
+		resp, err = course.client.Do(&http.Request{
+			Method: "GET",
+			URL:    &url.URL{Scheme: "https", Host: host, Path: "/api/v1/other"},
+			Header: http.Header{},
+		})
+		is.NoErr(err)
+		resp.Body.Close()
+	}
+	is.Equal(requests, []string{"uploads.example.com ", DefaultHost + " sis_user_id:jdoe"})
+	is.True(unwrapDoer(course.client) == client)
+}
@@ -0,0 +1,234 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiscussionEntry is a post in a discussion topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html
+type DiscussionEntry struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	ParentID  int       `json:"parent_id"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// ReadState is either "read" or "unread".
+	ReadState       string `json:"read_state"`
+	ForcedReadState bool   `json:"forced_read_state"`
+	RatingCount     int    `json:"rating_count"`
+	RatingSum       int    `json:"rating_sum"`
+	Attachment      *File  `json:"attachment"`
+	// RecentReplies is only a few of the entry's replies,
+	// use Replies to get all of them.
+	RecentReplies  []*DiscussionEntry `json:"recent_replies"`
+	HasMoreReplies bool               `json:"has_more_replies"`
+
+	// topic is the api path of the entry's topic.
+	topic  string
+	client doer
+}
+
+// CreateDiscussionTopic will create a discussion topic in the course.
+// Options like Opt("discussion_type", "threaded"), Opt("published", true),
+// and Opt("allow_rating", true) can be used to set up the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.create
+func (c *Course) CreateDiscussionTopic(title, message string, opts ...Option) (*DiscussionTopic, error) {
+	return createDiscussionTopic(c.client, c.id("/courses/%d"), title, message, opts)
+}
+
+// CreateAnnouncement will post an announcement to the course. Use
+// DateOpt("delayed_post_at", t) to post the announcement later.
+func (c *Course) CreateAnnouncement(title, message string, opts ...Option) (*DiscussionTopic, error) {
+	return c.CreateDiscussionTopic(title, message, append(opts, Opt("is_announcement", true))...)
+}
+
+// Entries will get the top level entries in the topic, newest first.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.entries
+func (d *DiscussionTopic) Entries(opts ...Option) ([]*DiscussionEntry, error) {
+	return listEntries(d.client, d.path(""), d.path("/entries"), opts)
+}
+
+// Post will add an entry to the topic.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.add_entry
+func (d *DiscussionTopic) Post(message string, opts ...Option) (*DiscussionEntry, error) {
+	return postEntry(d.client, d.path(""), d.path("/entries"), message, opts)
+}
+
+// MarkRead will mark the topic as read.
+func (d *DiscussionTopic) MarkRead() error {
+	return send(d.client, "PUT", d.path("/read"))
+}
+
+// MarkUnread will mark the topic as unread.
+func (d *DiscussionTopic) MarkUnread() error {
+	return send(d.client, "DELETE", d.path("/read"))
+}
+
+// MarkAllRead will mark the topic and all of its entries as read.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.mark_all_read
+func (d *DiscussionTopic) MarkAllRead() error {
+	return send(d.client, "PUT", d.path("/read_all"))
+}
+
+// Delete will delete the topic.
+func (d *DiscussionTopic) Delete() error {
+	return send(d.client, "DELETE", d.path(""))
+}
+
+func (d *DiscussionTopic) path(s string) string {
+	return fmt.Sprintf("%s/discussion_topics/%d", d.context, d.ID) + s
+}
+
+// Replies will get all of the replies to the entry, newest first.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.replies
+func (e *DiscussionEntry) Replies(opts ...Option) ([]*DiscussionEntry, error) {
+	return listEntries(e.client, e.topic, e.path("/replies"), opts)
+}
+
+// Reply will post a reply to the entry.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.add_reply
+func (e *DiscussionEntry) Reply(message string, opts ...Option) (*DiscussionEntry, error) {
+	return postEntry(e.client, e.topic, e.path("/replies"), message, opts)
+}
+
+// Rate will like the entry, or remove the like if liked is false.
+// The topic must allow rating.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics_api.rate_entry
+func (e *DiscussionEntry) Rate(liked bool) error {
+	rating := "0"
+	if liked {
+		rating = "1"
+	}
+	resp, err := post(e.client, e.path("/rating"), params{"rating": {rating}})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// MarkRead will mark the entry as read.
+func (e *DiscussionEntry) MarkRead() error {
+	if err := send(e.client, "PUT", e.path("/read")); err != nil {
+		return err
+	}
+	e.ReadState = "read"
+	return nil
+}
+
+// MarkUnread will mark the entry as unread.
+func (e *DiscussionEntry) MarkUnread() error {
+	if err := send(e.client, "DELETE", e.path("/read")); err != nil {
+		return err
+	}
+	e.ReadState = "unread"
+	return nil
+}
+
+func (e *DiscussionEntry) path(s string) string {
+	return fmt.Sprintf("%s/entries/%d", e.topic, e.ID) + s
+}
+
+func createDiscussionTopic(d doer, context, title, message string, opts []Option) (*DiscussionTopic, error) {
+	q := params{"title": {title}, "message": {message}}
+	q.Add(opts)
+	resp, err := post(d, context+"/discussion_topics", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	t := &DiscussionTopic{context: context, client: d}
+	return t, decodeJSON(resp.Body, t)
+}
+
+func listEntries(d doer, topic, path string, opts []Option) (entries []*DiscussionEntry, err error) {
+	ch := make(chan *DiscussionEntry)
+	errs := newPaginatedList(d, path, func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			e := &DiscussionEntry{}
+			if err := dec.Decode(e); err != nil {
+				return err
+			}
+			e.setClient(d, topic)
+			ch <- e
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case e := <-ch:
+			entries = append(entries, e)
+		case err, ok := <-errs:
+			if !ok {
+				return entries, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+func postEntry(d doer, topic, path, message string, opts []Option) (*DiscussionEntry, error) {
+	q := params{"message": {message}}
+	q.Add(opts)
+	resp, err := post(d, path, q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	e := &DiscussionEntry{}
+	if err = decodeJSON(resp.Body, e); err != nil {
+		return nil, err
+	}
+	e.setClient(d, topic)
+	return e, nil
+}
+
+func (e *DiscussionEntry) setClient(d doer, topic string) {
+	e.client, e.topic = d, topic
+	for _, r := range e.RecentReplies {
+		r.setClient(d, topic)
+	}
+}
+
+// send makes a request that has no response body.
+func send(d doer, method, path string) error {
+	resp, err := do(d, newreq(method, path, nil))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// contextPath converts a context code like "course_1"
+// to its api path, "/courses/1".
+func contextPath(code string) string {
+	i := strings.LastIndex(code, "_")
+	if i < 0 {
+		return ""
+	}
+	return "/" + code[:i] + "s/" + code[i+1:]
+}
+
+// contextCourseID returns the course id of a context
+// path or zero if it is not a course's path.
+func contextCourseID(context string) int {
+	if !strings.HasPrefix(context, "/courses/") {
+		return 0
+	}
+	id, _ := strconv.Atoi(strings.TrimPrefix(context, "/courses/"))
+	return id
+}
@@ -0,0 +1,132 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDiscussions(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("title") != "Welcome" || q.Get("message") != "hi" || q.Get("is_announcement") != "true" {
+			t.Errorf("wrong topic: %v", q)
+		}
+		fmt.Fprint(w, `{"id":3,"title":"Welcome","message":"hi"}`)
+	})
+	mux.HandleFunc("/api/v1/announcements", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[{"id":3,"title":"Welcome","context_code":"course_1"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/3/entries", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `[{"id":7,"message":"first","read_state":"unread","recent_replies":[{"id":8,"parent_id":7}]}]`)
+		case "POST":
+			if r.URL.Query().Get("message") != "hello" {
+				t.Error("wrong message")
+			}
+			fmt.Fprint(w, `{"id":9,"message":"hello"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/3/entries/7/replies", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `[{"id":8,"parent_id":7},{"id":10,"parent_id":7}]`)
+		case "POST":
+			fmt.Fprint(w, `{"id":11,"parent_id":7,"message":"reply"}`)
+		}
+	})
+	var rating string
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/3/entries/7/rating", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		rating = r.URL.Query().Get("rating")
+	})
+	var reads []string
+	readHandler := func(w http.ResponseWriter, r *http.Request) {
+		reads = append(reads, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/3/entries/7/read", readHandler)
+	mux.HandleFunc("/api/v1/courses/1/discussion_topics/3/read_all", readHandler)
+
+	c := &Course{ID: 1, client: client}
+	topic, err := c.CreateAnnouncement("Welcome", "hi")
+	is.NoErr(err)
+	is.Equal(topic.ID, 3)
+
+	canvas := &Canvas{client: client}
+	announcements, err := canvas.Announcements([]string{c.ContextCode()})
+	is.NoErr(err)
+	is.Equal(len(announcements), 1)
+	topic = announcements[0]
+	is.Equal(topic.context, "/courses/1")
+
+	entries, err := topic.Entries()
+	is.NoErr(err)
+	is.Equal(len(entries), 1)
+	entry := entries[0]
+	is.Equal(entry.RecentReplies[0].path(""), "/courses/1/discussion_topics/3/entries/8")
+	posted, err := topic.Post("hello")
+	is.NoErr(err)
+	is.Equal(posted.ID, 9)
+
+	replies, err := entry.Replies()
+	is.NoErr(err)
+	is.Equal(len(replies), 2)
+	reply, err := entry.Reply("reply")
+	is.NoErr(err)
+	is.Equal(reply.ParentID, 7)
+
+	is.NoErr(entry.Rate(true))
+	is.Equal(rating, "1")
+	is.NoErr(entry.MarkRead())
+	is.Equal(entry.ReadState, "read")
+	is.NoErr(entry.MarkUnread())
+	is.NoErr(topic.MarkAllRead())
+	is.Equal(reads, []string{
+		"PUT /api/v1/courses/1/discussion_topics/3/entries/7/read",
+		"DELETE /api/v1/courses/1/discussion_topics/3/entries/7/read",
+		"PUT /api/v1/courses/1/discussion_topics/3/read_all",
+	})
+}
+
+func TestAnnouncementOptions(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/announcements", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("start_date") != "2026-09-01T00:00:00Z" || q.Get("end_date") != "2026-09-15T00:00:00Z" ||
+			q.Get("active_only") != "true" || q.Get("latest_only") != "true" || len(q["context_codes[]"]) != 2 {
+			t.Errorf("wrong announcement options: %v", q)
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/announcements?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":1,"title":"a","context_code":"course_4"},{"id":2,"title":"b","context_code":"group_5"}]`)
+	})
+	c := &Canvas{client: client}
+	announcements, err := c.Announcements(
+		[]string{"course_4", "group_5"},
+		AnnouncementsSince(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)),
+		AnnouncementsUntil(time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)),
+		ActiveAnnouncements,
+		LatestAnnouncements,
+	)
+	is.NoErr(err)
+	is.Equal(len(announcements), 2)
+	sort.Slice(announcements, func(i, j int) bool { return announcements[i].ID < announcements[j].ID })
+	is.Equal(announcements[0].CourseID, 4)
+	is.Equal(announcements[0].context, "/courses/4")
+	is.Equal(announcements[1].CourseID, 0)
+	is.Equal(announcements[1].context, "/groups/5")
+}
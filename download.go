@@ -0,0 +1,198 @@
+package canvas
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ErrDownloadMismatch is returned when a downloaded file does
+// not match the size or checksum it was checked against.
+var ErrDownloadMismatch = errors.New("canvas: downloaded file does not match")
+
+// downloadRetries is the number of times an interrupted
+// download is resumed before giving up.
+const downloadRetries = 3
+
+// DownloadOption configures a file download.
+type DownloadOption func(*downloadConfig)
+
+type downloadConfig struct {
+	size bool
+	md5  string
+}
+
+// VerifySize checks that the number of bytes downloaded is the
+// same as the file size that canvas has for the file.
+func VerifySize() DownloadOption {
+	return func(dc *downloadConfig) { dc.size = true }
+}
+
+// VerifyMD5 checks the downloaded file against a hex encoded md5 sum.
+// Canvas does not give out file checksums so the sum has to come from
+// somewhere else, like an earlier download.
+func VerifyMD5(sum string) DownloadOption {
+	return func(dc *downloadConfig) { dc.md5 = strings.ToLower(sum) }
+}
+
+// Download will write the contents of the file to w. If the connection
+// drops part way through, the download is resumed with a range request
+// instead of starting over.
+func (f *File) Download(w io.Writer, opts ...DownloadOption) (int64, error) {
+	conf := newDownloadConfig(opts)
+	h := md5.New()
+	n, err := f.download(io.MultiWriter(w, h), 0)
+	if err != nil {
+		return n, err
+	}
+	return n, conf.verify(f, n, h)
+}
+
+// DownloadTo will download the file to path. The file is downloaded to
+// path with a ".part" suffix first and only moved to path once it is
+// complete and verified, so a failed download never replaces a good
+// copy. If a partial download is left behind from an earlier call,
+// the download continues from the end of it.
+func (f *File) DownloadTo(path string, opts ...DownloadOption) error {
+	conf := newDownloadConfig(opts)
+	part := path + ".part"
+	file, err := os.OpenFile(part, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// hash what is already there so a resumed download
+	// can still be checked
+	h := md5.New()
+	offset, err := io.Copy(h, file)
+	if err != nil {
+		return err
+	}
+	if f.Size > 0 && offset > int64(f.Size) {
+		// the partial file is from some other version of the file
+		if err = file.Truncate(0); err != nil {
+			return err
+		}
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		offset = 0
+		h.Reset()
+	}
+	n, err := f.download(io.MultiWriter(file, h), offset)
+	if err != nil {
+		return err
+	}
+	if err = conf.verify(f, offset+n, h); err != nil {
+		file.Close()
+		os.Remove(part)
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(part, path)
+}
+
+func newDownloadConfig(opts []DownloadOption) *downloadConfig {
+	conf := &downloadConfig{}
+	for _, o := range opts {
+		o(conf)
+	}
+	return conf
+}
+
+func (dc *downloadConfig) verify(f *File, n int64, h hash.Hash) error {
+	if dc.size && n != int64(f.Size) {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrDownloadMismatch, n, f.Size)
+	}
+	if dc.md5 != "" {
+		if sum := hex.EncodeToString(h.Sum(nil)); sum != dc.md5 {
+			return fmt.Errorf("%w: got md5 %s, want %s", ErrDownloadMismatch, sum, dc.md5)
+		}
+	}
+	return nil
+}
+
+// download writes the file to w starting at offset and resumes
+// the download if reading the response fails.
+func (f *File) download(w io.Writer, offset int64) (int64, error) {
+	if f.URL == "" {
+		return 0, errors.New("canvas: file has no download url")
+	}
+	if f.Size > 0 && offset >= int64(f.Size) {
+		// asking for a range past the end is an error
+		return 0, nil
+	}
+	var written int64
+	for retries := 0; ; {
+		n, err := f.downloadFrom(w, offset+written)
+		written += n
+		var re *readError
+		if err == nil || !errors.As(err, &re) || retries >= downloadRetries {
+			return written, err
+		}
+		if n == 0 {
+			// only count the attempts that made no progress
+			retries++
+		}
+	}
+}
+
+func (f *File) downloadFrom(w io.Writer, offset int64) (int64, error) {
+	u, err := url.Parse(f.URL)
+	if err != nil {
+		return 0, err
+	}
+	req := &http.Request{
+		Method: "GET",
+		Proto:  "HTTP/1.1",
+		URL:    u,
+		Header: http.Header{},
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	var d doer = http.DefaultClient
+	if f.client != nil {
+		d = f.client
+	}
+	resp, err := do(d, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body := io.Reader(&bodyReader{resp.Body})
+	if offset > 0 && resp.StatusCode != http.StatusPartialContent {
+		// the server sent the whole file so skip
+		// what has already been written
+		if _, err = io.CopyN(io.Discard, body, offset); err != nil {
+			return 0, err
+		}
+	}
+	return io.Copy(w, body)
+}
+
+// readError is an error from reading a response body,
+// as opposed to writing it somewhere.
+type readError struct{ err error }
+
+func (e *readError) Error() string { return e.err.Error() }
+func (e *readError) Unwrap() error { return e.err }
+
+type bodyReader struct{ r io.Reader }
+
+func (br *bodyReader) Read(b []byte) (int, error) {
+	n, err := br.r.Read(b)
+	if err != nil && err != io.EOF {
+		err = &readError{err}
+	}
+	return n, err
+}
@@ -0,0 +1,87 @@
+package canvas
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFileDownload(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	content := strings.Repeat("0123456789", 100)
+	sum := md5.Sum([]byte(content))
+	var (
+		ranges    []string
+		interrupt bool
+	)
+	mux.HandleFunc("/files/1/download", func(w http.ResponseWriter, r *http.Request) {
+		rng := r.Header.Get("Range")
+		ranges = append(ranges, rng)
+		var start int
+		if rng != "" {
+			fmt.Sscanf(rng, "bytes=%d-", &start)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)-start))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		}
+		body := content[start:]
+		if interrupt {
+			// the connection drops part way through
+			interrupt = false
+			body = body[:300]
+		}
+		fmt.Fprint(w, body)
+	})
+	f := &File{ID: 1, URL: fmt.Sprintf("https://%s/files/1/download", DefaultHost), Size: len(content), client: client}
+
+	var b strings.Builder
+	interrupt = true
+	n, err := f.Download(&b, VerifySize(), VerifyMD5(hex.EncodeToString(sum[:])))
+	is.NoErr(err)
+	is.Equal(n, int64(len(content)))
+	is.Equal(b.String(), content)
+	is.Equal(ranges, []string{"", "bytes=300-"})
+
+	b.Reset()
+	_, err = f.WriteTo(&b)
+	is.NoErr(err)
+	is.Equal(b.String(), content)
+
+	_, err = f.Download(&b, VerifyMD5("abc"))
+	is.True(errors.Is(err, ErrDownloadMismatch))
+	f.Size++
+	_, err = f.Download(&b, VerifySize())
+	is.True(errors.Is(err, ErrDownloadMismatch))
+	f.Size--
+
+	// resume from a partial file left behind
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	is.NoErr(os.WriteFile(path+".part", []byte(content[:500]), 0644))
+	ranges = nil
+	is.NoErr(f.DownloadTo(path, VerifySize(), VerifyMD5(hex.EncodeToString(sum[:]))))
+	is.Equal(ranges, []string{"bytes=500-"})
+	data, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.Equal(string(data), content)
+	_, err = os.Stat(path + ".part")
+	is.True(os.IsNotExist(err))
+
+	// a bad download is thrown away
+	err = f.DownloadTo(filepath.Join(dir, "bad.txt"), VerifyMD5("abc"))
+	is.True(errors.Is(err, ErrDownloadMismatch))
+	_, err = os.Stat(filepath.Join(dir, "bad.txt.part"))
+	is.True(os.IsNotExist(err))
+}
@@ -0,0 +1,45 @@
+package canvas
+
+import (
+	"context"
+	"errors"
+)
+
+// Assignment duplication workflow states.
+const (
+	WorkflowDuplicating       WorkflowState = "duplicating"
+	WorkflowFailedToDuplicate WorkflowState = "failed_to_duplicate"
+)
+
+// Duplicate will make a copy of the assignment in the same course. Use
+// Opt("target_course_id", id) to copy it into another course.
+//
+// Assignments like new quizzes are copied by canvas in the background
+// and Duplicate will wait until the copy has finished or the context is
+// cancelled. An error is returned if the copy failed.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignments.duplicate
+func (a *Assignment) Duplicate(ctx context.Context, opts ...Option) (*Assignment, error) {
+	resp, err := post(a.client, a.path("/duplicate"), optEnc(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	dup := &Assignment{client: a.client, courseCode: a.courseCode}
+	if err = decodeJSON(resp.Body, dup); err != nil {
+		return nil, err
+	}
+	err = poll(ctx,
+		func() bool { return dup.Workflow() != WorkflowDuplicating },
+		func() error { return getjson(a.client, dup, nil, "%s", dup.path("")) },
+	)
+	if ctx.Err() != nil {
+		return dup, ctx.Err()
+	} else if err != nil {
+		return nil, err
+	}
+	if dup.Workflow() == WorkflowFailedToDuplicate {
+		return dup, errors.New("assignment failed to duplicate")
+	}
+	return dup, nil
+}
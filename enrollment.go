@@ -0,0 +1,274 @@
+package canvas
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EnrollmentType is the type of an enrollment.
+type EnrollmentType string
+
+// Enrollment types
+const (
+	StudentEnrollment     EnrollmentType = "StudentEnrollment"
+	TeacherEnrollment     EnrollmentType = "TeacherEnrollment"
+	TAEnrollment          EnrollmentType = "TaEnrollment"
+	DesignerEnrollment    EnrollmentType = "DesignerEnrollment"
+	ObserverEnrollment    EnrollmentType = "ObserverEnrollment"
+	StudentViewEnrollment EnrollmentType = "StudentViewEnrollment"
+)
+
+// filterName returns the short name ("teacher", "ta", "student_view", etc.)
+// that the api uses for filtering by enrollment type.
+func (et EnrollmentType) filterName() string {
+	switch et {
+	case StudentViewEnrollment:
+		return "student_view"
+	default:
+		return strings.ToLower(strings.TrimSuffix(string(et), "Enrollment"))
+	}
+}
+
+// EnrollmentState is the state of an enrollment.
+type EnrollmentState string
+
+// Enrollment states
+const (
+	EnrollmentActive          EnrollmentState = "active"
+	EnrollmentInvited         EnrollmentState = "invited"
+	EnrollmentInactive        EnrollmentState = "inactive"
+	EnrollmentCompleted       EnrollmentState = "completed"
+	EnrollmentRejected        EnrollmentState = "rejected"
+	EnrollmentDeleted         EnrollmentState = "deleted"
+	EnrollmentCreationPending EnrollmentState = "creation_pending"
+
+	// EnrollmentInvitedOrPending is only used for filtering
+	// courses, it will never be the state of an enrollment.
+	EnrollmentInvitedOrPending EnrollmentState = "invited_or_pending"
+)
+
+// EnrollmentTypeFilter is an Option that filters courses or users by
+// enrollment type.
+//
+//	c.Users(canvas.EnrollmentTypeFilter(canvas.TeacherEnrollment))
+//
+// Listing courses only supports filtering by one enrollment type, but users
+// can be filtered by more than one.
+func EnrollmentTypeFilter(types ...EnrollmentType) Option {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.filterName()
+	}
+	if len(names) == 1 {
+		return Opt("enrollment_type", names[0])
+	}
+	return ArrayOpt("enrollment_type", names...)
+}
+
+// EnrollmentStateFilter is an Option that filters courses or users by
+// enrollment state.
+//
+// Listing courses only supports filtering by one enrollment state and
+// only with EnrollmentActive, EnrollmentInvitedOrPending, and
+// EnrollmentCompleted.
+func EnrollmentStateFilter(states ...EnrollmentState) Option {
+	names := make([]string, len(states))
+	for i, s := range states {
+		names[i] = string(s)
+	}
+	if len(names) == 1 {
+		return Opt("enrollment_state", names[0])
+	}
+	return ArrayOpt("enrollment_state", names...)
+}
+
+// ListEnrollments will get the enrollments in the course. Use
+// EnrollmentTypeFilter and EnrollmentStateFilter to narrow them down.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (c *Course) ListEnrollments(opts ...Option) ([]*Enrollment, error) {
+	return collectEnrollments(c.client, c.id("/courses/%d/enrollments"), opts)
+}
+
+// ListEnrollments will get the user's enrollments in every course.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (u *User) ListEnrollments(opts ...Option) ([]*Enrollment, error) {
+	return collectEnrollments(u.client, u.id("/users/%d/enrollments"), opts)
+}
+
+// Enroll will enroll a user in the course. Use options like
+// Opt("enrollment[enrollment_state]", "active") to skip the invitation,
+// Opt("enrollment[course_section_id]", id) to enroll them in a section,
+// or Opt("enrollment[notify]", true) to send them an email.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.create
+func (c *Course) Enroll(userID int, typ EnrollmentType, opts ...Option) (*Enrollment, error) {
+	q := params{
+		"enrollment[user_id]": {fmt.Sprint(userID)},
+		"enrollment[type]":    {string(typ)},
+	}
+	q.Add(opts)
+	resp, err := post(c.client, c.id("/courses/%d/enrollments"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	e := &Enrollment{client: c.client}
+	return e, decodeJSON(resp.Body, e)
+}
+
+// InvitationResult is the outcome of re-sending
+// the invitation for one enrollment.
+type InvitationResult struct {
+	Enrollment *Enrollment
+	// Err is any error from re-sending the invitation.
+	Err error
+}
+
+// InvitationReport is a summary of Course.ResendInvitations.
+type InvitationReport struct {
+	// Results has a result for every invitation that was re-sent
+	// or failed to send.
+	Results []*InvitationResult
+	// Sent is the number of invitations re-sent and Failed is the
+	// number that could not be sent.
+	Sent, Failed int
+	// Skipped is the number of pending enrollments that were left
+	// out by the filter or cannot be invited, like the test student.
+	Skipped int
+}
+
+// ResendInvitations will send the invitation email again for every
+// enrollment in the course that has not been accepted and that the filter
+// matches. A nil filter matches every pending enrollment and opts are
+// used to list the enrollments, e.g. ArrayOpt("type", "StudentEnrollment").
+//
+// The api has no endpoint for re-sending invitations so each pending
+// enrollment is created again with the same user, type, role, and section
+// along with Opt("enrollment[notify]", true), which makes canvas send the
+// invitation again without changing the enrollment. An invitation failing
+// to send does not stop the others, the report has a result for every
+// invitation and the returned error joins the errors of the ones that
+// failed.
+//
+//	report, err := course.ResendInvitations(func(e *canvas.Enrollment) bool {
+//		return e.CreatedAt.Before(time.Now().AddDate(0, 0, -7))
+//	})
+func (c *Course) ResendInvitations(filter func(*Enrollment) bool, opts ...Option) (*InvitationReport, error) {
+	opts = append([]Option{
+		ArrayOpt("state", string(EnrollmentInvited), string(EnrollmentCreationPending)),
+	}, opts...)
+	enrollments, err := c.ListEnrollments(opts...)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errl   []error
+		report = &InvitationReport{}
+		sem    = make(chan struct{}, bulkWorkers)
+	)
+	for _, e := range enrollments {
+		if !invitable(e) || (filter != nil && !filter(e)) {
+			report.Skipped++
+			continue
+		}
+		res := &InvitationResult{Enrollment: e}
+		report.Results = append(report.Results, res)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			res.Err = c.resendInvitation(res.Enrollment)
+			mu.Lock()
+			defer mu.Unlock()
+			if res.Err != nil {
+				report.Failed++
+				errl = append(errl, fmt.Errorf("enrollment %d: %w", res.Enrollment.ID, res.Err))
+			} else {
+				report.Sent++
+			}
+		}()
+	}
+	wg.Wait()
+	sort.Slice(errl, func(i, j int) bool { return errl[i].Error() < errl[j].Error() })
+	return report, joinErrs(errl)
+}
+
+// invitable returns true for enrollments that are waiting
+// on an invitation that canvas can send again.
+func invitable(e *Enrollment) bool {
+	switch EnrollmentState(e.EnrollmentState) {
+	case EnrollmentInvited, EnrollmentCreationPending:
+	default:
+		return false
+	}
+	return e.Type != string(StudentViewEnrollment) && e.UserID != 0
+}
+
+func (c *Course) resendInvitation(e *Enrollment) error {
+	opts := []Option{
+		Opt("enrollment[enrollment_state]", string(EnrollmentInvited)),
+		Opt("enrollment[notify]", true),
+	}
+	if e.CourseSectionID != 0 {
+		opts = append(opts, Opt("enrollment[course_section_id]", strconv.Itoa(e.CourseSectionID)))
+	}
+	if e.RoleID != 0 {
+		opts = append(opts, Opt("enrollment[role_id]", strconv.Itoa(e.RoleID)))
+	}
+	if e.LimitPrivilegesToCourseSection {
+		opts = append(opts, Opt("enrollment[limit_privileges_to_course_section]", true))
+	}
+	_, err := c.Enroll(e.UserID, EnrollmentType(e.Type), opts...)
+	return err
+}
+
+// Conclude will end the enrollment. The user can still see
+// the course but can no longer take part in it.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.destroy
+func (e *Enrollment) Conclude() error {
+	return e.task("conclude")
+}
+
+// Deactivate will make the enrollment inactive. The user cannot see
+// the course until the enrollment is reactivated.
+func (e *Enrollment) Deactivate() error {
+	return e.task("deactivate")
+}
+
+// Delete will delete the enrollment.
+func (e *Enrollment) Delete() error {
+	return e.task("delete")
+}
+
+// Reactivate will make an inactive enrollment active again.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.reactivate
+func (e *Enrollment) Reactivate() error {
+	resp, err := put(e.client, e.path("/reactivate"), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, e)
+}
+
+func (e *Enrollment) task(task string) error {
+	resp, err := delete(e.client, e.path(""), params{"task": {task}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, e)
+}
+
+func (e *Enrollment) path(s string) string {
+	return fmt.Sprintf("/courses/%d/enrollments/%d", e.CourseID, e.ID) + s
+}
@@ -0,0 +1,136 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestEnrollments(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			if q.Get("type[]") != "StudentEnrollment" {
+				t.Error("wrong filter")
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `[{"id":3,"course_id":1,"user_id":7,"type":"StudentEnrollment","enrollment_state":"active"}]`)
+		case "POST":
+			if q.Get("enrollment[user_id]") != "8" || q.Get("enrollment[type]") != "TaEnrollment" ||
+				q.Get("enrollment[enrollment_state]") != "active" {
+				t.Errorf("wrong enrollment: %v", q)
+			}
+			fmt.Fprint(w, `{"id":4,"course_id":1,"user_id":8,"type":"TaEnrollment","enrollment_state":"active"}`)
+		}
+	})
+	var tasks []string
+	mux.HandleFunc("/api/v1/courses/1/enrollments/3", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "DELETE")
+		task := r.URL.Query().Get("task")
+		tasks = append(tasks, task)
+		state := map[string]string{"conclude": "completed", "deactivate": "inactive", "delete": "deleted"}[task]
+		fmt.Fprintf(w, `{"id":3,"course_id":1,"enrollment_state":%q}`, state)
+	})
+	mux.HandleFunc("/api/v1/courses/1/enrollments/3/reactivate", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id":3,"course_id":1,"enrollment_state":"active"}`)
+	})
+	mux.HandleFunc("/api/v1/users/7/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[{"id":3,"course_id":1},{"id":9,"course_id":2}]`)
+	})
+
+	c := &Course{ID: 1, client: client}
+	enrollments, err := c.ListEnrollments(ArrayOpt("type", "StudentEnrollment"))
+	is.NoErr(err)
+	is.Equal(len(enrollments), 1)
+	e := enrollments[0]
+	is.NoErr(e.Conclude())
+	is.Equal(e.EnrollmentState, string(EnrollmentCompleted))
+	is.NoErr(e.Deactivate())
+	is.Equal(e.EnrollmentState, string(EnrollmentInactive))
+	is.NoErr(e.Reactivate())
+	is.Equal(e.EnrollmentState, string(EnrollmentActive))
+	is.NoErr(e.Delete())
+	is.Equal(e.EnrollmentState, string(EnrollmentDeleted))
+	is.Equal(tasks, []string{"conclude", "deactivate", "delete"})
+
+	ta, err := c.Enroll(8, TAEnrollment, Opt("enrollment[enrollment_state]", "active"))
+	is.NoErr(err)
+	is.Equal(ta.Type, string(TAEnrollment))
+
+	u := &User{ID: 7, client: client}
+	enrollments, err = u.ListEnrollments()
+	is.NoErr(err)
+	is.Equal(len(enrollments), 2)
+}
+
+func TestResendInvitations(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var (
+		mu      sync.Mutex
+		invited []string
+	)
+	mux.HandleFunc("/api/v1/courses/1/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			if s := q["state[]"]; len(s) != 2 || s[0] != "invited" || s[1] != "creation_pending" {
+				t.Errorf("wrong states: %v", s)
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `[
+				{"id":1,"course_id":1,"user_id":7,"type":"StudentEnrollment","enrollment_state":"invited","course_section_id":4},
+				{"id":2,"course_id":1,"user_id":8,"type":"TaEnrollment","enrollment_state":"creation_pending","role_id":9},
+				{"id":3,"course_id":1,"user_id":9,"type":"StudentEnrollment","enrollment_state":"invited"},
+				{"id":4,"course_id":1,"user_id":10,"type":"StudentViewEnrollment","enrollment_state":"invited"},
+				{"id":5,"course_id":1,"user_id":11,"type":"StudentEnrollment","enrollment_state":"invited"}]`)
+		case "POST":
+			if q.Get("enrollment[notify]") != "true" || q.Get("enrollment[enrollment_state]") != "invited" {
+				t.Errorf("wrong enrollment: %v", q)
+			}
+			user := q.Get("enrollment[user_id]")
+			switch user {
+			case "7":
+				if q.Get("enrollment[course_section_id]") != "4" {
+					t.Error("section not kept")
+				}
+			case "8":
+				if q.Get("enrollment[role_id]") != "9" || q.Get("enrollment[type]") != "TaEnrollment" {
+					t.Error("role not kept")
+				}
+			case "11":
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"message":"cannot invite"}`)
+				return
+			}
+			mu.Lock()
+			invited = append(invited, user)
+			mu.Unlock()
+			fmt.Fprintf(w, `{"id":1,"user_id":%s,"enrollment_state":"invited"}`, user)
+		}
+	})
+
+	c := &Course{ID: 1, client: client}
+	report, err := c.ResendInvitations(func(e *Enrollment) bool { return e.UserID != 9 })
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "enrollment 5"))
+	is.Equal(report.Sent, 2)
+	is.Equal(report.Failed, 1)
+	is.Equal(report.Skipped, 2)
+	is.Equal(len(report.Results), 3)
+	is.True(report.Results[2].Err != nil)
+	sort.Strings(invited)
+	is.Equal(invited, []string{"7", "8"})
+}
@@ -0,0 +1,296 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// canvasExtension is the caliper extension that
+// canvas puts its own information in.
+const canvasExtension = "com.instructure.canvas"
+
+// CaliperEvent is an event in the IMS Caliper 1.1 format.
+//
+// https://canvas.instructure.com/doc/api/file.caliper_live_events.html
+type CaliperEvent struct {
+	Context    string         `json:"@context"`
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	Action     string         `json:"action"`
+	EventTime  time.Time      `json:"eventTime"`
+	Actor      *CaliperEntity `json:"actor"`
+	Object     *CaliperEntity `json:"object"`
+	Generated  *CaliperEntity `json:"generated"`
+	Group      *CaliperEntity `json:"group"`
+	Membership *CaliperEntity `json:"membership"`
+	EdApp      *CaliperEntity `json:"edApp"`
+	Session    *CaliperEntity `json:"session"`
+	Extensions Extensions     `json:"extensions"`
+}
+
+// CaliperEntity is a person, course, assignment, or anything
+// else that is part of a caliper event. Only the fields used
+// by canvas events are included.
+type CaliperEntity struct {
+	// ID is a urn like "urn:instructure:canvas:course:1234".
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Extensions  Extensions `json:"extensions"`
+
+	DateCreated   time.Time `json:"dateCreated"`
+	DateModified  time.Time `json:"dateModified"`
+	DateToStartOn time.Time `json:"dateToStartOn"`
+	DateToSubmit  time.Time `json:"dateToSubmit"`
+	MaxScore      float64   `json:"maxScore"`
+	ScoreGiven    float64   `json:"scoreGiven"`
+	Count         int       `json:"count"`
+	Status        string    `json:"status"`
+	Roles         []string  `json:"roles"`
+
+	Assignable   *CaliperEntity `json:"assignable"`
+	Assignee     *CaliperEntity `json:"assignee"`
+	Attempt      *CaliperEntity `json:"attempt"`
+	ScoredBy     *CaliperEntity `json:"scoredBy"`
+	Member       *CaliperEntity `json:"member"`
+	Organization *CaliperEntity `json:"organization"`
+}
+
+// UnmarshalJSON accepts entities that are only an id.
+func (ce *CaliperEntity) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, &ce.ID)
+	}
+	type entity CaliperEntity
+	return json.Unmarshal(b, (*entity)(ce))
+}
+
+// CanvasID returns the canvas id of the entity. It is nil safe.
+func (ce *CaliperEntity) CanvasID() ID {
+	if ce == nil {
+		return ""
+	}
+	if id, ok := ce.Extensions.Canvas()["entity_id"]; ok {
+		return ID(str(id))
+	}
+	if i := strings.LastIndexByte(ce.ID, ':'); i >= 0 {
+		return ID(ce.ID[i+1:])
+	}
+	return ID(ce.ID)
+}
+
+// Extensions are the extra fields added to caliper
+// events and entities, keyed by the extension name.
+type Extensions map[string]map[string]interface{}
+
+// Canvas returns the fields added by canvas.
+func (ex Extensions) Canvas() map[string]interface{} {
+	return ex[canvasExtension]
+}
+
+type caliperEnvelope struct {
+	Sensor      string            `json:"sensor"`
+	SendTime    time.Time         `json:"sendTime"`
+	DataVersion string            `json:"dataVersion"`
+	Data        []json.RawMessage `json:"data"`
+}
+
+// caliperNames maps caliper events to canvas event names for
+// when canvas does not put the name in the event's extensions.
+// The keys are the event type, action, and object type.
+var caliperNames = map[[3]string]string{
+	{"AssessmentEvent", "Submitted", ""}:                     SubmissionCreatedEvent,
+	{"AssessmentEvent", "Modified", ""}:                      SubmissionUpdatedEvent,
+	{"GradeEvent", "Graded", ""}:                             GradeChangeEvent,
+	{"EntityEvent", "Created", "Membership"}:                 EnrollmentCreatedEvent,
+	{"EntityEvent", "Modified", "Membership"}:                EnrollmentUpdatedEvent,
+	{"EntityEvent", "Created", "AssignableDigitalResource"}:  AssignmentCreatedEvent,
+	{"EntityEvent", "Modified", "AssignableDigitalResource"}: AssignmentUpdatedEvent,
+	{"EntityEvent", "Created", "CourseOffering"}:             CourseCreatedEvent,
+	{"EntityEvent", "Modified", "CourseOffering"}:            CourseUpdatedEvent,
+	{"SessionEvent", "LoggedIn", ""}:                         LoggedInEvent,
+}
+
+func caliperName(ce *CaliperEvent) string {
+	if name := str(ce.Extensions.Canvas()["event_name"]); name != "" {
+		return name
+	}
+	var objectType string
+	if ce.Object != nil {
+		objectType = ce.Object.Type
+	}
+	if name, ok := caliperNames[[3]string{ce.Type, ce.Action, objectType}]; ok {
+		return name
+	}
+	return caliperNames[[3]string{ce.Type, ce.Action, ""}]
+}
+
+func parseCaliper(b []byte) ([]*Event, error) {
+	var env caliperEnvelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(env.Data))
+	for _, raw := range env.Data {
+		ce := &CaliperEvent{}
+		if err := json.Unmarshal(raw, ce); err != nil {
+			return nil, err
+		}
+		e := &Event{
+			Name:    caliperName(ce),
+			Time:    ce.EventTime,
+			Format:  FormatCaliper,
+			Body:    raw,
+			Caliper: ce,
+		}
+		if err := caliperMetadata(e, ce); err != nil {
+			return nil, err
+		}
+		e.Data = caliperData(e.Name, ce)
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// caliperMetadata fills in the event's metadata from the
+// canvas extension, the actor, and the group.
+func caliperMetadata(e *Event, ce *CaliperEvent) error {
+	if ext := ce.Extensions.Canvas(); ext != nil {
+		b, err := json.Marshal(ext)
+		if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(b, &e.Metadata); err != nil {
+			return fmt.Errorf("events: bad caliper extension: %w", err)
+		}
+	}
+	e.Metadata.EventName = e.Name
+	e.Metadata.EventTime = ce.EventTime.Format(time.RFC3339Nano)
+	if ce.Actor != nil {
+		e.Metadata.UserID = ce.Actor.CanvasID()
+		if login := str(ce.Actor.Extensions.Canvas()["user_login"]); login != "" {
+			e.Metadata.UserLogin = login
+		}
+	}
+	if ce.Group != nil {
+		e.Metadata.ContextID = ce.Group.CanvasID()
+		switch ce.Group.Type {
+		case "CourseOffering":
+			e.Metadata.ContextType = "Course"
+		case "Group":
+			e.Metadata.ContextType = "Group"
+		default:
+			e.Metadata.ContextType = "Account"
+		}
+	}
+	return nil
+}
+
+// caliperData builds the typed body for a caliper event
+// from its entities. It returns nil for unknown events.
+func caliperData(name string, ce *CaliperEvent) interface{} {
+	obj := ce.Object
+	if obj == nil {
+		obj = &CaliperEntity{}
+	}
+	switch name {
+	case SubmissionCreatedEvent, SubmissionUpdatedEvent:
+		s := Submission{
+			SubmissionID: obj.CanvasID(),
+			AssignmentID: obj.Assignable.CanvasID(),
+			UserID:       obj.Assignee.CanvasID(),
+			Attempt:      obj.Count,
+			SubmittedAt:  obj.DateCreated,
+			UpdatedAt:    obj.DateModified,
+		}
+		if name == SubmissionCreatedEvent {
+			return (*SubmissionCreated)(&s)
+		}
+		return (*SubmissionUpdated)(&s)
+	case GradeChangeEvent:
+		score := ce.Generated
+		if score == nil {
+			score = &CaliperEntity{}
+		}
+		attempt := score.Attempt
+		if attempt == nil {
+			attempt = obj
+		}
+		return &GradeChanged{
+			SubmissionID:   attempt.CanvasID(),
+			AssignmentID:   attempt.Assignable.CanvasID(),
+			StudentID:      attempt.Assignee.CanvasID(),
+			CourseID:       ce.Group.CanvasID(),
+			GraderID:       score.ScoredBy.CanvasID(),
+			Score:          score.ScoreGiven,
+			PointsPossible: score.MaxScore,
+			UpdatedAt:      score.DateCreated,
+		}
+	case EnrollmentCreatedEvent, EnrollmentUpdatedEvent:
+		en := Enrollment{
+			EnrollmentID:  obj.CanvasID(),
+			CourseID:      obj.Organization.CanvasID(),
+			UserID:        obj.Member.CanvasID(),
+			WorkflowState: obj.Status,
+			CreatedAt:     obj.DateCreated,
+			UpdatedAt:     obj.DateModified,
+		}
+		if len(obj.Roles) > 0 {
+			en.Type = obj.Roles[0]
+		}
+		if name == EnrollmentCreatedEvent {
+			return (*EnrollmentCreated)(&en)
+		}
+		return (*EnrollmentUpdated)(&en)
+	case AssignmentCreatedEvent, AssignmentUpdatedEvent:
+		a := Assignment{
+			AssignmentID:   obj.CanvasID(),
+			Title:          obj.Name,
+			Description:    obj.Description,
+			PointsPossible: obj.MaxScore,
+			DueAt:          obj.DateToSubmit,
+			UnlockAt:       obj.DateToStartOn,
+			UpdatedAt:      obj.DateModified,
+		}
+		if ce.Group != nil {
+			a.ContextType, a.ContextID = "Course", ce.Group.CanvasID()
+		}
+		if name == AssignmentCreatedEvent {
+			return (*AssignmentCreated)(&a)
+		}
+		return (*AssignmentUpdated)(&a)
+	case CourseCreatedEvent, CourseUpdatedEvent:
+		c := Course{
+			CourseID:      obj.CanvasID(),
+			Name:          obj.Name,
+			WorkflowState: obj.Status,
+			CreatedAt:     obj.DateCreated,
+			UpdatedAt:     obj.DateModified,
+		}
+		if name == CourseCreatedEvent {
+			return (*CourseCreated)(&c)
+		}
+		return (*CourseUpdated)(&c)
+	case LoggedInEvent:
+		return &LoggedIn{RedirectURL: str(obj.Extensions.Canvas()["redirect_url"])}
+	}
+	return nil
+}
+
+// str converts an extension value to a string, ids
+// are sometimes sent as numbers.
+func str(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+const caliperGrade = `{
+	"sensor": "http://canvas.example.com/",
+	"sendTime": "2026-09-01T12:30:01.000Z",
+	"dataVersion": "http://purl.imsglobal.org/ctx/caliper/v1p1",
+	"data": [{
+		"@context": "http://purl.imsglobal.org/ctx/caliper/v1p1",
+		"id": "urn:uuid:1",
+		"type": "GradeEvent",
+		"action": "Graded",
+		"eventTime": "2026-09-01T12:30:00.000Z",
+		"actor": {
+			"id": "urn:instructure:canvas:user:9",
+			"type": "Person",
+			"extensions": {"com.instructure.canvas": {"user_login": "teacher", "entity_id": "9"}}
+		},
+		"object": {
+			"id": "urn:instructure:canvas:submission:7",
+			"type": "Attempt",
+			"assignee": "urn:instructure:canvas:user:21",
+			"assignable": {"id": "urn:instructure:canvas:assignment:3", "type": "AssignableDigitalResource"}
+		},
+		"generated": {
+			"id": "urn:instructure:canvas:submission:7",
+			"type": "Score",
+			"scoreGiven": 9,
+			"maxScore": 10,
+			"scoredBy": "urn:instructure:canvas:user:9"
+		},
+		"group": {"id": "urn:instructure:canvas:course:42", "type": "CourseOffering"},
+		"extensions": {"com.instructure.canvas": {"root_account_id": "1", "request_id": "abc", "hostname": "canvas.example.com"}}
+	}, {
+		"type": "SessionEvent",
+		"action": "LoggedIn",
+		"eventTime": "2026-09-01T12:31:00.000Z",
+		"actor": {"id": "urn:instructure:canvas:user:21", "type": "Person"},
+		"object": {"id": "http://canvas.example.com/", "extensions": {"com.instructure.canvas": {"redirect_url": "http://canvas.example.com/courses/42"}}}
+	}, {
+		"type": "NavigationEvent",
+		"action": "NavigatedTo",
+		"eventTime": "2026-09-01T12:32:00.000Z",
+		"extensions": {"com.instructure.canvas": {"event_name": "asset_accessed"}}
+	}]
+}`
+
+func TestParseCaliper(t *testing.T) {
+	events, err := ParseMessage([]byte(caliperGrade))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events; got %d", len(events))
+	}
+	e := events[0]
+	if e.Name != GradeChangeEvent || e.Format != FormatCaliper || e.Caliper == nil {
+		t.Errorf("wrong event: %+v", e)
+	}
+	if !e.Time.Equal(time.Date(2026, 9, 1, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("wrong event time: %v", e.Time)
+	}
+	m := e.Metadata
+	if m.UserID != "9" || m.UserLogin != "teacher" || m.ContextType != "Course" || m.ContextID != "42" ||
+		m.RootAccountID != "1" || m.RequestID != "abc" || m.EventName != GradeChangeEvent {
+		t.Errorf("wrong metadata: %+v", m)
+	}
+	g, ok := e.Data.(*GradeChanged)
+	if !ok {
+		t.Fatalf("wrong data type %T", e.Data)
+	}
+	if g.SubmissionID != "7" || g.AssignmentID != "3" || g.StudentID != "21" || g.GraderID != "9" ||
+		g.CourseID != "42" || g.Score != 9 || g.PointsPossible != 10 {
+		t.Errorf("wrong grade change: %+v", g)
+	}
+
+	login, ok := events[1].Data.(*LoggedIn)
+	if events[1].Name != LoggedInEvent || !ok || login.RedirectURL != "http://canvas.example.com/courses/42" {
+		t.Errorf("wrong login event: %+v", events[1])
+	}
+	if events[1].Metadata.UserID != "21" {
+		t.Errorf("wrong user: %q", events[1].Metadata.UserID)
+	}
+	if events[2].Name != "asset_accessed" || events[2].Data != nil {
+		t.Errorf("the event name should come from the canvas extension: %+v", events[2])
+	}
+}
+
+func TestCaliperEntityID(t *testing.T) {
+	var nilEntity *CaliperEntity
+	for _, tt := range []struct {
+		e  *CaliperEntity
+		id ID
+	}{
+		{nilEntity, ""},
+		{&CaliperEntity{ID: "urn:instructure:canvas:course:42"}, "42"},
+		{&CaliperEntity{ID: "urn:instructure:canvas:course:42", Extensions: Extensions{
+			canvasExtension: {"entity_id": 10000000000042.0},
+		}}, "10000000000042"},
+		{&CaliperEntity{ID: "plain"}, "plain"},
+	} {
+		if id := tt.e.CanvasID(); id != tt.id {
+			t.Errorf("wrong id: got %q, want %q", id, tt.id)
+		}
+	}
+}
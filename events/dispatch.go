@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/harrybrwn/errs"
+)
+
+// AllEvents can be given to Dispatcher.Handle to
+// register a handler for every event.
+const AllEvents = "*"
+
+// Handler handles live events.
+type Handler interface {
+	HandleEvent(ctx context.Context, e *Event) error
+}
+
+// HandlerFunc is a function that can be used as a Handler.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+// HandleEvent calls the function.
+func (f HandlerFunc) HandleEvent(ctx context.Context, e *Event) error { return f(ctx, e) }
+
+// Dispatcher sends events to the handlers registered for the event's
+// name. The zero value is ready to use and it is safe to register
+// handlers while events are being dispatched.
+type Dispatcher struct {
+	// ErrorHandler is called with errors from ServeHTTP since they
+	// cannot be returned. Errors are ignored if it is nil.
+	ErrorHandler func(error)
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// Handle registers a handler for the events with the given name. Use
+// AllEvents to handle every event.
+func (d *Dispatcher) Handle(name string, h Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.handlers == nil {
+		d.handlers = make(map[string][]Handler)
+	}
+	d.handlers[name] = append(d.handlers[name], h)
+}
+
+// HandleFunc registers a handler function for the events with the given name.
+func (d *Dispatcher) HandleFunc(name string, f func(ctx context.Context, e *Event) error) {
+	d.Handle(name, HandlerFunc(f))
+}
+
+// Dispatch calls the handlers registered for the event in the order
+// they were registered, followed by the handlers for all events. Every
+// handler is called and their errors are returned together.
+func (d *Dispatcher) Dispatch(ctx context.Context, e *Event) error {
+	d.mu.RLock()
+	handlers := make([]Handler, 0, len(d.handlers[e.Name])+len(d.handlers[AllEvents]))
+	handlers = append(handlers, d.handlers[e.Name]...)
+	handlers = append(handlers, d.handlers[AllEvents]...)
+	d.mu.RUnlock()
+	var errl []error
+	for _, h := range handlers {
+		if err := h.HandleEvent(ctx, e); err != nil {
+			errl = append(errl, err)
+		}
+	}
+	return errs.Chain(errl...)
+}
+
+// HandleMessage parses the body of an SQS message or webhook delivery
+// and dispatches each event in it. An error means the message should be
+// delivered again, so the message should only be deleted from the
+// queue when nil is returned.
+func (d *Dispatcher) HandleMessage(ctx context.Context, body []byte) error {
+	events, err := ParseMessage(body)
+	if err != nil {
+		return err
+	}
+	var errl []error
+	for _, e := range events {
+		if err = d.Dispatch(ctx, e); err != nil {
+			errl = append(errl, err)
+		}
+	}
+	return errs.Chain(errl...)
+}
+
+// ServeHTTP accepts live events delivered by canvas. Canvas is always
+// told that the delivery was accepted once it has been parsed, handler
+// errors are given to the ErrorHandler.
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	events, err := Parse(r.Body)
+	if err != nil {
+		d.handleErr(err)
+		http.Error(w, "bad event", http.StatusBadRequest)
+		return
+	}
+	for _, e := range events {
+		if err = d.Dispatch(r.Context(), e); err != nil {
+			d.handleErr(err)
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (d *Dispatcher) handleErr(err error) {
+	if d.ErrorHandler != nil {
+		d.ErrorHandler(err)
+	}
+}
@@ -0,0 +1,347 @@
+// Package events decodes canvas Live Events so that programs can react to
+// things happening in canvas as they happen instead of polling the api.
+//
+// Canvas delivers live events to an Amazon SQS queue or to an https
+// endpoint in either the canvas raw format or the IMS Caliper 1.1 format.
+// Parse and ParseMessage handle both formats and turn the event bodies
+// into typed structs like *SubmissionCreated and *GradeChanged. A
+// Dispatcher sends each event to the handlers registered for its name.
+//
+//	d := &events.Dispatcher{}
+//	d.HandleFunc(events.GradeChangeEvent, func(ctx context.Context, e *events.Event) error {
+//		g := e.Data.(*events.GradeChanged)
+//		log.Printf("user %s got %v on assignment %s", g.StudentID, g.Score, g.AssignmentID)
+//		return nil
+//	})
+//	http.Handle("/canvas/events", d)
+//
+// Messages read from SQS can be given to Dispatcher.HandleMessage.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_introduction.html
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+// Format is the format that an event was delivered in.
+type Format string
+
+// The formats canvas can deliver events in.
+const (
+	FormatCanvas  Format = "canvas"
+	FormatCaliper Format = "caliper"
+)
+
+// Names of the events that are decoded into typed structs.
+const (
+	SubmissionCreatedEvent = "submission_created"
+	SubmissionUpdatedEvent = "submission_updated"
+	GradeChangeEvent       = "grade_change"
+	EnrollmentCreatedEvent = "enrollment_created"
+	EnrollmentUpdatedEvent = "enrollment_updated"
+	AssignmentCreatedEvent = "assignment_created"
+	AssignmentUpdatedEvent = "assignment_updated"
+	CourseCreatedEvent     = "course_created"
+	CourseUpdatedEvent     = "course_updated"
+	LoggedInEvent          = "logged_in"
+)
+
+// ErrUnknownFormat is returned when a message is
+// not in the canvas or caliper format.
+var ErrUnknownFormat = errors.New("events: unknown event format")
+
+// Event is a canvas live event.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_event_metadata.html
+type Event struct {
+	// Name is the canvas event name, e.g. "grade_change". Caliper
+	// events are given the name of the matching canvas event.
+	Name   string
+	Time   time.Time
+	Format Format
+	// Metadata is the information canvas sends with every event.
+	Metadata Metadata
+	// Data is the typed event body, e.g. *GradeChanged for
+	// "grade_change" events. It is nil for events that this
+	// package does not have a type for.
+	Data interface{}
+	// Body is the raw event body. For caliper events
+	// it is the whole caliper event.
+	Body json.RawMessage
+	// Caliper is set for events delivered in the caliper format.
+	Caliper *CaliperEvent
+}
+
+// Metadata is sent with every event in the canvas format.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_event_metadata.html
+type Metadata struct {
+	EventName     string `json:"event_name"`
+	EventTime     string `json:"event_time"`
+	RootAccountID ID     `json:"root_account_id"`
+	UserID        ID     `json:"user_id"`
+	RealUserID    ID     `json:"real_user_id"`
+	UserLogin     string `json:"user_login"`
+	UserSISID     string `json:"user_sis_id"`
+	// ContextType is "Course", "Account", etc.
+	ContextType  string `json:"context_type"`
+	ContextID    ID     `json:"context_id"`
+	ContextRole  string `json:"context_role"`
+	RequestID    string `json:"request_id"`
+	SessionID    string `json:"session_id"`
+	Hostname     string `json:"hostname"`
+	UserAgent    string `json:"user_agent"`
+	ClientIP     string `json:"client_ip"`
+	URL          string `json:"url"`
+	Producer     string `json:"producer"`
+	JobID        ID     `json:"job_id"`
+	JobTag       string `json:"job_tag"`
+	TimeZone     string `json:"time_zone"`
+	DeveloperKey ID     `json:"developer_key_id"`
+}
+
+// ID is a canvas id. Canvas sends ids as strings
+// most of the time but sometimes sends numbers.
+type ID string
+
+// UnmarshalJSON accepts strings and numbers.
+func (id *ID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*id = ""
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*id = ID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*id = ID(n)
+	return nil
+}
+
+// Submission is the body of submission events.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_submission.html
+type Submission struct {
+	SubmissionID   ID        `json:"submission_id"`
+	AssignmentID   ID        `json:"assignment_id"`
+	UserID         ID        `json:"user_id"`
+	GroupID        ID        `json:"group_id"`
+	Attempt        int       `json:"attempt"`
+	Score          float64   `json:"score"`
+	Grade          string    `json:"grade"`
+	SubmissionType string    `json:"submission_type"`
+	Body           string    `json:"body"`
+	URL            string    `json:"url"`
+	WorkflowState  string    `json:"workflow_state"`
+	Late           bool      `json:"late"`
+	Missing        bool      `json:"missing"`
+	SubmittedAt    time.Time `json:"submitted_at"`
+	GradedAt       time.Time `json:"graded_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	LTIUserID      string    `json:"lti_user_id"`
+}
+
+// SubmissionCreated is sent when a student submits an assignment.
+type SubmissionCreated Submission
+
+// SubmissionUpdated is sent when a submission is changed.
+type SubmissionUpdated Submission
+
+// GradeChanged is sent when a submission's grade changes.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_grade.html
+type GradeChanged struct {
+	SubmissionID      ID        `json:"submission_id"`
+	AssignmentID      ID        `json:"assignment_id"`
+	CourseID          ID        `json:"course_id"`
+	StudentID         ID        `json:"student_id"`
+	StudentSISID      string    `json:"student_sis_id"`
+	GraderID          ID        `json:"grader_id"`
+	Grade             string    `json:"grade"`
+	OldGrade          string    `json:"old_grade"`
+	Score             float64   `json:"score"`
+	OldScore          float64   `json:"old_score"`
+	PointsPossible    float64   `json:"points_possible"`
+	OldPointsPossible float64   `json:"old_points_possible"`
+	GradingComplete   bool      `json:"grading_complete"`
+	Muted             bool      `json:"muted"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Enrollment is the body of enrollment events.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_enrollment.html
+type Enrollment struct {
+	EnrollmentID ID     `json:"enrollment_id"`
+	CourseID     ID     `json:"course_id"`
+	SectionID    ID     `json:"course_section_id"`
+	UserID       ID     `json:"user_id"`
+	UserName     string `json:"user_name"`
+	// Type is the enrollment type, e.g. "StudentEnrollment".
+	Type                 string    `json:"type"`
+	AssociatedUserID     ID        `json:"associated_user_id"`
+	LimitToCourseSection bool      `json:"limit_privileges_to_course_section"`
+	WorkflowState        string    `json:"workflow_state"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// EnrollmentCreated is sent when a user is enrolled in a course.
+type EnrollmentCreated Enrollment
+
+// EnrollmentUpdated is sent when an enrollment is changed.
+type EnrollmentUpdated Enrollment
+
+// Assignment is the body of assignment events.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_assignment.html
+type Assignment struct {
+	AssignmentID ID `json:"assignment_id"`
+	// ContextType is "Course" and ContextID
+	// is the course id.
+	ContextType     string    `json:"context_type"`
+	ContextID       ID        `json:"context_id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	PointsPossible  float64   `json:"points_possible"`
+	SubmissionTypes string    `json:"submission_types"`
+	WorkflowState   string    `json:"workflow_state"`
+	DueAt           time.Time `json:"due_at"`
+	UnlockAt        time.Time `json:"unlock_at"`
+	LockAt          time.Time `json:"lock_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// AssignmentCreated is sent when an assignment is created.
+type AssignmentCreated Assignment
+
+// AssignmentUpdated is sent when an assignment is changed.
+type AssignmentUpdated Assignment
+
+// Course is the body of course events.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_course.html
+type Course struct {
+	CourseID      ID        `json:"course_id"`
+	UUID          string    `json:"uuid"`
+	AccountID     ID        `json:"account_id"`
+	Name          string    `json:"name"`
+	WorkflowState string    `json:"workflow_state"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CourseCreated is sent when a course is created.
+type CourseCreated Course
+
+// CourseUpdated is sent when a course is changed.
+type CourseUpdated Course
+
+// LoggedIn is sent when a user logs in.
+type LoggedIn struct {
+	RedirectURL string `json:"redirect_url"`
+}
+
+// newData returns a value for the typed body of
+// the named event or nil if there is no type for it.
+func newData(name string) interface{} {
+	switch name {
+	case SubmissionCreatedEvent:
+		return &SubmissionCreated{}
+	case SubmissionUpdatedEvent:
+		return &SubmissionUpdated{}
+	case GradeChangeEvent:
+		return &GradeChanged{}
+	case EnrollmentCreatedEvent:
+		return &EnrollmentCreated{}
+	case EnrollmentUpdatedEvent:
+		return &EnrollmentUpdated{}
+	case AssignmentCreatedEvent:
+		return &AssignmentCreated{}
+	case AssignmentUpdatedEvent:
+		return &AssignmentUpdated{}
+	case CourseCreatedEvent:
+		return &CourseCreated{}
+	case CourseUpdatedEvent:
+		return &CourseUpdated{}
+	case LoggedInEvent:
+		return &LoggedIn{}
+	}
+	return nil
+}
+
+// Parse will decode one delivery from canvas. Canvas format deliveries
+// hold one event and caliper deliveries may hold more than one.
+func Parse(r io.Reader) ([]*Event, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMessage(b)
+}
+
+// ParseMessage will decode the body of an SQS message
+// or webhook delivery in either format.
+func ParseMessage(b []byte) ([]*Event, error) {
+	var probe struct {
+		Metadata    json.RawMessage `json:"metadata"`
+		Data        json.RawMessage `json:"data"`
+		DataVersion string          `json:"dataVersion"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return nil, err
+	}
+	switch {
+	case len(probe.Metadata) > 0:
+		e, err := parseCanvas(b)
+		if err != nil {
+			return nil, err
+		}
+		return []*Event{e}, nil
+	case len(probe.Data) > 0 || probe.DataVersion != "":
+		return parseCaliper(b)
+	}
+	return nil, ErrUnknownFormat
+}
+
+func parseCanvas(b []byte) (*Event, error) {
+	var raw struct {
+		Metadata Metadata        `json:"metadata"`
+		Body     json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Metadata.EventName == "" {
+		return nil, errors.New("events: event has no name")
+	}
+	e := &Event{
+		Name:     raw.Metadata.EventName,
+		Format:   FormatCanvas,
+		Metadata: raw.Metadata,
+		Body:     raw.Body,
+	}
+	e.Time, _ = time.Parse(time.RFC3339Nano, raw.Metadata.EventTime)
+	if data := newData(e.Name); data != nil && len(raw.Body) > 0 {
+		if err := json.Unmarshal(raw.Body, data); err != nil {
+			return nil, fmt.Errorf("events: could not decode %s body: %w", e.Name, err)
+		}
+		e.Data = data
+	}
+	return e, nil
+}
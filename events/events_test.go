@@ -0,0 +1,151 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const gradeChange = `{
+	"metadata": {
+		"event_name": "grade_change",
+		"event_time": "2026-09-01T12:30:00.000Z",
+		"user_id": 21,
+		"root_account_id": "1",
+		"context_type": "Course",
+		"context_id": "42",
+		"request_id": "abc"
+	},
+	"body": {
+		"submission_id": "7",
+		"assignment_id": "3",
+		"student_id": "21",
+		"grader_id": "9",
+		"score": 9,
+		"old_score": 7,
+		"grade": "9",
+		"points_possible": 10,
+		"updated_at": "2026-09-01T12:30:00Z"
+	}
+}`
+
+const submissionCreated = `{
+	"metadata": {"event_name": "submission_created", "event_time": "2026-09-01T12:00:00Z", "user_id": "21"},
+	"body": {"submission_id": "7", "assignment_id": "3", "user_id": "21", "attempt": 2, "submission_type": "online_upload", "late": true}
+}`
+
+func TestParseCanvas(t *testing.T) {
+	events, err := ParseMessage([]byte(gradeChange))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected one event; got %d", len(events))
+	}
+	e := events[0]
+	if e.Name != GradeChangeEvent || e.Format != FormatCanvas || e.Metadata.UserID != "21" || e.Metadata.ContextID != "42" {
+		t.Errorf("wrong event: %+v", e)
+	}
+	if !e.Time.Equal(time.Date(2026, 9, 1, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("wrong event time: %v", e.Time)
+	}
+	g, ok := e.Data.(*GradeChanged)
+	if !ok {
+		t.Fatalf("wrong data type %T", e.Data)
+	}
+	if g.SubmissionID != "7" || g.Score != 9 || g.OldScore != 7 || g.GraderID != "9" || g.UpdatedAt.IsZero() {
+		t.Errorf("wrong grade change: %+v", g)
+	}
+
+	events, err = Parse(strings.NewReader(submissionCreated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, ok := events[0].Data.(*SubmissionCreated)
+	if !ok || s.Attempt != 2 || !s.Late || s.SubmissionType != "online_upload" {
+		t.Errorf("wrong submission: %+v", events[0].Data)
+	}
+
+	// events without a type still have their body
+	events, err = ParseMessage([]byte(`{"metadata":{"event_name":"wiki_page_created"},"body":{"title":"hi"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Data != nil || string(events[0].Body) != `{"title":"hi"}` {
+		t.Errorf("wrong untyped event: %+v", events[0])
+	}
+
+	if _, err = ParseMessage([]byte(`{"body":{}}`)); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat; got %v", err)
+	}
+	if _, err = ParseMessage([]byte(`{"metadata":{}}`)); err == nil {
+		t.Error("expected an error for an event with no name")
+	}
+	if _, err = ParseMessage([]byte(`{"metadata":{"event_name":"grade_change"},"body":{"score":"nine"}}`)); err == nil {
+		t.Error("expected an error for a bad body")
+	}
+	if _, err = ParseMessage([]byte(`[`)); err == nil {
+		t.Error("expected an error for bad json")
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	var (
+		d       = &Dispatcher{}
+		calls   []string
+		handled []error
+	)
+	d.HandleFunc(GradeChangeEvent, func(ctx context.Context, e *Event) error {
+		calls = append(calls, "grade")
+		return nil
+	})
+	d.HandleFunc(GradeChangeEvent, func(ctx context.Context, e *Event) error {
+		calls = append(calls, "failing")
+		return errors.New("handler is down")
+	})
+	d.Handle(AllEvents, HandlerFunc(func(ctx context.Context, e *Event) error {
+		calls = append(calls, "all:"+e.Name)
+		return nil
+	}))
+	d.ErrorHandler = func(err error) { handled = append(handled, err) }
+
+	err := d.HandleMessage(context.Background(), []byte(gradeChange))
+	if err == nil || !strings.Contains(err.Error(), "handler is down") {
+		t.Errorf("expected the handler's error; got %v", err)
+	}
+	if strings.Join(calls, ",") != "grade,failing,all:grade_change" {
+		t.Errorf("wrong handler calls: %v", calls)
+	}
+
+	calls = nil
+	if err = d.HandleMessage(context.Background(), []byte(submissionCreated)); err != nil {
+		t.Error(err)
+	}
+	if strings.Join(calls, ",") != "all:submission_created" {
+		t.Errorf("wrong handler calls: %v", calls)
+	}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		d.ServeHTTP(rec, httptest.NewRequest("POST", "/events", strings.NewReader(body)))
+		return rec
+	}
+	if rec := post(gradeChange); rec.Code != http.StatusAccepted {
+		t.Errorf("expected 202 even when a handler fails; got %d", rec.Code)
+	}
+	if len(handled) != 1 {
+		t.Errorf("handler errors should be handled: %v", handled)
+	}
+	if rec := post(`not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400; got %d", rec.Code)
+	}
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, httptest.NewRequest("GET", "/events", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "POST" {
+		t.Errorf("expected 405; got %d", rec.Code)
+	}
+}
@@ -0,0 +1,51 @@
+package canvas
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AssignmentExtension is the number of extra attempts a
+// student has been given for an assignment.
+type AssignmentExtension struct {
+	AssignmentID  int `json:"assignment_id"`
+	UserID        int `json:"user_id"`
+	ExtraAttempts int `json:"extra_attempts"`
+}
+
+// SetExtensions will give a student extra attempts on the assignment
+// on top of the assignment's allowed attempts. This only has an effect
+// on assignments that limit the number of attempts.
+//
+// https://canvas.instructure.com/doc/api/assignment_extensions.html#method.assignment_extensions.create
+func (a *Assignment) SetExtensions(userID, extraAttempts int) (*AssignmentExtension, error) {
+	exts, err := setAssignmentExtensions(a.client, a.CourseID, a.ID, []AssignmentExtension{
+		{UserID: userID, ExtraAttempts: extraAttempts},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(exts) == 0 {
+		return nil, fmt.Errorf("no extension returned for user %d", userID)
+	}
+	return &exts[0], nil
+}
+
+func setAssignmentExtensions(d doer, courseID, assignmentID int, exts []AssignmentExtension) ([]AssignmentExtension, error) {
+	q := params{}
+	for _, e := range exts {
+		q["assignment_extensions[][user_id]"] = append(
+			q["assignment_extensions[][user_id]"], strconv.Itoa(e.UserID))
+		q["assignment_extensions[][extra_attempts]"] = append(
+			q["assignment_extensions[][extra_attempts]"], strconv.Itoa(e.ExtraAttempts))
+	}
+	resp, err := post(d, fmt.Sprintf("/courses/%d/assignments/%d/extensions", courseID, assignmentID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Extensions []AssignmentExtension `json:"assignment_extensions"`
+	}
+	return result.Extensions, decodeJSON(resp.Body, &result)
+}
@@ -0,0 +1,90 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Verbosity settings for external feeds.
+const (
+	FeedFull     = "full"
+	FeedTruncate = "truncate"
+	FeedLinkOnly = "link_only"
+)
+
+// ExternalFeed is an rss or atom feed that canvas will
+// automatically post to a course as announcements.
+type ExternalFeed struct {
+	ID          int    `json:"id" url:"-"`
+	DisplayName string `json:"display_name" url:"-"`
+	URL         string `json:"url" url:"url"`
+	// HeaderMatch will only post items with titles
+	// that contain this phrase if it is set.
+	HeaderMatch string `json:"header_match" url:"header_match,omitempty"`
+	// Verbosity is how much of each item is posted, one
+	// of FeedFull, FeedTruncate, or FeedLinkOnly.
+	Verbosity string    `json:"verbosity" url:"verbosity,omitempty"`
+	CreatedAt time.Time `json:"created_at" url:"-"`
+}
+
+// ExternalFeeds will get the course's external feeds.
+//
+// https://canvas.instructure.com/doc/api/announcement_external_feeds.html#method.external_feeds.index
+func (c *Course) ExternalFeeds(opts ...Option) (feeds []*ExternalFeed, err error) {
+	ch := make(chan *ExternalFeed)
+	errs := newPaginatedList(c.client, c.id("/courses/%d/external_feeds"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			f := &ExternalFeed{}
+			if err := dec.Decode(f); err != nil {
+				return err
+			}
+			ch <- f
+			return nil
+		})
+	}, opts).start()
+	var errl []error
+	for {
+		select {
+		case f := <-ch:
+			feeds = append(feeds, f)
+		case err, ok := <-errs:
+			if !ok {
+				return feeds, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// CreateExternalFeed will add an external feed to the course.
+//
+// https://canvas.instructure.com/doc/api/announcement_external_feeds.html#method.external_feeds.create
+func (c *Course) CreateExternalFeed(feed ExternalFeed) (*ExternalFeed, error) {
+	q, err := query.Values(&feed)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := post(c.client, c.id("/courses/%d/external_feeds"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	f := &ExternalFeed{}
+	return f, decodeJSON(resp.Body, f)
+}
+
+// DeleteExternalFeed will remove an external feed from the course.
+//
+// https://canvas.instructure.com/doc/api/announcement_external_feeds.html#method.external_feeds.destroy
+func (c *Course) DeleteExternalFeed(id int) (*ExternalFeed, error) {
+	resp, err := delete(c.client, fmt.Sprintf("/courses/%d/external_feeds/%d", c.ID, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	f := &ExternalFeed{}
+	return f, decodeJSON(resp.Body, f)
+}
@@ -0,0 +1,254 @@
+package canvas
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// ExternalToolTag links an assignment to the external tool (LTI)
+// that students use to submit it.
+//
+//	c.CreateAssignment(canvas.Assignment{
+//		Name:            "Lab 1",
+//		SubmissionTypes: []string{"external_tool"},
+//		ExternalToolTagAttributes: &canvas.ExternalToolTag{
+//			URL:    "https://tool.example.com/launch",
+//			NewTab: true,
+//		},
+//	})
+type ExternalToolTag struct {
+	// URL is the launch url of the tool.
+	URL string `json:"url" url:"url,omitempty"`
+	// NewTab will open the tool in a new tab.
+	NewTab bool `json:"new_tab" url:"new_tab,omitempty"`
+	// ContentID is the id of the external tool and ContentType is
+	// always "ContextExternalTool" when the tool is known.
+	ContentID      int    `json:"content_id" url:"content_id,omitempty"`
+	ContentType    string `json:"content_type" url:"content_type,omitempty"`
+	ResourceLinkID string `json:"resource_link_id" url:"-"`
+	ExternalData   string `json:"external_data" url:"-"`
+}
+
+// External tool privacy levels, they control how
+// much user information is sent to the tool.
+const (
+	PrivacyAnonymous = "anonymous"
+	PrivacyNameOnly  = "name_only"
+	PrivacyEmailOnly = "email_only"
+	PrivacyPublic    = "public"
+)
+
+// ExternalTool is an LTI tool installed in a course or account.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html
+type ExternalTool struct {
+	ID          int    `json:"id" url:"-"`
+	Name        string `json:"name" url:"name,omitempty"`
+	Description string `json:"description" url:"description,omitempty"`
+	// URL or Domain is used to match the tool to launch urls.
+	URL    string `json:"url" url:"url,omitempty"`
+	Domain string `json:"domain" url:"domain,omitempty"`
+	// PrivacyLevel is one of PrivacyAnonymous, PrivacyNameOnly,
+	// PrivacyEmailOnly, or PrivacyPublic.
+	PrivacyLevel string `json:"privacy_level" url:"privacy_level,omitempty"`
+	ConsumerKey  string `json:"consumer_key" url:"consumer_key,omitempty"`
+	// SharedSecret is only sent when creating or editing
+	// a tool, canvas never returns it.
+	SharedSecret  string `json:"-" url:"shared_secret,omitempty"`
+	IconURL       string `json:"icon_url" url:"icon_url,omitempty"`
+	NotSelectable bool   `json:"not_selectable" url:"not_selectable,omitempty"`
+	// ClientID is the developer key of an LTI 1.3 tool.
+	ClientID string `json:"-" url:"client_id,omitempty"`
+	// ConfigType is "by_url" or "by_xml" when the tool is configured
+	// from an xml file at ConfigURL or from ConfigXML.
+	ConfigType string `json:"-" url:"config_type,omitempty"`
+	ConfigURL  string `json:"-" url:"config_url,omitempty"`
+	ConfigXML  string `json:"-" url:"config_xml,omitempty"`
+	// CustomFields are sent to the tool with every launch.
+	CustomFields  map[string]string `json:"custom_fields" url:"-"`
+	WorkflowState string            `json:"workflow_state" url:"-"`
+	DeploymentID  string            `json:"deployment_id" url:"-"`
+	Version       string            `json:"version" url:"-"`
+	CreatedAt     time.Time         `json:"created_at" url:"-"`
+	UpdatedAt     time.Time         `json:"updated_at" url:"-"`
+}
+
+// SessionlessLaunch is a request for a url that launches an
+// external tool without the user being logged in to canvas.
+// Either the ToolID or the URL of the tool must be set.
+type SessionlessLaunch struct {
+	ToolID int    `url:"id,omitempty"`
+	URL    string `url:"url,omitempty"`
+	// LaunchType is "assessment" to launch the tool for an assignment
+	// or "module_item" to launch it for a module item.
+	LaunchType   string `url:"launch_type,omitempty"`
+	AssignmentID int    `url:"assignment_id,omitempty"`
+	ModuleItemID int    `url:"module_item_id,omitempty"`
+}
+
+// ExternalTools will get the external tools installed in the course. Use
+// Opt("include_parents", true) to include the tools from the course's
+// accounts.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.index
+func (c *Course) ExternalTools(opts ...Option) ([]*ExternalTool, error) {
+	return listAll[*ExternalTool](c.client, c.id("/courses/%d/external_tools"), opts)
+}
+
+// ExternalTool will get one of the course's external tools.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.show
+func (c *Course) ExternalTool(id int) (*ExternalTool, error) {
+	return getExternalTool(c.client, c.id("/courses/%d/external_tools"), id)
+}
+
+// CreateExternalTool will install an external tool in the course.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.create
+func (c *Course) CreateExternalTool(tool ExternalTool, opts ...Option) (*ExternalTool, error) {
+	return createExternalTool(c.client, c.id("/courses/%d/external_tools"), &tool, opts)
+}
+
+// EditExternalTool will save any changes made to the tool. Only the
+// fields that are set are changed.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.update
+func (c *Course) EditExternalTool(tool *ExternalTool, opts ...Option) error {
+	return editExternalTool(c.client, c.id("/courses/%d/external_tools"), tool, opts)
+}
+
+// DeleteExternalTool will remove an external tool from the course.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.destroy
+func (c *Course) DeleteExternalTool(id int) error {
+	return deleteExternalTool(c.client, c.id("/courses/%d/external_tools"), id)
+}
+
+// SessionlessLaunchURL will get a url that launches an external tool in
+// the course. The url can only be used once and expires after a few
+// minutes.
+//
+//	u, err := course.SessionlessLaunchURL(canvas.SessionlessLaunch{ToolID: 12})
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.generate_sessionless_launch
+func (c *Course) SessionlessLaunchURL(launch SessionlessLaunch) (string, error) {
+	return sessionlessLaunchURL(c.client, c.id("/courses/%d/external_tools"), &launch)
+}
+
+// ExternalTools will get the external tools installed in the account.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.index
+func (a *Account) ExternalTools(opts ...Option) ([]*ExternalTool, error) {
+	return listAll[*ExternalTool](a.cli, a.path("/external_tools"), opts)
+}
+
+// ExternalTool will get one of the account's external tools.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.show
+func (a *Account) ExternalTool(id int) (*ExternalTool, error) {
+	return getExternalTool(a.cli, a.path("/external_tools"), id)
+}
+
+// CreateExternalTool will install an external tool in the account,
+// making it available to every course in the account.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.create
+func (a *Account) CreateExternalTool(tool ExternalTool, opts ...Option) (*ExternalTool, error) {
+	return createExternalTool(a.cli, a.path("/external_tools"), &tool, opts)
+}
+
+// EditExternalTool will save any changes made to the tool. Only the
+// fields that are set are changed.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.update
+func (a *Account) EditExternalTool(tool *ExternalTool, opts ...Option) error {
+	return editExternalTool(a.cli, a.path("/external_tools"), tool, opts)
+}
+
+// DeleteExternalTool will remove an external tool from the account.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.destroy
+func (a *Account) DeleteExternalTool(id int) error {
+	return deleteExternalTool(a.cli, a.path("/external_tools"), id)
+}
+
+// SessionlessLaunchURL will get a url that launches an external tool in
+// the account. The url can only be used once and expires after a few
+// minutes.
+//
+// https://canvas.instructure.com/doc/api/external_tools.html#method.external_tools.generate_sessionless_launch
+func (a *Account) SessionlessLaunchURL(launch SessionlessLaunch) (string, error) {
+	return sessionlessLaunchURL(a.cli, a.path("/external_tools"), &launch)
+}
+
+func getExternalTool(d doer, path string, id int) (*ExternalTool, error) {
+	tool := &ExternalTool{}
+	return tool, getjson(d, tool, nil, "%s/%d", path, id)
+}
+
+func createExternalTool(d doer, path string, tool *ExternalTool, opts []Option) (*ExternalTool, error) {
+	q, err := externalToolValues(tool, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := post(d, path, q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	created := &ExternalTool{}
+	return created, decodeJSON(resp.Body, created)
+}
+
+func editExternalTool(d doer, path string, tool *ExternalTool, opts []Option) error {
+	q, err := externalToolValues(tool, opts)
+	if err != nil {
+		return err
+	}
+	resp, err := put(d, fmt.Sprintf("%s/%d", path, tool.ID), q)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, tool)
+}
+
+func deleteExternalTool(d doer, path string, id int) error {
+	resp, err := delete(d, fmt.Sprintf("%s/%d", path, id), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func externalToolValues(tool *ExternalTool, opts []Option) (url.Values, error) {
+	q, err := query.Values(tool)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range tool.CustomFields {
+		q.Set(fmt.Sprintf("custom_fields[%s]", k), v)
+	}
+	params(q).Add(opts)
+	return q, nil
+}
+
+func sessionlessLaunchURL(d doer, path string, launch *SessionlessLaunch) (string, error) {
+	if launch.ToolID == 0 && launch.URL == "" {
+		return "", fmt.Errorf("sessionless launch needs a tool id or url")
+	}
+	q, err := query.Values(launch)
+	if err != nil {
+		return "", err
+	}
+	var res struct {
+		URL string `json:"url"`
+	}
+	if err = getjson(d, &res, q, "%s/sessionless_launch", path); err != nil {
+		return "", err
+	}
+	return res.URL, nil
+}
@@ -0,0 +1,96 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestExternalTools(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	for _, base := range []string{"/api/v1/courses/1/external_tools", "/api/v1/accounts/2/external_tools"} {
+		mux.HandleFunc(base, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "GET":
+				w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+				fmt.Fprint(w, `[{"id":3,"name":"Quizlet","privacy_level":"anonymous","custom_fields":{"a":"b"}}]`)
+			case "POST":
+				q := r.URL.Query()
+				if q.Get("name") != "Lab" || q.Get("shared_secret") != "s" || q.Get("custom_fields[course]") != "$Canvas.course.id" {
+					t.Errorf("wrong query parameters: %v", q)
+				}
+				fmt.Fprint(w, `{"id":4,"name":"Lab","privacy_level":"public"}`)
+			}
+		})
+	}
+	mux.HandleFunc("/api/v1/courses/1/external_tools/4", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":4,"name":"Lab"}`)
+		case "PUT":
+			q := r.URL.Query()
+			if q.Get("description") != "labs" || q.Get("name") != "Lab" {
+				t.Errorf("wrong query parameters: %v", q)
+			}
+			fmt.Fprint(w, `{"id":4,"name":"Lab","description":"labs"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"id":4}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/external_tools/sessionless_launch", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("id") != "4" || q.Get("launch_type") != "assessment" || q.Get("assignment_id") != "9" {
+			t.Errorf("wrong query parameters: %v", q)
+		}
+		fmt.Fprint(w, `{"id":4,"name":"Lab","url":"https://canvas/launch?verifier=x"}`)
+	})
+	mux.HandleFunc("/api/v1/accounts/2/external_tools/sessionless_launch", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("url") != "https://tool.example.com/launch" {
+			t.Error("url not sent")
+		}
+		fmt.Fprint(w, `{"url":"https://canvas/launch?verifier=y"}`)
+	})
+
+	c := &Course{ID: 1, client: client}
+	tools, err := c.ExternalTools()
+	is.NoErr(err)
+	is.Equal(len(tools), 1)
+	is.Equal(tools[0].PrivacyLevel, PrivacyAnonymous)
+	is.Equal(tools[0].CustomFields["a"], "b")
+	tool, err := c.CreateExternalTool(ExternalTool{
+		Name:         "Lab",
+		SharedSecret: "s",
+		CustomFields: map[string]string{"course": "$Canvas.course.id"},
+	})
+	is.NoErr(err)
+	is.Equal(tool.ID, 4)
+	tool, err = c.ExternalTool(4)
+	is.NoErr(err)
+	tool.Description = "labs"
+	is.NoErr(c.EditExternalTool(tool))
+	is.Equal(tool.Description, "labs")
+	u, err := c.SessionlessLaunchURL(SessionlessLaunch{ToolID: 4, LaunchType: "assessment", AssignmentID: 9})
+	is.NoErr(err)
+	is.Equal(u, "https://canvas/launch?verifier=x")
+	is.NoErr(c.DeleteExternalTool(4))
+	_, err = c.SessionlessLaunchURL(SessionlessLaunch{})
+	is.True(err != nil)
+
+	a := &Account{ID: 2, cli: client}
+	tools, err = a.ExternalTools()
+	is.NoErr(err)
+	is.Equal(tools[0].ID, 3)
+	_, err = a.CreateExternalTool(ExternalTool{
+		Name:         "Lab",
+		SharedSecret: "s",
+		CustomFields: map[string]string{"course": "$Canvas.course.id"},
+	})
+	is.NoErr(err)
+	u, err = a.SessionlessLaunchURL(SessionlessLaunch{URL: "https://tool.example.com/launch"})
+	is.NoErr(err)
+	is.Equal(u, "https://canvas/launch?verifier=y")
+}
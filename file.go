@@ -2,7 +2,7 @@ package canvas
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -195,17 +196,13 @@ func (f *File) edit(opts ...Option) error {
 		return err
 	}
 	defer resp.Body.Close()
-	return json.NewDecoder(resp.Body).Decode(f)
+	return decodeJSON(resp.Body, f)
 }
 
-// WriteTo will write the contents of the file to an io.Writer
+// WriteTo will write the contents of the file to an io.Writer. Downloads
+// that are interrupted are resumed where they left off.
 func (f *File) WriteTo(w io.Writer) (int64, error) {
-	resp, err := http.Get(f.URL)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-	return io.Copy(w, resp.Body)
+	return f.Download(w)
 }
 
 func (f *File) strID() string {
@@ -255,7 +252,7 @@ func (fw *fileWriter) Write(b []byte) (int, error) {
 }
 
 func (fw *fileWriter) Close() error {
-	file, err := uploadFile(fw.d, fw.buf, fw.path, fw.params)
+	file, err := uploadFile(context.Background(), fw.d, fw.buf, fw.path, fw.params)
 	if err != nil {
 		return err
 	}
@@ -400,7 +397,7 @@ func (f *Folder) Folders(opts ...Option) <-chan *Folder {
 		fmt.Sprintf("folders/%d/folders", f.ID),
 		sendFoldersFunc(f.client, ch, f), opts,
 	)
-	go handleErrs(pages.start(), ch, ConcurrentErrorHandler)
+	go handleErrs(pages, ch, ConcurrentErrorHandler)
 	return ch
 }
 
@@ -477,6 +474,17 @@ func (f *Folder) UploadFile(
 	filename string,
 	r io.Reader,
 	opts ...Option,
+) (*File, error) {
+	return f.UploadFileContext(context.Background(), filename, r, opts...)
+}
+
+// UploadFileContext uploads a file into the folder and will
+// abort the upload if the context is cancelled.
+func (f *Folder) UploadFileContext(
+	ctx context.Context,
+	filename string,
+	r io.Reader,
+	opts ...Option,
 ) (*File, error) {
 	path := fmt.Sprintf("/folders/%d/files", f.ID)
 	params := fileUploadParams{
@@ -484,7 +492,7 @@ func (f *Folder) UploadFile(
 		ParentFolderID: f.ID,
 	}
 	params.setOptions(opts)
-	return uploadFile(f.client, r, path, &params)
+	return uploadFile(ctx, f.client, r, path, &params)
 }
 
 // https://canvas.instructure.com/doc/api/files.html#method.folders.update
@@ -494,7 +502,7 @@ func (f *Folder) edit(opts ...Option) error {
 		return err
 	}
 	defer resp.Body.Close()
-	return json.NewDecoder(resp.Body).Decode(f)
+	return decodeJSON(resp.Body, f)
 }
 
 func filesChannel(
@@ -506,7 +514,7 @@ func filesChannel(
 ) <-chan *File {
 	ch := make(fileChan)
 	pager := newPaginatedList(d, path, sendFilesFunc(d, ch, parent), opts)
-	go handleErrs(pager.start(), ch, handler)
+	go handleErrs(pager, ch, handler)
 	return ch
 }
 
@@ -521,7 +529,7 @@ func foldersChannel(
 	pages := newPaginatedList(
 		d, path, sendFoldersFunc(d, ch, parent), opts,
 	)
-	go handleErrs(pages.start(), ch, ConcurrentErrorHandler)
+	go handleErrs(pages, ch, ConcurrentErrorHandler)
 	return ch
 }
 
@@ -545,7 +553,7 @@ func createFolder(
 	}
 	defer resp.Body.Close()
 	f := &Folder{client: d}
-	return f, json.NewDecoder(resp.Body).Decode(f)
+	return f, decodeJSON(resp.Body, f)
 }
 
 type fileUploadParams struct {
@@ -568,8 +576,29 @@ type fileUploadParams struct {
 	// These will be set as if it were an "include[]" parameter
 	// when the upload returns a canvas file.
 	SuccessInclude []string `url:"success_include,omitempty"`
+
+	progress func(sent, total int64)
+}
+
+// UploadProgress is an Option for file uploads that calls fn as the
+// file contents are sent to canvas. The total is the size of the
+// whole request body which includes a small amount of form data
+// along with the file.
+func UploadProgress(fn func(sent, total int64)) Option {
+	return &uploadOption{apply: func(up *fileUploadParams) {
+		up.progress = fn
+	}}
 }
 
+// uploadOption is an Option that configures a file upload
+// and is never sent to canvas.
+type uploadOption struct {
+	apply func(*fileUploadParams)
+}
+
+func (uo *uploadOption) Name() string    { return "" }
+func (uo *uploadOption) Value() []string { return nil }
+
 func (up *fileUploadParams) asOptions() []Option {
 	q, err := query.Values(up)
 	if err != nil {
@@ -585,6 +614,10 @@ func (up *fileUploadParams) asOptions() []Option {
 func (up *fileUploadParams) setOptions(opts []Option) {
 	var vals []string
 	for _, opt := range opts {
+		if uo, ok := opt.(*uploadOption); ok {
+			uo.apply(up)
+			continue
+		}
 		vals = opt.Value()
 		if len(vals) < 1 {
 			continue
@@ -640,6 +673,7 @@ func newFileUploadParams(filename string, opts []Option) *fileUploadParams {
 
 // https://canvas.instructure.com/doc/api/file.file_uploads.html
 func uploadFile(
+	ctx context.Context,
 	d doer,
 	r io.Reader,
 	endpoint string,
@@ -648,17 +682,19 @@ func uploadFile(
 	if params.Name == "" {
 		return nil, errors.New("empty filename")
 	}
-	req := newreq("POST", endpoint, params)
-	resp, err := do(d, req)
+	// Asking for an upload url does not create anything
+	// so it is always safe to retry.
+	req := withForceRetry(newreq("POST", endpoint, params).WithContext(ctx))
+	resp, err := uploadStep(d, UploadPreflight, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 	uploader, err := decodeUploader(resp.Body) // will close the body
 	if err != nil {
-		return nil, err
+		return nil, &UploadError{Step: UploadPreflight, StatusCode: resp.StatusCode, Err: err}
 	}
-	return uploader.upload(d, params.Name, r)
+	return uploader.upload(ctx, d, params, r, uploadLookupPath(endpoint))
 }
 
 func decodeUploader(r io.Reader) (*fileupload, error) {
@@ -667,7 +703,7 @@ func decodeUploader(r io.Reader) (*fileupload, error) {
 		body:   b,
 		writer: multipart.NewWriter(b),
 	}
-	err := json.NewDecoder(r).Decode(fup)
+	err := decodeJSON(r, fup)
 	if err != nil {
 		return nil, err
 	}
@@ -696,30 +732,281 @@ type fileupload struct {
 	writer *multipart.Writer
 }
 
-func (f *fileupload) upload(d doer, filename string, r io.Reader) (*File, error) {
-	form, err := f.writer.CreateFormFile(f.FileParam, filename)
+func (f *fileupload) upload(
+	ctx context.Context,
+	d doer,
+	params *fileUploadParams,
+	r io.Reader,
+	lookup string,
+) (*File, error) {
+	form, err := f.writer.CreateFormFile(f.FileParam, params.Name)
 	if err != nil {
 		return nil, err
 	}
-	if _, err = io.Copy(form, r); err != nil {
+	size, err := io.Copy(form, &contextReader{ctx: ctx, r: r})
+	if err != nil {
 		return nil, err
 	}
 	f.writer.Close() // do not defer, adds the correct line endings to the body
+
+	var (
+		file  *File
+		start = time.Now()
+	)
+	for attempt := 0; ; attempt++ {
+		file, err = f.send(ctx, d, params)
+		if err == nil || attempt >= retries(d) || ctx.Err() != nil || !retryableUpload(err) {
+			break
+		}
+		if werr := waitRetry(ctx, d, attempt); werr != nil {
+			err = werr
+			break
+		}
+		// The upload may have made it to canvas before failing
+		// so we look for the file before sending it again.
+		if lookup == "" {
+			continue
+		}
+		if found, ferr := findUploaded(d, lookup, params.Name, size, start); ferr == nil && found != nil {
+			file, err = found, nil
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		// The upload finished after it was cancelled so we
+		// delete the new file rather than leave it behind.
+		return nil, errs.Pair(err, file.Delete())
+	}
+	return file, nil
+}
+
+func (f *fileupload) send(ctx context.Context, d doer, params *fileUploadParams) (*File, error) {
+	var body io.Reader = bytes.NewReader(f.body.Bytes())
+	if params.progress != nil {
+		body = &progressReader{
+			r:     body,
+			total: int64(f.body.Len()),
+			fn:    params.progress,
+		}
+	}
 	req := &http.Request{
 		Method: "POST",
 		URL:    f.url,
-		Body:   ioutil.NopCloser(f.body),
+		Body:   ioutil.NopCloser(body),
 		Header: http.Header{
 			"Content-Type": {f.writer.FormDataContentType()}},
 		ContentLength: int64(f.body.Len()),
 	}
-	resp, err := do(d, req)
+	resp, err := uploadStep(d, UploadStorage, req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	step := responseStep(UploadStorage, resp)
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &UploadError{Step: step, StatusCode: resp.StatusCode, Err: err}
+	}
 	file := &File{client: d}
-	return file, json.NewDecoder(resp.Body).Decode(file)
+	err = decodeJSON(bytes.NewReader(b), file)
+	if err == nil && file.ID != 0 {
+		return file, nil
+	}
+	loc, lerr := resp.Location()
+	if resp.StatusCode != http.StatusCreated || lerr != nil {
+		return nil, &UploadError{
+			Step:       step,
+			StatusCode: resp.StatusCode,
+			Body:       truncateBody(b),
+			Err:        errs.Pair(err, errors.New("no file returned")),
+		}
+	}
+	// some storage services only give a location to get the file from
+	confirm, err := uploadStep(d, UploadConfirm, (&http.Request{
+		Method: "GET",
+		URL:    loc,
+		Header: http.Header{},
+	}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer confirm.Body.Close()
+	file = &File{client: d}
+	if err = decodeJSON(confirm.Body, file); err != nil {
+		return nil, &UploadError{Step: UploadConfirm, StatusCode: confirm.StatusCode, Err: err}
+	}
+	return file, nil
+}
+
+// UploadStep is one of the steps of a file upload.
+//
+// https://canvas.instructure.com/doc/api/file.file_uploads.html
+type UploadStep string
+
+// The steps of a file upload.
+const (
+	// UploadPreflight is the request to canvas for an upload url.
+	UploadPreflight UploadStep = "preflight"
+	// UploadStorage is the request that sends the file
+	// to the upload url.
+	UploadStorage UploadStep = "storage"
+	// UploadConfirm is the request that finishes the upload
+	// and gets the new file from canvas.
+	UploadConfirm UploadStep = "confirm"
+)
+
+// maxUploadErrorBody is the most of a response
+// body that is kept in an UploadError.
+const maxUploadErrorBody = 4096
+
+// UploadError is returned when a file upload fails. It says which step
+// of the upload failed along with the response that was sent back,
+// which is often the only clue to why the file storage service turned
+// the file down.
+type UploadError struct {
+	Step UploadStep
+	// StatusCode is the response status or zero
+	// if no response was received.
+	StatusCode int
+	// Body is the start of the response body.
+	Body string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *UploadError) Error() string {
+	msg := fmt.Sprintf("upload %s failed", e.Step)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" with status %d", e.StatusCode)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	if e.Body != "" {
+		msg += fmt.Sprintf(" (response: %q)", e.Body)
+	}
+	return msg
+}
+
+func (e *UploadError) Unwrap() error {
+	return e.Err
+}
+
+// uploadStep sends one request of a file upload. Error
+// responses are returned as UploadErrors.
+func uploadStep(d doer, step UploadStep, req *http.Request) (*http.Response, error) {
+	resp, err := d.Do(req)
+	if err != nil {
+		return nil, &UploadError{Step: step, Err: err}
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+	step = responseStep(step, resp)
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxUploadErrorBody))
+	resp.Body.Close()
+	if err != nil {
+		return nil, &UploadError{Step: step, StatusCode: resp.StatusCode, Err: err}
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	_, err = checkResponse(resp)
+	return nil, &UploadError{Step: step, StatusCode: resp.StatusCode, Body: truncateBody(b), Err: err}
+}
+
+// responseStep returns the step that resp came from. Storage
+// services may redirect to canvas to confirm the upload.
+func responseStep(step UploadStep, resp *http.Response) UploadStep {
+	if step == UploadStorage && resp.Request != nil && resp.Request.Response != nil {
+		return UploadConfirm
+	}
+	return step
+}
+
+func truncateBody(b []byte) string {
+	if len(b) > maxUploadErrorBody {
+		b = b[:maxUploadErrorBody]
+	}
+	return string(bytes.TrimSpace(b))
+}
+
+// retryableUpload returns false for errors where
+// sending the file again will not help.
+func retryableUpload(err error) bool {
+	if IsRateLimit(err) {
+		return false
+	}
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// uploadLookupPath returns the path used to list the
+// files uploaded to endpoint.
+func uploadLookupPath(endpoint string) string {
+	if strings.HasSuffix(endpoint, "/submissions/self/files") {
+		// submission files are put in the user's files
+		return "/users/self/files"
+	}
+	return endpoint
+}
+
+// findUploaded looks for a file that was uploaded after since. It
+// returns nil if the file could not be found.
+func findUploaded(d doer, path, name string, size int64, since time.Time) (*File, error) {
+	files := make([]*File, 0)
+	err := getjson(d, &files, optEnc{
+		Opt("search_term", name),
+		Opt("sort", "created_at"),
+		Opt("order", "desc"),
+	}, path)
+	if err != nil {
+		return nil, err
+	}
+	// leave some room for clock differences
+	since = since.Add(-time.Minute)
+	for _, file := range files {
+		if (file.DisplayName == name || file.Filename == name) &&
+			int64(file.Size) == size && file.CreatedAt.After(since) {
+			file.client = d
+			return file, nil
+		}
+	}
+	return nil, nil
+}
+
+// contextReader stops reading once the context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(b []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(b)
+}
+
+// progressReader reports the number of bytes read so far.
+type progressReader struct {
+	r     io.Reader
+	sent  int64
+	total int64
+	fn    func(sent, total int64)
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	if n > 0 {
+		pr.sent += int64(n)
+		pr.fn(pr.sent, pr.total)
+	}
+	return n, err
 }
 
 func listFiles(d doer, path string, parent *Folder, opts []Option) ([]*File, error) {
@@ -727,10 +1014,9 @@ func listFiles(d doer, path string, parent *Folder, opts []Option) ([]*File, err
 		opts = []Option{}
 	}
 	var (
-		page     = 1
-		perpage  = 10
-		files    []*File
-		tmpfiles []*File = make([]*File, 10)
+		page    = 1
+		perpage = 10
+		files   []*File
 	)
 	p := params{
 		"page":     {strconv.Itoa(page)},
@@ -748,7 +1034,10 @@ func listFiles(d doer, path string, parent *Folder, opts []Option) ([]*File, err
 	}
 	files = make([]*File, 0, n*perpage)
 
-	if err := json.NewDecoder(resp.Body).Decode(&tmpfiles); err != nil {
+	// every page is decoded into a new slice, decoding into the last
+	// page's slice would overwrite the files it points to
+	var tmpfiles []*File
+	if err := decodeJSON(resp.Body, &tmpfiles); err != nil {
 		return nil, err
 	}
 	files = append(files, tmpfiles...)
@@ -763,7 +1052,8 @@ func listFiles(d doer, path string, parent *Folder, opts []Option) ([]*File, err
 		if err != nil {
 			return files, err
 		}
-		if err = json.NewDecoder(resp.Body).Decode(&tmpfiles); err != nil {
+		tmpfiles = nil
+		if err = decodeJSON(resp.Body, &tmpfiles); err != nil {
 			resp.Body.Close()
 			return files, err
 		}
@@ -781,13 +1071,16 @@ func listFolders(d doer, path string, parent *Folder, opts []Option) ([]*Folder,
 	page := newPaginatedList(d, path, sendFoldersFunc(d, ch, nil), opts)
 	folders := make([]*Folder, 0)
 	errs := page.start()
+	var errl []error
 	for {
 		select {
 		case folder := <-ch:
 			folders = append(folders, folder)
-		case err := <-errs:
-			close(ch)
-			return folders, err
+		case err, ok := <-errs:
+			if !ok {
+				return folders, joinErrs(errl)
+			}
+			errl = append(errl, err)
 		}
 	}
 }
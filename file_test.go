@@ -2,12 +2,16 @@ package canvas
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -423,6 +427,126 @@ func TestFolder_Copy(t *testing.T) {
 func TestAssignmentUpload(t *testing.T) {
 }
 
+func TestUploadContext(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/submissions/self/files", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		if r.URL.Query().Get("name") != "essay.txt" {
+			t.Error("wrong file name")
+		}
+		fmt.Fprint(w, `{"upload_url":"https://uploads.example.com/upload","file_param":"file","upload_params":{"key":"value"}}`)
+	})
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b, _ := ioutil.ReadAll(f)
+		fmt.Fprintf(w, `{"id":9,"size":%d,"display_name":"essay.txt"}`, len(b))
+	})
+	mux.HandleFunc("/api/v1/files/9", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "DELETE")
+		w.Write([]byte("{}"))
+	})
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	content := strings.Repeat("a", 64*1024)
+
+	var sent, total int64
+	file, err := a.SubmitFileContext(
+		context.Background(), "essay.txt", strings.NewReader(content),
+		UploadProgress(func(s, t int64) { sent, total = s, t }),
+	)
+	is.NoErr(err)
+	is.Equal(file.ID, 9)
+	is.Equal(file.Size, len(content))
+	is.True(total > int64(len(content)))
+	is.Equal(sent, total)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = a.SubmitFileContext(ctx, "essay.txt", strings.NewReader(content))
+	is.True(errors.Is(err, context.Canceled))
+
+	// cancelled once the whole file has been sent, either the request is
+	// aborted or the new file is deleted
+	ctx, cancel = context.WithCancel(context.Background())
+	_, err = a.SubmitFileContext(
+		ctx, "essay.txt", strings.NewReader(content),
+		UploadProgress(func(s, t int64) {
+			if s == t {
+				cancel()
+			}
+		}),
+	)
+	is.True(errors.Is(err, context.Canceled))
+}
+
+func TestUploadError(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var storage http.HandlerFunc
+	mux.HandleFunc("/api/v1/courses/1/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") == "denied.txt" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"file size exceeds quota"}`)
+			return
+		}
+		fmt.Fprint(w, `{"upload_url":"https://uploads.example.com/upload","file_param":"file"}`)
+	})
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) { storage(w, r) })
+	mux.HandleFunc("/confirm", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":9,"display_name":"a.txt"}`)
+	})
+	course := &Course{ID: 1, client: client}
+
+	_, err := course.UploadFile("denied.txt", strings.NewReader("a"))
+	var ue *UploadError
+	is.True(errors.As(err, &ue))
+	is.Equal(ue.Step, UploadPreflight)
+	is.Equal(ue.StatusCode, http.StatusBadRequest)
+	is.True(strings.Contains(ue.Body, "file size exceeds quota"))
+	var e *Error
+	is.True(errors.As(err, &e))
+
+	storage = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<Error><Code>EntityTooLarge</Code></Error>`)
+	}
+	_, err = course.UploadFile("a.txt", strings.NewReader("a"))
+	is.True(errors.As(err, &ue))
+	is.Equal(ue.Step, UploadStorage)
+	is.Equal(ue.StatusCode, http.StatusBadRequest)
+	is.Equal(ue.Body, `<Error><Code>EntityTooLarge</Code></Error>`)
+	is.True(strings.Contains(err.Error(), "EntityTooLarge"))
+
+	storage = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://uploads.example.com/confirm")
+		w.WriteHeader(http.StatusCreated)
+	}
+	file, err := course.UploadFile("a.txt", strings.NewReader("a"))
+	is.NoErr(err)
+	is.Equal(file.ID, 9)
+
+	storage = func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/bad-confirm", http.StatusSeeOther)
+	}
+	mux.HandleFunc("/bad-confirm", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"upload expired"}`)
+	})
+	_, err = course.UploadFile("a.txt", strings.NewReader("a"))
+	is.True(errors.As(err, &ue))
+	is.Equal(ue.Step, UploadConfirm)
+	is.Equal(ue.StatusCode, http.StatusNotFound)
+}
+
 func foldersHandlerFunc(t *testing.T, n int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Link", `<https://canvas.instructure.com/api/v1/courses/000/users?search_term=test&page=1&per_page=10>; rel="current",<https://canvas.instructure.com/api/v1/courses/000/users?search_term=test&page=1&per_page=10>; rel="first",<https://canvas.instructure.com/api/v1/courses/000/users?search_term=test&page=1&per_page=10>; rel="last"`)
@@ -0,0 +1,282 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harrybrwn/go-canvas/store"
+)
+
+// SyncOption configures Course.SyncFiles.
+type SyncOption func(*syncConfig)
+
+type syncConfig struct {
+	workers  int
+	filter   func(*File) bool
+	progress func(SyncEvent)
+	store    store.Store
+}
+
+// SyncWorkers sets the number of files downloaded at once. The default is 4.
+func SyncWorkers(n int) SyncOption {
+	return func(sc *syncConfig) { sc.workers = n }
+}
+
+// SyncFilter will only sync the files that fn returns true for.
+func SyncFilter(fn func(*File) bool) SyncOption {
+	return func(sc *syncConfig) { sc.filter = fn }
+}
+
+// SyncProgress sets a function that is called after every file is
+// handled. It may be called from more than one goroutine at a time.
+func SyncProgress(fn func(SyncEvent)) SyncOption {
+	return func(sc *syncConfig) { sc.progress = fn }
+}
+
+// SyncManifest keeps a manifest of the synced files in the store's
+// manifests bucket, keyed by the sync directory. Files listed in the
+// manifest are not downloaded again until they change on canvas, even
+// if the modification times of the local copies have been changed.
+func SyncManifest(s store.Store) SyncOption {
+	return func(sc *syncConfig) { sc.store = s }
+}
+
+// syncManifest is the value saved in the manifests bucket.
+type syncManifest struct {
+	Files map[int]syncedFile `json:"files"`
+}
+
+type syncedFile struct {
+	Path      string    `json:"path"`
+	Size      int       `json:"size"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// has returns true if the file was synced to path
+// and has not changed on canvas or locally since.
+func (sm *syncManifest) has(f *File, path string) bool {
+	sf, ok := sm.Files[f.ID]
+	if !ok || sf.Path != path || sf.Size != f.Size || !sf.UpdatedAt.Equal(f.UpdatedAt) {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Size() == int64(f.Size)
+}
+
+func loadManifest(s store.Store, dir string) (*syncManifest, error) {
+	m := &syncManifest{}
+	err := store.GetJSON(s, store.Manifests, dir, m)
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+	if m.Files == nil {
+		m.Files = make(map[int]syncedFile)
+	}
+	return m, nil
+}
+
+// SyncEvent is sent to the SyncProgress
+// function for every file that is synced.
+type SyncEvent struct {
+	File *File
+	// Path is the local path of the file.
+	Path string
+	// Skipped is true when the local file was already up to date
+	// or the file is locked and cannot be downloaded.
+	Skipped bool
+	Err     error
+	// Done is the number of files that have been handled out of Total.
+	Done, Total int
+}
+
+// SyncFiles will download every file in the course into dir, keeping
+// the course's folder structure. Files are downloaded concurrently and
+// a file failing to download does not stop the others.
+//
+// Canvas does not provide file checksums, so a local file is considered
+// unchanged when its size matches and its modification time matches
+// the time the file was last updated on canvas. SyncFiles sets the
+// modification time of every file it downloads so that running it
+// again only downloads the files that changed. Use SyncManifest to
+// keep track of the synced files in a store instead.
+func (c *Course) SyncFiles(dir string, opts ...SyncOption) error {
+	conf := &syncConfig{workers: 4}
+	for _, o := range opts {
+		o(conf)
+	}
+	if conf.workers < 1 {
+		conf.workers = 1
+	}
+	var manifest *syncManifest
+	if conf.store != nil {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		if manifest, err = loadManifest(conf.store, abs); err != nil {
+			return err
+		}
+		dir = abs
+	}
+	folders, err := c.ListFolders()
+	if err != nil {
+		return err
+	}
+	paths := make(map[int]string, len(folders))
+	for _, f := range folders {
+		paths[f.ID] = localFolderPath(f.FullName)
+	}
+	files, err := c.ListFiles()
+	if err != nil {
+		return err
+	}
+	if conf.filter != nil {
+		filtered := files[:0]
+		for _, f := range files {
+			if conf.filter(f) {
+				filtered = append(filtered, f)
+			}
+		}
+		files = filtered
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errl []error
+		done int
+		sem  = make(chan struct{}, conf.workers)
+	)
+	for _, f := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(f *File) {
+			defer func() { <-sem; wg.Done() }()
+			local := filepath.Join(dir, paths[f.FolderID], localName(f.DisplayName))
+			var (
+				skipped bool
+				err     error
+			)
+			mu.Lock()
+			inManifest := manifest != nil && manifest.has(f, local)
+			mu.Unlock()
+			if inManifest {
+				skipped = true
+			} else {
+				skipped, err = syncFile(c.client, f, local)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if err != nil {
+				errl = append(errl, fmt.Errorf("%s: %w", local, err))
+			} else if manifest != nil && f.URL != "" {
+				manifest.Files[f.ID] = syncedFile{Path: local, Size: f.Size, UpdatedAt: f.UpdatedAt}
+			}
+			if conf.progress != nil {
+				conf.progress(SyncEvent{
+					File:    f,
+					Path:    local,
+					Skipped: skipped,
+					Err:     err,
+					Done:    done,
+					Total:   len(files),
+				})
+			}
+		}(f)
+	}
+	wg.Wait()
+	if manifest != nil {
+		if err := store.PutJSON(conf.store, store.Manifests, dir, manifest); err != nil {
+			errl = append(errl, err)
+		}
+	}
+	return joinErrs(errl)
+}
+
+// syncFile downloads the file to path if the local copy is out of date.
+func syncFile(d doer, f *File, path string) (skipped bool, err error) {
+	if f.URL == "" {
+		// locked files have no url
+		return true, nil
+	}
+	if upToDate(f, path) {
+		return true, nil
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	u, err := url.Parse(f.URL)
+	if err != nil {
+		return false, err
+	}
+	resp, err := do(d, &http.Request{
+		Method: "GET",
+		Proto:  "HTTP/1.1",
+		URL:    u,
+		Header: http.Header{},
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	// write to a temporary file so that a failed download
+	// never replaces a good local copy
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".sync-*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err = tmp.Close(); err != nil {
+		return false, err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return false, os.Chtimes(path, time.Now(), f.UpdatedAt)
+}
+
+func upToDate(f *File, path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.Size() == int64(f.Size) && info.ModTime().Equal(f.UpdatedAt)
+}
+
+// localFolderPath converts a canvas folder name like "course files/week 1"
+// into a relative local path without the root folder.
+func localFolderPath(fullname string) string {
+	parts := strings.Split(fullname, "/")
+	for i := range parts {
+		parts[i] = localName(parts[i])
+	}
+	if len(parts) > 0 {
+		// drop the root folder, e.g. "course files"
+		parts = parts[1:]
+	}
+	return filepath.Join(parts...)
+}
+
+// localName makes a file or folder name safe to use as one
+// element of a local path.
+func localName(name string) string {
+	name = strings.NewReplacer("/", "_", `\`, "_").Replace(name)
+	if name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
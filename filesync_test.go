@@ -0,0 +1,77 @@
+package canvas
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/harrybrwn/go-canvas/store"
+	"github.com/matryer/is"
+)
+
+func TestSyncFiles(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	dir := t.TempDir()
+	var downloads int32
+	updated := "2020-09-01T10:00:00Z"
+	mux.HandleFunc("/api/v1/courses/1/folders", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[{"id":1,"full_name":"course files"},{"id":2,"full_name":"course files/week 1"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/files", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprintf(w, `[
+			{"id":1,"folder_id":1,"display_name":"syllabus.txt","size":8,"updated_at":%[1]q,"url":"https://files.test/1"},
+			{"id":2,"folder_id":2,"display_name":"notes.txt","size":5,"updated_at":%[1]q,"url":"https://files.test/2"},
+			{"id":3,"folder_id":2,"display_name":"locked.txt","size":5,"updated_at":%[1]q,"url":""}
+		]`, updated)
+	})
+	mux.HandleFunc("/1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		fmt.Fprint(w, "syllabus")
+	})
+	mux.HandleFunc("/2", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		fmt.Fprint(w, "notes")
+	})
+	c := &Course{ID: 1, client: client}
+	var events []SyncEvent
+	err := c.SyncFiles(dir, SyncWorkers(2), SyncProgress(func(e SyncEvent) {
+		events = append(events, e)
+	}))
+	is.NoErr(err)
+	is.Equal(len(events), 3)
+	is.Equal(events[2].Done, 3)
+	is.Equal(atomic.LoadInt32(&downloads), int32(2))
+	b, err := ioutil.ReadFile(filepath.Join(dir, "week 1", "notes.txt"))
+	is.NoErr(err)
+	is.Equal(string(b), "notes")
+	_, err = os.Stat(filepath.Join(dir, "syllabus.txt"))
+	is.NoErr(err)
+
+	// nothing changed
+	is.NoErr(c.SyncFiles(dir))
+	is.Equal(atomic.LoadInt32(&downloads), int32(2))
+
+	updated = "2020-09-02T10:00:00Z"
+	is.NoErr(c.SyncFiles(dir, SyncFilter(func(f *File) bool { return f.ID == 2 })))
+	is.Equal(atomic.LoadInt32(&downloads), int32(3))
+
+	// the manifest is used instead of modification times
+	s := store.NewMemory()
+	is.NoErr(c.SyncFiles(dir, SyncManifest(s)))
+	is.Equal(atomic.LoadInt32(&downloads), int32(4)) // syllabus.txt is out of date
+	keys, err := s.Keys(store.Manifests)
+	is.NoErr(err)
+	is.Equal(len(keys), 1)
+	is.NoErr(os.Chtimes(filepath.Join(dir, "syllabus.txt"), time.Now(), time.Now()))
+	is.NoErr(c.SyncFiles(dir, SyncManifest(s)))
+	is.Equal(atomic.LoadInt32(&downloads), int32(4))
+}
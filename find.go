@@ -0,0 +1,210 @@
+package canvas
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrNotFound is returned when a search does not find any matches.
+var ErrNotFound = errors.New("canvas: no matching items found")
+
+// findPrefetch is the default number of pages fetched ahead
+// of time when searching.
+const findPrefetch = 2
+
+// FindFirst will search the paginated listing at path and return the first
+// item that matches. Pages are searched in order and no more pages are
+// requested once a match is found. If nothing matches, ErrNotFound is
+// returned.
+//
+//	final, err := canvas.FindFirst(c, "/courses/1/assignments", func(a *canvas.Assignment) bool {
+//		return a.Name == "Final Exam"
+//	})
+func FindFirst[T any](c *Canvas, path string, match func(T) bool, opts ...Option) (T, error) {
+	var zero T
+	found, err := find(c.client, path, 1, match, opts)
+	if len(found) == 0 {
+		if err == nil {
+			err = ErrNotFound
+		}
+		return zero, err
+	}
+	return found[0], err
+}
+
+// FindAll will search the paginated listing at path and return up to n
+// items that match, in the order canvas lists them. No more pages are
+// requested once n matches are found. If n is less than one then every
+// page is searched.
+func FindAll[T any](c *Canvas, path string, n int, match func(T) bool, opts ...Option) ([]T, error) {
+	return find(c.client, path, n, match, opts)
+}
+
+func find[T any](d doer, path string, n int, match func(T) bool, opts []Option) ([]T, error) {
+	var (
+		mu    sync.Mutex
+		found = make([]T, 0)
+		p     *paginated
+	)
+	full := func() bool { return n > 0 && len(found) >= n }
+	opts = append([]Option{InOrder, WithPrefetch(findPrefetch)}, opts...)
+	p = newPaginatedList(d, path, func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			var v T
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if full() || !match(v) {
+				return nil
+			}
+			if sc, ok := any(v).(interface{ setclient(doer) }); ok {
+				sc.setclient(d)
+			}
+			found = append(found, v)
+			if full() {
+				p.stop()
+			}
+			return nil
+		})
+	}, opts)
+	var errl []error
+	for err := range p.start() {
+		errl = append(errl, err)
+	}
+	return found, joinErrs(errl)
+}
+
+// FindCourse will find the courses whose name or course code best
+// match nameOrCode. Matching ignores case and punctuation and allows
+// for abbreviations so "calc 2" will find "Calculus 2 (MATH-202)". The
+// courses are sorted from best to worst match and ErrNotFound is
+// returned if nothing matches.
+func (c *Canvas) FindCourse(nameOrCode string, opts ...Option) ([]*Course, error) {
+	// the courses endpoint does not take a search_term
+	courses, err := c.Courses(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return bestMatches(courses, nameOrCode, func(c *Course) []string {
+		return []string{c.Name, c.CourseCode}
+	})
+}
+
+// FindAssignment will find the course assignments whose names best
+// match name. Canvas is asked to search for the name first and if that
+// finds nothing then all of the assignments are searched locally, which
+// catches abbreviations and typos that canvas misses. The assignments
+// are sorted from best to worst match and ErrNotFound is returned if
+// nothing matches.
+func (c *Course) FindAssignment(name string, opts ...Option) ([]*Assignment, error) {
+	searchOpts := append([]Option{Opt("search_term", name)}, opts...)
+	asses, err := c.ListAssignments(searchOpts...)
+	if err != nil {
+		return nil, err
+	}
+	key := func(a *Assignment) []string { return []string{a.Name} }
+	found, err := bestMatches(asses, name, key)
+	if err != ErrNotFound {
+		return found, err
+	}
+	if asses, err = c.ListAssignments(opts...); err != nil {
+		return nil, err
+	}
+	return bestMatches(asses, name, key)
+}
+
+// bestMatches sorts the items that match query from best to worst.
+// Items that score the same keep the order that canvas sent them in.
+func bestMatches[T any](items []T, query string, keys func(T) []string) ([]T, error) {
+	type scored struct {
+		item  T
+		score float64
+	}
+	matches := make([]scored, 0)
+	for _, item := range items {
+		var best float64
+		for _, k := range keys(item) {
+			if s := fuzzyScore(query, k); s > best {
+				best = s
+			}
+		}
+		if best > 0 {
+			matches = append(matches, scored{item, best})
+		}
+	}
+	if len(matches) == 0 {
+		return nil, ErrNotFound
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	res := make([]T, len(matches))
+	for i, m := range matches {
+		res[i] = m.item
+	}
+	return res, nil
+}
+
+// fuzzyScore rates how well name matches query between zero for no
+// match and one for an exact match.
+func fuzzyScore(query, name string) float64 {
+	qwords, nwords := fuzzyWords(query), fuzzyWords(name)
+	if len(qwords) == 0 || len(nwords) == 0 {
+		return 0
+	}
+	q, n := strings.Join(qwords, ""), strings.Join(nwords, "")
+	switch {
+	case q == n:
+		return 1
+	case strings.HasPrefix(n, q):
+		return 0.9
+	case strings.Contains(n, q):
+		return 0.8
+	}
+	// every word in the query starts a word in the name
+	var matched int
+	for _, qw := range qwords {
+		for _, nw := range nwords {
+			if strings.HasPrefix(nw, qw) {
+				matched++
+				break
+			}
+		}
+	}
+	if matched == len(qwords) {
+		return 0.7
+	}
+	if matched > 0 {
+		return 0.5 * float64(matched) / float64(len(qwords))
+	}
+	// the query's letters show up in order, like "hw" for "homework"
+	if isSubsequence(q, n) {
+		return 0.2 * float64(len(q)) / float64(len(n))
+	}
+	return 0
+}
+
+// fuzzyWords splits s into lower case words of letters and numbers.
+func fuzzyWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func isSubsequence(sub, s string) bool {
+	for _, r := range s {
+		if len(sub) == 0 {
+			break
+		}
+		if strings.HasPrefix(sub, string(r)) {
+			sub = sub[len(string(r)):]
+		}
+	}
+	return len(sub) == 0
+}
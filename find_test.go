@@ -0,0 +1,119 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFind(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	pages := 10
+	var requests int32
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var page int
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments?page=%d>; rel="last"`, DefaultHost, pages))
+		fmt.Fprintf(w, `[{"id":%d,"name":"hw %[1]d"},{"id":%d,"name":"quiz %[2]d"}]`, page*10, page*10+1)
+	})
+	c := &Canvas{client: client}
+
+	a, err := FindFirst(c, "/courses/1/assignments", func(a *Assignment) bool {
+		return a.Name == "quiz 21"
+	})
+	is.NoErr(err)
+	is.Equal(a.ID, 21)
+	is.True(a.client != nil)
+	is.True(atomic.LoadInt32(&requests) <= 2+findPrefetch)
+
+	atomic.StoreInt32(&requests, 0)
+	quizzes, err := FindAll(c, "/courses/1/assignments", 3, func(a *Assignment) bool {
+		return a.ID%10 == 1
+	})
+	is.NoErr(err)
+	is.Equal(len(quizzes), 3)
+	for i, q := range quizzes {
+		is.Equal(q.ID, (i+1)*10+1)
+	}
+	is.True(atomic.LoadInt32(&requests) < int32(pages))
+
+	all, err := FindAll(c, "/courses/1/assignments", 0, func(a *Assignment) bool { return true })
+	is.NoErr(err)
+	is.Equal(len(all), pages*2)
+
+	_, err = FindFirst(c, "/courses/1/assignments", func(a *Assignment) bool { return false })
+	is.Equal(err, ErrNotFound)
+}
+
+func TestFindByName(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[
+			{"id":1,"name":"Intro to Programming","course_code":"CS-101"},
+			{"id":2,"name":"Calculus 2","course_code":"MATH-202"},
+			{"id":3,"name":"Advanced Calculus","course_code":"MATH-401"}]`)
+	})
+	var searches []string
+	mux.HandleFunc("/api/v1/courses/2/assignments", func(w http.ResponseWriter, r *http.Request) {
+		term := r.URL.Query().Get("search_term")
+		searches = append(searches, term)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/2/assignments?page=1>; rel="last"`, DefaultHost))
+		switch term {
+		case "midterm":
+			fmt.Fprint(w, `[{"id":5,"name":"Midterm Review"},{"id":4,"name":"Midterm"}]`)
+		case "":
+			fmt.Fprint(w, `[{"id":6,"name":"Homework 1"},{"id":7,"name":"Quiz 1"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	})
+	c := &Canvas{client: client}
+
+	courses, err := c.FindCourse("calculus")
+	is.NoErr(err)
+	is.Equal(len(courses), 2)
+	is.Equal(courses[0].ID, 2) // prefix beats substring
+	is.Equal(courses[1].ID, 3)
+	courses, err = c.FindCourse("cs101")
+	is.NoErr(err)
+	is.Equal(courses[0].ID, 1)
+	courses, err = c.FindCourse("intro prog")
+	is.NoErr(err)
+	is.Equal(len(courses), 1)
+	_, err = c.FindCourse("biology")
+	is.Equal(err, ErrNotFound)
+
+	course := courses[0]
+	course.ID = 2
+	asses, err := course.FindAssignment("midterm")
+	is.NoErr(err)
+	is.Equal(asses[0].ID, 4)
+	is.Equal(asses[1].ID, 5)
+	is.Equal(searches, []string{"midterm"})
+
+	// canvas finds nothing so everything is searched
+	asses, err = course.FindAssignment("hw 1")
+	is.NoErr(err)
+	is.Equal(asses[0].ID, 6)
+	is.Equal(searches[1:], []string{"hw 1", ""})
+	_, err = course.FindAssignment("final")
+	is.Equal(err, ErrNotFound)
+}
+
+func TestFuzzyScore(t *testing.T) {
+	is := is.New(t)
+	is.Equal(fuzzyScore("Quiz 1", "quiz-1"), 1.0)
+	is.True(fuzzyScore("hw", "Homework") > 0)
+	is.Equal(fuzzyScore("", "Homework"), 0.0)
+	is.Equal(fuzzyScore("exam", "Homework"), 0.0)
+	is.True(fuzzyScore("calc", "Calculus") > fuzzyScore("calc 2", "Calculus 3"))
+}
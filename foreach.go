@@ -0,0 +1,128 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ForEachCourse will call fn for every one of the current user's courses
+// that the filter matches. A nil filter matches every course and opts are
+// used to list the courses, e.g. Opt("enrollment_state", "active").
+//
+// Courses are handled concurrently by four workers, or by the number given
+// to WithConcurrency. Before each call the workers wait for the client's
+// rate limit quota the same way paginated listings do, so callbacks that
+// make many requests share one throttle. A course failing does not stop
+// the others and the returned error joins the errors of every course
+// that failed. Cancelling the context stops courses from being started
+// and is given to the callbacks that are running.
+//
+//	err := c.ForEachCourse(ctx, nil, func(ctx context.Context, course *canvas.Course) error {
+//		_, err := course.UpdateSettingsPartial(canvas.CourseSettingsPatch{
+//			AllowStudentOrganizedGroups: canvas.Bool(false),
+//		})
+//		return err
+//	}, canvas.Opt("enrollment_type", "teacher"))
+func (c *Canvas) ForEachCourse(
+	ctx context.Context,
+	filter func(*Course) bool,
+	fn func(context.Context, *Course) error,
+	opts ...Option,
+) error {
+	courses, err := c.Courses(opts...)
+	if err != nil {
+		return err
+	}
+	return forEachCourse(ctx, c.client, courses, filter, fn)
+}
+
+// ForEachCourse will call fn for every one of the current user's courses
+// that the filter matches.
+func ForEachCourse(
+	ctx context.Context,
+	filter func(*Course) bool,
+	fn func(context.Context, *Course) error,
+	opts ...Option,
+) error {
+	return ca.ForEachCourse(ctx, filter, fn, opts...)
+}
+
+// ForEachCourse will call fn for every course in the account that the
+// filter matches. It works like Canvas.ForEachCourse.
+func (a *Account) ForEachCourse(
+	ctx context.Context,
+	filter func(*Course) bool,
+	fn func(context.Context, *Course) error,
+	opts ...Option,
+) error {
+	courses, err := a.Courses(opts...)
+	if err != nil {
+		return err
+	}
+	return forEachCourse(ctx, a.cli, courses, filter, fn)
+}
+
+func forEachCourse(
+	ctx context.Context,
+	d doer,
+	courses []*Course,
+	filter func(*Course) bool,
+	fn func(context.Context, *Course) error,
+) error {
+	cli, _ := unwrapDoer(d).(*client)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errl []error
+		sem  = make(chan struct{}, defaultWorkers(d))
+	)
+	addErr := func(err error) {
+		mu.Lock()
+		errl = append(errl, err)
+		mu.Unlock()
+	}
+loop:
+	for _, course := range courses {
+		if filter != nil && !filter(course) {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		wg.Add(1)
+		go func(course *Course) {
+			defer func() { <-sem; wg.Done() }()
+			if cli != nil {
+				if err := cli.waitForQuota(ctx, course.id("/courses/%d"), 0); err != nil {
+					return
+				}
+			}
+			if err := fn(ctx, course); err != nil {
+				addErr(fmt.Errorf("course %d: %w", course.ID, err))
+			}
+		}(course)
+	}
+	wg.Wait()
+	sort.Slice(errl, func(i, j int) bool { return errl[i].Error() < errl[j].Error() })
+	if err := ctx.Err(); err != nil {
+		errl = append(errl, err)
+	}
+	return joinErrs(errl)
+}
+
+// defaultWorkers is the number of workers used by helpers that do
+// something for many objects at once. It is the limit set with
+// WithConcurrency or bulkWorkers if there is no limit.
+func defaultWorkers(d doer) int {
+	if c, ok := unwrapDoer(d).(*client); ok && c.concurrency > 0 {
+		return c.concurrency
+	}
+	return bulkWorkers
+}
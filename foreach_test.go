@@ -0,0 +1,72 @@
+package canvas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestForEachCourse(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("enrollment_type") != "teacher" {
+			t.Error("listing options not sent")
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":1},{"id":2},{"id":3},{"id":4},{"id":5},{"id":6}]`)
+	})
+	c := &Canvas{client: &client{Client: *httpClient, concurrency: 2}}
+
+	var (
+		inflight, most int32
+		mu             sync.Mutex
+		seen           []int
+	)
+	err := c.ForEachCourse(context.Background(), func(c *Course) bool {
+		return c.ID != 6
+	}, func(ctx context.Context, c *Course) error {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		seen = append(seen, c.ID)
+		mu.Unlock()
+		if c.ID%2 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	}, Opt("enrollment_type", "teacher"))
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "course 2: failed"))
+	is.True(strings.Contains(err.Error(), "course 4: failed"))
+	sort.Ints(seen)
+	is.Equal(seen, []int{1, 2, 3, 4, 5})
+	is.Equal(atomic.LoadInt32(&most), int32(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls int32
+	err = c.ForEachCourse(ctx, nil, func(context.Context, *Course) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}, Opt("enrollment_type", "teacher"))
+	is.True(errors.Is(err, context.Canceled))
+	is.Equal(atomic.LoadInt32(&calls), int32(0))
+}
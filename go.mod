@@ -1,9 +1,12 @@
 module github.com/harrybrwn/go-canvas
 
-go 1.14
+go 1.18
 
 require (
 	github.com/harrybrwn/errs v0.0.2-0.20200523142445-e4279967174e
 	github.com/harrybrwn/go-querystring v1.0.1-0.20200812230556-de172bc021ad
 	github.com/matryer/is v1.3.0
+	go.etcd.io/bbolt v1.3.9
 )
+
+require golang.org/x/sys v0.9.0 // indirect
@@ -0,0 +1,207 @@
+package canvas
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Gradebook is a matrix of every student's grade for every assignment
+// in a course. Each row has one cell for each of the assignments in the
+// same order as Assignments.
+type Gradebook struct {
+	Assignments []*Assignment
+	Rows        []GradebookRow
+}
+
+// GradebookRow is one student's grades.
+type GradebookRow struct {
+	Student *User
+	Cells   []GradebookCell
+}
+
+// GradebookCell is a student's grade for one assignment.
+type GradebookCell struct {
+	// Submission is nil if canvas has no
+	// submission for the student.
+	Submission *Submission
+	Score      float64
+	Grade      string
+	// Graded is false when the submission has no score.
+	Graded  bool
+	Late    bool
+	Missing bool
+	Excused bool
+}
+
+// Gradebook will build the course's gradebook. The assignments,
+// students, and submissions are all fetched at the same time. The
+// options are used to list the course's students, e.g.
+// EnrollmentStateFilter(EnrollmentActive). Assignments are in the
+// order of their assignment group and position and students are
+// sorted by their sortable name.
+func (c *Course) Gradebook(opts ...Option) (*Gradebook, error) {
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		errl        []error
+		assignments []*Assignment
+		students    []*User
+		subs        = make(map[int]map[int]*Submission) // user id -> assignment id
+	)
+	collect := func(err error) {
+		if err != nil {
+			mu.Lock()
+			errl = append(errl, err)
+			mu.Unlock()
+		}
+	}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		var err error
+		assignments, err = c.ListAssignments()
+		collect(err)
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		students, err = c.Users(append([]Option{EnrollmentTypeFilter(StudentEnrollment)}, opts...)...)
+		collect(err)
+	}()
+	go func() {
+		defer wg.Done()
+		collect(c.gradebookSubmissions(&mu, subs))
+	}()
+	wg.Wait()
+	if err := joinErrs(errl); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(assignments, func(i, j int) bool {
+		a, b := assignments[i], assignments[j]
+		if a.AssignmentGroupID != b.AssignmentGroupID {
+			return a.AssignmentGroupID < b.AssignmentGroupID
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID < b.ID
+	})
+	sort.Slice(students, func(i, j int) bool {
+		a, b := students[i], students[j]
+		if a.SortableName != b.SortableName {
+			return a.SortableName < b.SortableName
+		}
+		return a.ID < b.ID
+	})
+	gb := &Gradebook{Assignments: assignments, Rows: make([]GradebookRow, len(students))}
+	for i, s := range students {
+		row := GradebookRow{Student: s, Cells: make([]GradebookCell, len(assignments))}
+		for j, a := range assignments {
+			row.Cells[j] = newGradebookCell(subs[s.ID][a.ID])
+		}
+		gb.Rows[i] = row
+	}
+	return gb, nil
+}
+
+// Cell returns the cell for a student and assignment
+// or nil if either is not in the gradebook.
+func (gb *Gradebook) Cell(userID, assignmentID int) *GradebookCell {
+	col := -1
+	for i, a := range gb.Assignments {
+		if a.ID == assignmentID {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return nil
+	}
+	for _, row := range gb.Rows {
+		if row.Student.ID == userID {
+			return &row.Cells[col]
+		}
+	}
+	return nil
+}
+
+// WriteCSV will write the gradebook as a csv file with one row for each
+// student and one column for each assignment. Cells have the score, "EX"
+// if the student is excused, or are empty if they have not been graded.
+func (gb *Gradebook) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"Student", "ID", "SIS User ID"}
+	for _, a := range gb.Assignments {
+		header = append(header, fmt.Sprintf("%s (%d)", a.Name, a.ID))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range gb.Rows {
+		rec := []string{row.Student.SortableName, strconv.Itoa(row.Student.ID), row.Student.SisUserID}
+		for _, cell := range row.Cells {
+			switch {
+			case cell.Excused:
+				rec = append(rec, "EX")
+			case cell.Graded:
+				rec = append(rec, strconv.FormatFloat(cell.Score, 'f', -1, 64))
+			default:
+				rec = append(rec, "")
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func newGradebookCell(s *Submission) GradebookCell {
+	if s == nil {
+		return GradebookCell{}
+	}
+	return GradebookCell{
+		Submission: s,
+		Score:      s.Score,
+		Grade:      s.Grade,
+		Graded:     s.Grade != "" || s.WorkflowState == "graded",
+		Late:       s.Late,
+		Missing:    s.Missing,
+		Excused:    s.Excused,
+	}
+}
+
+// gradebookSubmissions lists the submissions for every
+// student and puts them into subs by user and assignment.
+func (c *Course) gradebookSubmissions(mu *sync.Mutex, subs map[int]map[int]*Submission) error {
+	errs := newPaginatedList(c.client, c.id("/courses/%d/students/submissions"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			var group submissionGroup
+			if err := dec.Decode(&group); err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			byAssignment, ok := subs[group.UserID]
+			if !ok {
+				byAssignment = make(map[int]*Submission, len(group.Submissions))
+				subs[group.UserID] = byAssignment
+			}
+			for _, s := range group.Submissions {
+				byAssignment[s.AssignmentID] = s
+			}
+			return nil
+		})
+	}, []Option{ArrayOpt("student_ids", "all"), Opt("grouped", true)}).start()
+	var errl []error
+	for err := range errs {
+		errl = append(errl, err)
+	}
+	return joinErrs(errl)
+}
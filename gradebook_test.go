@@ -0,0 +1,66 @@
+package canvas
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGradebook(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	link := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+	}
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		link(w, r)
+		fmt.Fprint(w, `[{"id":20,"name":"HW 2","assignment_group_id":1,"position":2},
+			{"id":10,"name":"HW 1","assignment_group_id":1,"position":1},
+			{"id":30,"name":"Exam","assignment_group_id":2,"position":1}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("enrollment_type") != "student" {
+			t.Error("should only list students")
+		}
+		link(w, r)
+		fmt.Fprint(w, `[{"id":2,"sortable_name":"Smith, Zed"},{"id":1,"sortable_name":"Adams, Ann","sis_user_id":"s1"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/students/submissions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("grouped") != "true" || q.Get("student_ids[]") != "all" {
+			t.Errorf("wrong query: %v", q)
+		}
+		link(w, r)
+		fmt.Fprint(w, `[
+			{"user_id":1,"submissions":[
+				{"assignment_id":10,"score":9.5,"grade":"9.5","workflow_state":"graded"},
+				{"assignment_id":20,"score":0,"grade":"0","late":true,"workflow_state":"graded"},
+				{"assignment_id":30,"excused":true}]},
+			{"user_id":2,"submissions":[
+				{"assignment_id":10,"missing":true,"workflow_state":"unsubmitted"}]}]`)
+	})
+
+	c := &Course{ID: 1, client: client}
+	gb, err := c.Gradebook()
+	is.NoErr(err)
+	is.Equal(len(gb.Assignments), 3)
+	is.Equal(gb.Assignments[0].ID, 10)
+	is.Equal(gb.Assignments[2].ID, 30)
+	is.Equal(len(gb.Rows), 2)
+	is.Equal(gb.Rows[0].Student.ID, 1)
+	is.True(gb.Cell(1, 20).Late)
+	is.True(gb.Cell(1, 20).Graded)
+	is.True(gb.Cell(2, 10).Missing)
+	is.True(!gb.Cell(2, 30).Graded)
+	is.True(gb.Cell(3, 10) == nil)
+
+	var buf bytes.Buffer
+	is.NoErr(gb.WriteCSV(&buf))
+	is.Equal(buf.String(), "Student,ID,SIS User ID,HW 1 (10),HW 2 (20),Exam (30)\n"+
+		"\"Adams, Ann\",1,s1,9.5,0,EX\n"+
+		"\"Smith, Zed\",2,,,,\n")
+}
@@ -0,0 +1,282 @@
+// Package gradecalc computes course grades locally the same way that
+// canvas does. Grades are computed from a course's assignment groups
+// using group weights, drop rules, excused submissions, and optionally
+// counting ungraded assignments as zeros.
+package gradecalc
+
+import (
+	"sort"
+
+	"github.com/harrybrwn/go-canvas"
+)
+
+// Options control how a grade is calculated.
+type Options struct {
+	// Weighted should be set to the course's
+	// ApplyAssignmentGroupWeights setting.
+	Weighted bool
+	// UngradedAsZero will count ungraded assignments as zeros. Canvas
+	// does this for the final score but not the current score.
+	UngradedAsZero bool
+}
+
+// GroupResult is the computed score for one assignment group.
+type GroupResult struct {
+	Group    *canvas.AssignmentGroup
+	Score    float64
+	Possible float64
+	// Dropped holds the ids of the assignments that were
+	// dropped by the group's grading rules.
+	Dropped []int
+}
+
+// Percent returns the group's score as a percentage.
+func (gr *GroupResult) Percent() float64 {
+	if gr.Possible == 0 {
+		return 0
+	}
+	return gr.Score / gr.Possible * 100
+}
+
+// Result is a computed course grade.
+type Result struct {
+	// Score is the course score as a percentage.
+	Score float64
+	// Graded is false when there was nothing to compute
+	// a score from, canvas shows these scores as empty.
+	Graded bool
+	Groups []GroupResult
+}
+
+// Current computes the current score, ungraded assignments are ignored.
+func Current(groups []*canvas.AssignmentGroup, subs []*canvas.Submission, weighted bool) *Result {
+	return Calculate(groups, subs, Options{Weighted: weighted})
+}
+
+// Final computes the final score, ungraded assignments count as zeros.
+func Final(groups []*canvas.AssignmentGroup, subs []*canvas.Submission, weighted bool) *Result {
+	return Calculate(groups, subs, Options{Weighted: weighted, UngradedAsZero: true})
+}
+
+// Calculate computes the course grade for one student. The groups should
+// include their assignments (see canvas.IncludeOpt("assignments")). If subs
+// is nil, the submissions attached to each assignment are used which is what
+// canvas returns when a student uses IncludeOpt("assignments", "submission").
+func Calculate(groups []*canvas.AssignmentGroup, subs []*canvas.Submission, opts Options) *Result {
+	var bySubmission map[int]*canvas.Submission
+	if subs != nil {
+		bySubmission = make(map[int]*canvas.Submission, len(subs))
+		for _, s := range subs {
+			bySubmission[s.AssignmentID] = s
+		}
+	}
+	res := &Result{Groups: make([]GroupResult, 0, len(groups))}
+	for _, g := range groups {
+		items := make([]item, 0, len(g.Assignments))
+		for _, a := range g.Assignments {
+			sub := a.Submission
+			if bySubmission != nil {
+				sub = bySubmission[a.ID]
+			}
+			it, ok := newItem(a, sub, opts)
+			if !ok {
+				continue
+			}
+			items = append(items, it)
+		}
+		kept, dropped := dropItems(items, g.Rules)
+		gr := GroupResult{Group: g, Dropped: dropped}
+		for _, it := range kept {
+			gr.Score += it.score
+			gr.Possible += it.possible
+		}
+		res.Groups = append(res.Groups, gr)
+	}
+	if opts.Weighted {
+		res.Score, res.Graded = weightedScore(res.Groups)
+	} else {
+		res.Score, res.Graded = totalScore(res.Groups)
+	}
+	return res
+}
+
+func weightedScore(groups []GroupResult) (float64, bool) {
+	var score, fullWeight float64
+	for _, g := range groups {
+		if g.Possible == 0 {
+			continue
+		}
+		score += g.Score / g.Possible * g.Group.GroupWeight
+		fullWeight += g.Group.GroupWeight
+	}
+	if fullWeight == 0 {
+		return 0, false
+	}
+	if fullWeight < 100 {
+		score = score * 100 / fullWeight
+	}
+	return score, true
+}
+
+func totalScore(groups []GroupResult) (float64, bool) {
+	var score, possible float64
+	for _, g := range groups {
+		score += g.Score
+		possible += g.Possible
+	}
+	if possible == 0 {
+		return 0, false
+	}
+	return score / possible * 100, true
+}
+
+type item struct {
+	id        int
+	score     float64
+	possible  float64
+	neverDrop bool
+}
+
+func newItem(a *canvas.Assignment, sub *canvas.Submission, opts Options) (item, bool) {
+	if a.OmitFromFinalGrade || a.GradingType == canvas.NotGraded || !a.IsPublished() {
+		return item{}, false
+	}
+	if sub != nil && sub.Excused {
+		return item{}, false
+	}
+	it := item{id: a.ID, possible: a.PointsPossible}
+	if sub == nil || !graded(sub) {
+		if !opts.UngradedAsZero {
+			return item{}, false
+		}
+		return it, true
+	}
+	it.score = sub.Score
+	return it, true
+}
+
+func graded(s *canvas.Submission) bool {
+	return s.Grade != ""
+}
+
+// dropItems applies an assignment group's drop rules. Canvas keeps the set
+// of scores that gives the highest percentage when dropping the lowest
+// scores and the lowest percentage when dropping the highest scores which
+// is not always the same as dropping the lowest or highest raw scores when
+// assignments are worth different amounts of points.
+func dropItems(items []item, rules canvas.GradingRules) (kept []item, dropped []int) {
+	if rules.DropLowest == 0 && rules.DropHighest == 0 {
+		return items, nil
+	}
+	var droppable, cantDrop []item
+	for _, it := range items {
+		if containsInt(rules.NeverDrop, it.id) {
+			cantDrop = append(cantDrop, it)
+		} else {
+			droppable = append(droppable, it)
+		}
+	}
+	if len(droppable) == 0 {
+		return items, nil
+	}
+	dropLowest := rules.DropLowest
+	if dropLowest > len(droppable)-1 {
+		dropLowest = len(droppable) - 1
+	}
+	dropHighest := rules.DropHighest
+	if dropLowest+dropHighest >= len(droppable) {
+		dropHighest = 0
+	}
+	keepHighest := len(droppable) - dropLowest
+	keepLowest := keepHighest - dropHighest
+
+	kept = keepBest(droppable, cantDrop, keepHighest, true)
+	kept = keepBest(kept, cantDrop, keepLowest, false)
+
+	keptIDs := make(map[int]bool, len(kept))
+	for _, it := range kept {
+		keptIDs[it.id] = true
+	}
+	for _, it := range droppable {
+		if !keptIDs[it.id] {
+			dropped = append(dropped, it.id)
+		}
+	}
+	return append(kept, cantDrop...), dropped
+}
+
+// keepBest keeps n items that maximize (or minimize if highest is false)
+// the group's percentage. This uses Dinkelbach's method for fractional
+// programming which finds the optimal subset in a few iterations.
+func keepBest(items, cantDrop []item, n int, highest bool) []item {
+	if n < 1 {
+		n = 1
+	}
+	if len(items) <= n {
+		return items
+	}
+	sorted := make([]item, len(items))
+	copy(sorted, items)
+
+	pointed := false
+	for _, it := range items {
+		if it.possible > 0 {
+			pointed = true
+			break
+		}
+	}
+	if !pointed {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if highest {
+				return sorted[i].score > sorted[j].score
+			}
+			return sorted[i].score < sorted[j].score
+		})
+		return sorted[:n]
+	}
+
+	q := ratio(items, cantDrop)
+	var best []item
+	for i := 0; i < 100; i++ {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a := sorted[i].score - q*sorted[i].possible
+			b := sorted[j].score - q*sorted[j].possible
+			if highest {
+				return a > b
+			}
+			return a < b
+		})
+		best = append(best[:0], sorted[:n]...)
+		next := ratio(best, cantDrop)
+		if next == q {
+			break
+		}
+		q = next
+	}
+	return best
+}
+
+func ratio(items, extra []item) float64 {
+	var score, possible float64
+	for _, it := range items {
+		score += it.score
+		possible += it.possible
+	}
+	for _, it := range extra {
+		score += it.score
+		possible += it.possible
+	}
+	if possible == 0 {
+		return 0
+	}
+	return score / possible
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
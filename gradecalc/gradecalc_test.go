@@ -0,0 +1,97 @@
+package gradecalc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/harrybrwn/go-canvas"
+)
+
+func readJSON(t *testing.T, file string, v interface{}) {
+	t.Helper()
+	b, err := ioutil.ReadFile("testdata/" + file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(b, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// The expected scores in testdata/enrollments.json are the scores
+// that canvas computed for the same assignment groups and submissions.
+func TestCalculate(t *testing.T) {
+	var groups []*canvas.AssignmentGroup
+	readJSON(t, "assignment_groups.json", &groups)
+	var enrollments []struct {
+		canvas.Enrollment
+		Weighted bool `json:"apply_assignment_group_weights"`
+	}
+	readJSON(t, "enrollments.json", &enrollments)
+
+	for _, e := range enrollments {
+		current := Current(groups, nil, e.Weighted)
+		if !current.Graded {
+			t.Fatal("should have a current score")
+		}
+		if math.Abs(current.Score-e.Grades.CurrentScore) > 0.005 {
+			t.Errorf("weighted=%v: got current score %f; want %f", e.Weighted, current.Score, e.Grades.CurrentScore)
+		}
+		final := Final(groups, nil, e.Weighted)
+		if math.Abs(final.Score-e.Grades.FinalScore) > 0.005 {
+			t.Errorf("weighted=%v: got final score %f; want %f", e.Weighted, final.Score, e.Grades.FinalScore)
+		}
+	}
+
+	res := Final(groups, nil, true)
+	if len(res.Groups[0].Dropped) != 1 || res.Groups[0].Dropped[0] != 104 {
+		t.Errorf("should have dropped the zero worth 20 points, dropped %v", res.Groups[0].Dropped)
+	}
+	res = Current(groups, nil, true)
+	if len(res.Groups[0].Dropped) != 1 || res.Groups[0].Dropped[0] != 102 {
+		t.Errorf("should have dropped the lowest graded homework, dropped %v", res.Groups[0].Dropped)
+	}
+}
+
+func TestCalculate_Submissions(t *testing.T) {
+	groups := []*canvas.AssignmentGroup{{
+		GroupWeight: 100,
+		Assignments: []*canvas.Assignment{
+			{ID: 1, PointsPossible: 10, Published: true},
+			{ID: 2, PointsPossible: 10, Published: true},
+		},
+	}}
+	subs := []*canvas.Submission{
+		{AssignmentID: 1, Score: 5, Grade: "5"},
+	}
+	res := Current(groups, subs, false)
+	if res.Score != 50 {
+		t.Errorf("expected 50; got %f", res.Score)
+	}
+	res = Final(groups, subs, false)
+	if res.Score != 25 {
+		t.Errorf("expected 25; got %f", res.Score)
+	}
+	res = Current(groups, []*canvas.Submission{}, false)
+	if res.Graded {
+		t.Error("should not have a score without any graded submissions")
+	}
+}
+
+func TestDropItems(t *testing.T) {
+	items := []item{
+		{id: 1, score: 10, possible: 100},
+		{id: 2, score: 5, possible: 5},
+		{id: 3, score: 3, possible: 10},
+	}
+	kept, dropped := dropItems(items, canvas.GradingRules{DropHighest: 1})
+	if len(kept) != 2 || len(dropped) != 1 || dropped[0] != 2 {
+		t.Errorf("should drop the highest percentage; kept %v, dropped %v", kept, dropped)
+	}
+	kept, dropped = dropItems(items, canvas.GradingRules{DropLowest: 5})
+	if len(kept) != 1 || kept[0].id != 2 {
+		t.Errorf("should always keep one item; kept %v, dropped %v", kept, dropped)
+	}
+}
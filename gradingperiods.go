@@ -0,0 +1,185 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// GradingPeriodSet is a group of grading periods that is
+// used by the courses in the set's enrollment terms.
+//
+// https://canvas.instructure.com/doc/api/grading_period_sets.html
+type GradingPeriodSet struct {
+	ID       int    `json:"id" url:"-"`
+	Title    string `json:"title" url:"title,omitempty"`
+	Weighted bool   `json:"weighted" url:"weighted"`
+	// DisplayTotals will show the totals for all grading
+	// periods in the gradebook.
+	DisplayTotals     bool             `json:"display_totals_for_all_grading_periods" url:"display_totals_for_all_grading_periods"`
+	EnrollmentTermIDs []int            `json:"enrollment_term_ids" url:"-"`
+	GradingPeriods    []*GradingPeriod `json:"grading_periods" url:"-"`
+	AccountID         int              `json:"account_id" url:"-"`
+	CreatedAt         time.Time        `json:"created_at" url:"-"`
+	UpdatedAt         time.Time        `json:"updated_at" url:"-"`
+}
+
+// GradingPeriod is a span of time that grades are totaled for.
+type GradingPeriod struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	CloseDate time.Time `json:"close_date"`
+	// Weight is only used when the grading period set is weighted.
+	Weight   float64 `json:"weight"`
+	IsClosed bool    `json:"is_closed"`
+	IsLast   bool    `json:"is_last"`
+}
+
+type gradingPeriodSetOptions struct {
+	Set     GradingPeriodSet `url:"grading_period_set"`
+	TermIDs []int            `url:"enrollment_term_ids,brackets,omitempty"`
+}
+
+// GradingPeriodSets will list the account's grading period sets.
+//
+// https://canvas.instructure.com/doc/api/grading_period_sets.html#method.grading_period_sets.index
+func (a *Account) GradingPeriodSets(opts ...Option) (sets []*GradingPeriodSet, err error) {
+	ch := make(chan *GradingPeriodSet)
+	errs := newPaginatedList(a.cli, a.path("/grading_period_sets"), func(r io.Reader) error {
+		var res struct {
+			Sets []*GradingPeriodSet `json:"grading_period_sets"`
+		}
+		if err := decodeJSON(r, &res); err != nil {
+			return err
+		}
+		for _, s := range res.Sets {
+			ch <- s
+		}
+		return nil
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case s := <-ch:
+			sets = append(sets, s)
+		case err, ok := <-errs:
+			if !ok {
+				return sets, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// CreateGradingPeriodSet will create a grading period set for the
+// enrollment terms in set.EnrollmentTermIDs.
+//
+// https://canvas.instructure.com/doc/api/grading_period_sets.html#method.grading_period_sets.create
+func (a *Account) CreateGradingPeriodSet(set GradingPeriodSet) (*GradingPeriodSet, error) {
+	q, err := query.Values(&gradingPeriodSetOptions{Set: set, TermIDs: set.EnrollmentTermIDs})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := post(a.cli, a.path("/grading_period_sets"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res struct {
+		Set *GradingPeriodSet `json:"grading_period_set"`
+	}
+	if err = decodeJSON(resp.Body, &res); err != nil {
+		return nil, err
+	}
+	if res.Set == nil {
+		return nil, fmt.Errorf("no grading period set returned")
+	}
+	return res.Set, nil
+}
+
+// UpdateGradingPeriodSet will update the grading period set's title,
+// weighting, and enrollment terms. Terms that are not in
+// set.EnrollmentTermIDs are removed from the set.
+//
+// https://canvas.instructure.com/doc/api/grading_period_sets.html#method.grading_period_sets.update
+func (a *Account) UpdateGradingPeriodSet(set *GradingPeriodSet) error {
+	q, err := query.Values(&gradingPeriodSetOptions{Set: *set, TermIDs: set.EnrollmentTermIDs})
+	if err != nil {
+		return err
+	}
+	if len(set.EnrollmentTermIDs) == 0 {
+		// an empty array removes every term
+		q.Set("enrollment_term_ids[]", "")
+	}
+	resp, err := do(a.cli, newreq("PATCH", a.path(fmt.Sprintf("/grading_period_sets/%d", set.ID)), q))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// DeleteGradingPeriodSet will delete a grading period set
+// along with all of its grading periods.
+//
+// https://canvas.instructure.com/doc/api/grading_period_sets.html#method.grading_period_sets.destroy
+func (a *Account) DeleteGradingPeriodSet(id int) error {
+	resp, err := delete(a.cli, a.path(fmt.Sprintf("/grading_period_sets/%d", id)), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// SetGradingPeriods will create or update the grading periods of a
+// grading period set. Periods with an ID are updated and the rest are
+// created. Periods without a close date are closed at their end date.
+//
+// https://canvas.instructure.com/doc/api/grading_periods.html#method.grading_periods.batch_update
+func (a *Account) SetGradingPeriods(setID int, periods []*GradingPeriod) ([]*GradingPeriod, error) {
+	q := params{}
+	for i, p := range periods {
+		key := func(k string) string { return fmt.Sprintf("grading_periods[%d][%s]", i, k) }
+		if p.ID != 0 {
+			q.Set(key("id"), strconv.Itoa(p.ID))
+		}
+		closeDate := p.CloseDate
+		if closeDate.IsZero() {
+			closeDate = p.EndDate
+		}
+		q.Set(key("title"), p.Title)
+		q.Set(key("start_date"), p.StartDate.Format(time.RFC3339))
+		q.Set(key("end_date"), p.EndDate.Format(time.RFC3339))
+		q.Set(key("close_date"), closeDate.Format(time.RFC3339))
+		q.Set(key("weight"), strconv.FormatFloat(p.Weight, 'f', -1, 64))
+	}
+	resp, err := do(a.cli, newreq("PATCH", fmt.Sprintf("/grading_period_sets/%d/grading_periods/batch_update", setID), q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res gradingPeriodsResp
+	if err = decodeJSON(resp.Body, &res); err != nil {
+		return nil, err
+	}
+	return res.Periods, nil
+}
+
+// GradingPeriods will get the course's grading periods.
+//
+// https://canvas.instructure.com/doc/api/grading_periods.html#method.grading_periods.index
+func (c *Course) GradingPeriods() ([]*GradingPeriod, error) {
+	var res gradingPeriodsResp
+	if err := getjson(c.client, &res, nil, "/courses/%d/grading_periods", c.ID); err != nil {
+		return nil, err
+	}
+	return res.Periods, nil
+}
+
+type gradingPeriodsResp struct {
+	Periods []*GradingPeriod `json:"grading_periods"`
+}
@@ -0,0 +1,87 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGradingPeriodSets(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	a := &Account{ID: 1, cli: client}
+	mux.HandleFunc("/api/v1/accounts/1/grading_period_sets", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/accounts/1/grading_period_sets?page=1>; rel="last"`, DefaultHost))
+			fmt.Fprint(w, `{"grading_period_sets":[{"id":3,"title":"2026","weighted":true,"enrollment_term_ids":[4,5]}]}`)
+		case "POST":
+			if q.Get("grading_period_set[title]") != "2026" || q.Get("grading_period_set[weighted]") != "true" ||
+				q.Get("grading_period_set[display_totals_for_all_grading_periods]") != "false" ||
+				len(q["enrollment_term_ids[]"]) != 2 {
+				t.Errorf("wrong grading period set: %v", q)
+			}
+			fmt.Fprint(w, `{"grading_period_set":{"id":3,"title":"2026","weighted":true,"enrollment_term_ids":[4,5]}}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/accounts/1/grading_period_sets/3", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			q := r.URL.Query()
+			if q.Get("grading_period_set[weighted]") != "false" || q["enrollment_term_ids[]"][0] != "4" {
+				t.Errorf("wrong update: %v", q)
+			}
+		case "DELETE":
+		default:
+			t.Errorf("wrong method %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/v1/grading_period_sets/3/grading_periods/batch_update", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PATCH")
+		q := r.URL.Query()
+		if q.Get("grading_periods[0][id]") != "" || q.Get("grading_periods[0][title]") != "Fall" ||
+			q.Get("grading_periods[0][close_date]") != "2026-12-20T00:00:00Z" ||
+			q.Get("grading_periods[1][id]") != "8" || q.Get("grading_periods[1][weight]") != "50" {
+			t.Errorf("wrong grading periods: %v", q)
+		}
+		fmt.Fprint(w, `{"grading_periods":[{"id":7,"title":"Fall"},{"id":8,"title":"Spring","weight":50}]}`)
+	})
+	mux.HandleFunc("/api/v1/courses/2/grading_periods", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"grading_periods":[{"id":7,"title":"Fall","is_closed":true}]}`)
+	})
+
+	sets, err := a.GradingPeriodSets()
+	is.NoErr(err)
+	is.Equal(len(sets), 1)
+	is.Equal(sets[0].EnrollmentTermIDs, []int{4, 5})
+
+	set, err := a.CreateGradingPeriodSet(GradingPeriodSet{Title: "2026", Weighted: true, EnrollmentTermIDs: []int{4, 5}})
+	is.NoErr(err)
+	is.Equal(set.ID, 3)
+	set.Weighted = false
+	set.EnrollmentTermIDs = []int{4}
+	is.NoErr(a.UpdateGradingPeriodSet(set))
+
+	periods, err := a.SetGradingPeriods(set.ID, []*GradingPeriod{
+		{
+			Title:     "Fall",
+			StartDate: time.Date(2026, 8, 20, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{ID: 8, Title: "Spring", Weight: 50},
+	})
+	is.NoErr(err)
+	is.Equal(len(periods), 2)
+	is.NoErr(a.DeleteGradingPeriodSet(set.ID))
+
+	course := &Course{ID: 2, client: client}
+	periods, err = course.GradingPeriods()
+	is.NoErr(err)
+	is.True(periods[0].IsClosed)
+}
@@ -0,0 +1,163 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// graphqlPath is the canvas graphql endpoint, it is
+// not under the rest api path.
+const graphqlPath = "/api/graphql"
+
+// GraphQLError is an error returned from the
+// canvas graphql api.
+type GraphQLError struct {
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return "graphql: " + strings.Join(e.Messages, ", ")
+}
+
+// GraphQL will run a graphql query or mutation and decode the "data"
+// part of the response into out. Errors in the response are returned
+// as a *GraphQLError.
+//
+//	var data struct {
+//		Course struct {
+//			Name string `json:"name"`
+//		} `json:"course"`
+//	}
+//	err := c.GraphQL(`query ($id: ID!) { course(id: $id) { name } }`,
+//		map[string]interface{}{"id": "1"}, &data)
+//
+// https://canvas.instructure.com/doc/api/file.graphql.html
+func (c *Canvas) GraphQL(query string, variables map[string]interface{}, out interface{}) error {
+	return graphql(c.client, query, variables, out)
+}
+
+// GraphQL will run a graphql query and decode the data into out.
+func GraphQL(query string, variables map[string]interface{}, out interface{}) error {
+	return ca.GraphQL(query, variables, out)
+}
+
+// PageInfo is the pagination information for
+// a graphql connection.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GraphQLPages will run a query once for every page of a connection.
+// The query must take an "$after: String" variable and pass it to the
+// connection along with asking for the connection's pageInfo. The page
+// function is called with the data of every page and should return
+// the connection's PageInfo so that the next page can be requested.
+//
+//	query ($id: ID!, $after: String) {
+//	  course(id: $id) {
+//	    assignmentsConnection(first: 50, after: $after) {
+//	      nodes { name }
+//	      pageInfo { hasNextPage endCursor }
+//	    }
+//	  }
+//	}
+func (c *Canvas) GraphQLPages(
+	query string,
+	variables map[string]interface{},
+	page func(data json.RawMessage) (PageInfo, error),
+) error {
+	vars := make(map[string]interface{}, len(variables)+1)
+	for k, v := range variables {
+		vars[k] = v
+	}
+	for {
+		var data json.RawMessage
+		if err := graphql(c.client, query, vars, &data); err != nil {
+			return err
+		}
+		info, err := page(data)
+		if err != nil {
+			return err
+		}
+		if !info.HasNextPage || info.EndCursor == "" {
+			return nil
+		}
+		vars["after"] = info.EndCursor
+	}
+}
+
+// graphql will run a graphql query and decode the
+// "data" part of the response into data.
+func graphql(d doer, query string, vars map[string]interface{}, data interface{}) error {
+	b, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": vars,
+	})
+	if err != nil {
+		return err
+	}
+	req := &http.Request{
+		Method: "POST",
+		Proto:  "HTTP/1.1",
+		URL:    &url.URL{Scheme: "https", Path: graphqlPath},
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   ioutil.NopCloser(bytes.NewReader(b)),
+		GetBody: func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(b)), nil
+		},
+		ContentLength: int64(len(b)),
+	}
+	resp, err := do(d, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err = decodeJSON(resp.Body, &result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		e := &GraphQLError{}
+		for _, r := range result.Errors {
+			e.Messages = append(e.Messages, r.Message)
+		}
+		return e
+	}
+	if len(result.Data) == 0 || data == nil {
+		return nil
+	}
+	return json.Unmarshal(result.Data, data)
+}
+
+// mutationErrors are the errors that canvas
+// includes in mutation results.
+type mutationErrors []struct {
+	Attribute string `json:"attribute"`
+	Message   string `json:"message"`
+}
+
+func (me mutationErrors) err() error {
+	if len(me) == 0 {
+		return nil
+	}
+	e := &GraphQLError{}
+	for _, m := range me {
+		if m.Attribute != "" {
+			e.Messages = append(e.Messages, m.Attribute+": "+m.Message)
+		} else {
+			e.Messages = append(e.Messages, m.Message)
+		}
+	}
+	return e
+}
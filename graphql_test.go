@@ -0,0 +1,71 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGraphQLPages(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if body.Variables["id"] != "1" {
+			t.Error("variables were not sent")
+		}
+		switch body.Variables["after"] {
+		case nil:
+			fmt.Fprint(w, `{"data":{"course":{"assignmentsConnection":{
+				"nodes":[{"name":"one"},{"name":"two"}],
+				"pageInfo":{"hasNextPage":true,"endCursor":"Mg"}}}}}`)
+		case "Mg":
+			fmt.Fprint(w, `{"data":{"course":{"assignmentsConnection":{
+				"nodes":[{"name":"three"}],
+				"pageInfo":{"hasNextPage":false,"endCursor":"Mw"}}}}}`)
+		default:
+			fmt.Fprint(w, `{"errors":[{"message":"bad cursor"}]}`)
+		}
+	})
+	c := &Canvas{client: client}
+	vars := map[string]interface{}{"id": "1"}
+	var names []string
+	err := c.GraphQLPages(`query`, vars, func(data json.RawMessage) (PageInfo, error) {
+		var res struct {
+			Course struct {
+				Assignments struct {
+					Nodes []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+					PageInfo PageInfo `json:"pageInfo"`
+				} `json:"assignmentsConnection"`
+			} `json:"course"`
+		}
+		if err := json.Unmarshal(data, &res); err != nil {
+			return PageInfo{}, err
+		}
+		for _, n := range res.Course.Assignments.Nodes {
+			names = append(names, n.Name)
+		}
+		return res.Course.Assignments.PageInfo, nil
+	})
+	is.NoErr(err)
+	is.Equal(names, []string{"one", "two", "three"})
+	_, ok := vars["after"]
+	is.True(!ok) // the variables given should not be changed
+
+	err = c.GraphQL(`query`, map[string]interface{}{"id": "1", "after": "x"}, nil)
+	gqlerr, ok := err.(*GraphQLError)
+	is.True(ok)
+	is.Equal(gqlerr.Messages, []string{"bad cursor"})
+}
@@ -0,0 +1,423 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Group join levels.
+const (
+	GroupParentContextAutoJoin = "parent_context_auto_join"
+	GroupParentContextRequest  = "parent_context_request"
+	GroupInvitationOnly        = "invitation_only"
+)
+
+// Group is a canvas group.
+//
+// https://canvas.instructure.com/doc/api/groups.html
+type Group struct {
+	ID              int    `json:"id" url:"-"`
+	Name            string `json:"name" url:"name,omitempty"`
+	Description     string `json:"description" url:"description,omitempty"`
+	IsPublic        bool   `json:"is_public" url:"is_public,omitempty"`
+	FollowedByUser  bool   `json:"followed_by_user" url:"-"`
+	JoinLevel       string `json:"join_level" url:"join_level,omitempty"`
+	MembersCount    int    `json:"members_count" url:"-"`
+	AvatarURL       string `json:"avatar_url" url:"-"`
+	ContextType     string `json:"context_type" url:"-"`
+	CourseID        int    `json:"course_id" url:"-"`
+	AccountID       int    `json:"account_id" url:"-"`
+	Role            string `json:"role" url:"-"`
+	GroupCategoryID int    `json:"group_category_id" url:"-"`
+	SisGroupID      string `json:"sis_group_id" url:"sis_group_id,omitempty"`
+	SisImportID     int    `json:"sis_import_id" url:"-"`
+	StorageQuotaMB  int    `json:"storage_quota_mb" url:"storage_quota_mb,omitempty"`
+
+	client doer
+}
+
+// GroupCategory is a set of groups in a course or account.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html
+type GroupCategory struct {
+	ID   int    `json:"id" url:"-"`
+	Name string `json:"name" url:"name,omitempty"`
+	Role string `json:"role" url:"-"`
+	// SelfSignup is "enabled", "restricted" to students in the same
+	// section, or empty when students cannot sign up for groups.
+	SelfSignup string `json:"self_signup" url:"self_signup,omitempty"`
+	// AutoLeader is "first" or "random" when a group leader is
+	// assigned automatically.
+	AutoLeader         string `json:"auto_leader" url:"auto_leader,omitempty"`
+	ContextType        string `json:"context_type" url:"-"`
+	AccountID          int    `json:"account_id" url:"-"`
+	CourseID           int    `json:"course_id" url:"-"`
+	GroupLimit         int    `json:"group_limit" url:"group_limit,omitempty"`
+	SisGroupCategoryID string `json:"sis_group_category_id" url:"sis_group_category_id,omitempty"`
+	SisImportID        int    `json:"sis_import_id" url:"-"`
+
+	client doer
+}
+
+// GroupMembership is a user's membership in a group.
+type GroupMembership struct {
+	ID            int    `json:"id"`
+	GroupID       int    `json:"group_id"`
+	UserID        int    `json:"user_id"`
+	WorkflowState string `json:"workflow_state"`
+	Moderator     bool   `json:"moderator"`
+	JustCreated   bool   `json:"just_created"`
+	SisImportID   int    `json:"sis_import_id"`
+}
+
+// Groups will get the current user's groups.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.index
+func (c *Canvas) Groups(opts ...Option) ([]*Group, error) {
+	return listGroups(c.client, "/users/self/groups", opts)
+}
+
+// Groups will get the current user's groups.
+func Groups(opts ...Option) ([]*Group, error) { return ca.Groups(opts...) }
+
+// GetGroup will get a group by id.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.show
+func (c *Canvas) GetGroup(id int, opts ...Option) (*Group, error) {
+	g := &Group{client: c.client}
+	return g, getjson(c.client, g, optEnc(opts), "/groups/%d", id)
+}
+
+// GetGroup will get a group by id.
+func GetGroup(id int, opts ...Option) (*Group, error) { return ca.GetGroup(id, opts...) }
+
+// Groups will get the groups in the course.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.context_index
+func (c *Course) Groups(opts ...Option) ([]*Group, error) {
+	return listGroups(c.client, c.id("/courses/%d/groups"), opts)
+}
+
+// GroupCategories will get the course's group categories.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.index
+func (c *Course) GroupCategories(opts ...Option) (cats []*GroupCategory, err error) {
+	ch := make(chan *GroupCategory)
+	errs := newPaginatedList(c.client, c.id("/courses/%d/group_categories"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			gc := &GroupCategory{client: c.client}
+			if err := dec.Decode(gc); err != nil {
+				return err
+			}
+			ch <- gc
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case gc := <-ch:
+			cats = append(cats, gc)
+		case err, ok := <-errs:
+			if !ok {
+				return cats, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// CreateGroupCategory will create a group category in the course.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.create
+func (c *Course) CreateGroupCategory(gc GroupCategory, opts ...Option) (*GroupCategory, error) {
+	q, err := query.Values(&gc)
+	if err != nil {
+		return nil, err
+	}
+	params(q).Add(opts)
+	resp, err := post(c.client, c.id("/courses/%d/group_categories"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	cat := &GroupCategory{client: c.client}
+	return cat, decodeJSON(resp.Body, cat)
+}
+
+// Groups will get the groups in the category.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.groups
+func (gc *GroupCategory) Groups(opts ...Option) ([]*Group, error) {
+	return listGroups(gc.client, fmt.Sprintf("/group_categories/%d/groups", gc.ID), opts)
+}
+
+// CreateGroup will create a group in the category.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.create
+func (gc *GroupCategory) CreateGroup(g Group) (*Group, error) {
+	q, err := query.Values(&g)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := post(gc.client, fmt.Sprintf("/group_categories/%d/groups", gc.ID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	group := &Group{client: gc.client}
+	return group, decodeJSON(resp.Body, group)
+}
+
+// Clone will create a copy of the category and its groups in the given
+// course, e.g. to set up the same project groups for next semester. The
+// settings and groups are copied but the members and sis ids are not.
+// Options override the copied category settings, so Opt("name", "Labs
+// (Fall)") will rename the copy. Use ImportMembershipCSV on the new
+// category to fill the groups.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.create
+func (gc *GroupCategory) Clone(c *Course, opts ...Option) (*GroupCategory, error) {
+	groups, err := gc.Groups()
+	if err != nil {
+		return nil, err
+	}
+	cat, err := c.CreateGroupCategory(GroupCategory{
+		Name:       gc.Name,
+		SelfSignup: gc.SelfSignup,
+		AutoLeader: gc.AutoLeader,
+		GroupLimit: gc.GroupLimit,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		_, err = cat.CreateGroup(Group{
+			Name:           g.Name,
+			Description:    g.Description,
+			IsPublic:       g.IsPublic,
+			JoinLevel:      g.JoinLevel,
+			StorageQuotaMB: g.StorageQuotaMB,
+		})
+		if err != nil {
+			return cat, fmt.Errorf("could not clone group %q: %w", g.Name, err)
+		}
+	}
+	return cat, nil
+}
+
+// ImportMembershipCSV will assign users to the category's groups from a
+// csv file. Each row has a user column (user_id, sis_user_id, or
+// login_id) and a group column (group_name, canvas_group_id, or
+// group_id). Groups that do not exist are created. The import runs in
+// the background on canvas and the returned Progress can be used to
+// wait for it.
+//
+//	p, err := cat.ImportMembershipCSV(strings.NewReader("sis_user_id,group_name\ns1,Team 1\n"))
+//	if err != nil {
+//		return err
+//	}
+//	err = p.Wait(ctx)
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.import
+func (gc *GroupCategory) ImportMembershipCSV(r io.Reader) (*Progress, error) {
+	req, err := newAttachmentReq(fmt.Sprintf("/group_categories/%d/import", gc.ID), "memberships.csv", r)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(gc.client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	p := &Progress{client: gc.client}
+	return p, decodeJSON(resp.Body, p)
+}
+
+// Users will get the users in the category. Use Opt("unassigned", true)
+// to only get the users that are not in a group.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.users
+func (gc *GroupCategory) Users(opts ...Option) ([]*User, error) {
+	return collectUsers(gc.client, fmt.Sprintf("/group_categories/%d/users", gc.ID), opts)
+}
+
+// Delete will delete the category along with all of its groups.
+//
+// https://canvas.instructure.com/doc/api/group_categories.html#method.group_categories.destroy
+func (gc *GroupCategory) Delete() error {
+	resp, err := delete(gc.client, fmt.Sprintf("/group_categories/%d", gc.ID), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Edit will save any changes made to the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.update
+func (g *Group) Edit(opts ...Option) error {
+	q, err := query.Values(g)
+	if err != nil {
+		return err
+	}
+	params(q).Add(opts)
+	resp, err := put(g.client, g.path(""), q)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, g)
+}
+
+// Delete will delete the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.destroy
+func (g *Group) Delete() error {
+	resp, err := delete(g.client, g.path(""), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Users will get the users in the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.groups.users
+func (g *Group) Users(opts ...Option) ([]*User, error) {
+	return collectUsers(g.client, g.path("/users"), opts)
+}
+
+// Memberships will get the group's memberships. Use
+// Opt("filter_states[]", "invited") to only get pending memberships.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.index
+func (g *Group) Memberships(opts ...Option) (mems []*GroupMembership, err error) {
+	ch := make(chan *GroupMembership)
+	errs := newPaginatedList(g.client, g.path("/memberships"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			m := &GroupMembership{}
+			if err := dec.Decode(m); err != nil {
+				return err
+			}
+			ch <- m
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case m := <-ch:
+			mems = append(mems, m)
+		case err, ok := <-errs:
+			if !ok {
+				return mems, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// AddMember will add a user to the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.create
+func (g *Group) AddMember(userID int) (*GroupMembership, error) {
+	return g.membership("POST", "/memberships", params{"user_id": {fmt.Sprint(userID)}})
+}
+
+// UpdateMembership will change a user's membership in the group,
+// e.g. making them the group leader with Opt("moderator", true).
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.update
+func (g *Group) UpdateMembership(userID int, opts ...Option) (*GroupMembership, error) {
+	return g.membership("PUT", fmt.Sprintf("/users/%d", userID), optEnc(opts))
+}
+
+// RemoveMember will remove a user from the group.
+//
+// https://canvas.instructure.com/doc/api/groups.html#method.group_memberships.destroy
+func (g *Group) RemoveMember(userID int) error {
+	resp, err := delete(g.client, g.path(fmt.Sprintf("/users/%d", userID)), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (g *Group) membership(method, path string, q encoder) (*GroupMembership, error) {
+	resp, err := do(g.client, newreq(method, g.path(path), q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	m := &GroupMembership{}
+	return m, decodeJSON(resp.Body, m)
+}
+
+// Files returns a channel of the group's files.
+func (g *Group) Files(opts ...Option) <-chan *File {
+	return filesChannel(g.client, g.path("/files"), ConcurrentErrorHandler, opts, nil)
+}
+
+// ListFiles will get all of the group's files.
+func (g *Group) ListFiles(opts ...Option) ([]*File, error) {
+	return listFiles(g.client, g.path("/files"), nil, opts)
+}
+
+// DiscussionTopics will get the group's discussion topics.
+//
+// https://canvas.instructure.com/doc/api/discussion_topics.html#method.discussion_topics.index
+func (g *Group) DiscussionTopics(opts ...Option) ([]*DiscussionTopic, error) {
+	return listDiscussionTopics(g.client, g.path("/discussion_topics"), opts)
+}
+
+// Pages will get the group's wiki pages.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (g *Group) Pages(opts ...Option) ([]*Page, error) {
+	return listPages(g.client, g.path(""), opts)
+}
+
+// Page will get one of the group's pages given its url or id.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show
+func (g *Group) Page(urlOrID string, opts ...Option) (*Page, error) {
+	return getPage(g.client, g.path(""), urlOrID, opts)
+}
+
+// ContextCode returns the group's context code.
+func (g *Group) ContextCode() string {
+	return fmt.Sprintf("group_%d", g.ID)
+}
+
+func (g *Group) path(s string) string {
+	return fmt.Sprintf("/groups/%d", g.ID) + s
+}
+
+func listGroups(d doer, path string, opts []Option) (groups []*Group, err error) {
+	ch := make(chan *Group)
+	errs := newPaginatedList(d, path, func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			g := &Group{client: d}
+			if err := dec.Decode(g); err != nil {
+				return err
+			}
+			ch <- g
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case g := <-ch:
+			groups = append(groups, g)
+		case err, ok := <-errs:
+			if !ok {
+				return groups, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
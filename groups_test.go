@@ -0,0 +1,201 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGroups(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	link := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+	}
+	mux.HandleFunc("/api/v1/courses/1/group_categories", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			link(w, r)
+			fmt.Fprint(w, `[{"id":3,"name":"Projects","course_id":1}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("name") != "Labs" || q.Get("self_signup") != "enabled" {
+				t.Error("wrong query parameters")
+			}
+			fmt.Fprint(w, `{"id":4,"name":"Labs","self_signup":"enabled"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/group_categories/3/groups", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			link(w, r)
+			fmt.Fprint(w, `[{"id":7,"name":"Team 1","group_category_id":3,"members_count":2}]`)
+		case "POST":
+			if r.URL.Query().Get("name") != "Team 2" {
+				t.Error("wrong group name")
+			}
+			fmt.Fprint(w, `{"id":8,"name":"Team 2","group_category_id":3}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/groups/7", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			if r.URL.Query().Get("description") != "the best" {
+				t.Error("description not sent")
+			}
+			fmt.Fprint(w, `{"id":7,"name":"Team 1","description":"the best"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"id":7}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/groups/7/users", func(w http.ResponseWriter, r *http.Request) {
+		link(w, r)
+		fmt.Fprint(w, `[{"id":11},{"id":12}]`)
+	})
+	mux.HandleFunc("/api/v1/groups/7/memberships", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			link(w, r)
+			fmt.Fprint(w, `[{"id":1,"group_id":7,"user_id":11,"workflow_state":"accepted"}]`)
+		case "POST":
+			if r.URL.Query().Get("user_id") != "13" {
+				t.Error("wrong user")
+			}
+			fmt.Fprint(w, `{"id":2,"group_id":7,"user_id":13,"just_created":true}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/groups/7/users/13", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			if r.URL.Query().Get("moderator") != "true" {
+				t.Error("should be made moderator")
+			}
+			fmt.Fprint(w, `{"id":2,"group_id":7,"user_id":13,"moderator":true}`)
+		case "DELETE":
+			fmt.Fprint(w, `{}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/groups/7/discussion_topics", func(w http.ResponseWriter, r *http.Request) {
+		link(w, r)
+		fmt.Fprint(w, `[{"id":5,"title":"Plan"}]`)
+	})
+	mux.HandleFunc("/api/v1/groups/7/pages", func(w http.ResponseWriter, r *http.Request) {
+		link(w, r)
+		fmt.Fprint(w, `[{"page_id":6,"url":"notes","title":"Notes"}]`)
+	})
+
+	c := &Course{ID: 1, client: client}
+	cats, err := c.GroupCategories()
+	is.NoErr(err)
+	is.Equal(len(cats), 1)
+	cat, err := c.CreateGroupCategory(GroupCategory{Name: "Labs", SelfSignup: "enabled"})
+	is.NoErr(err)
+	is.Equal(cat.ID, 4)
+
+	groups, err := cats[0].Groups()
+	is.NoErr(err)
+	is.Equal(len(groups), 1)
+	g := groups[0]
+	is.Equal(g.MembersCount, 2)
+	created, err := cats[0].CreateGroup(Group{Name: "Team 2"})
+	is.NoErr(err)
+	is.Equal(created.ID, 8)
+
+	g.Description = "the best"
+	is.NoErr(g.Edit())
+	is.Equal(g.Description, "the best")
+	users, err := g.Users()
+	is.NoErr(err)
+	is.Equal(len(users), 2)
+	mems, err := g.Memberships()
+	is.NoErr(err)
+	is.Equal(mems[0].UserID, 11)
+	m, err := g.AddMember(13)
+	is.NoErr(err)
+	is.True(m.JustCreated)
+	m, err = g.UpdateMembership(13, Opt("moderator", true))
+	is.NoErr(err)
+	is.True(m.Moderator)
+	is.NoErr(g.RemoveMember(13))
+
+	topics, err := g.DiscussionTopics()
+	is.NoErr(err)
+	is.Equal(len(topics), 1)
+	pages, err := g.Pages()
+	is.NoErr(err)
+	is.Equal(pages[0].URL, "notes")
+	is.Equal(pages[0].path(""), "/groups/7/pages/notes")
+	is.NoErr(g.Delete())
+}
+
+func TestGroupCategoryClone(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	defer func(d time.Duration) { progressPollInterval = d }(progressPollInterval)
+	progressPollInterval = time.Millisecond
+
+	mux.HandleFunc("/api/v1/group_categories/3/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[{"id":7,"name":"Team 1","join_level":"invitation_only","sis_group_id":"t1"},{"id":8,"name":"Team 2"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/2/group_categories", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("name") != "Projects (Fall)" || q.Get("self_signup") != "enabled" || q.Get("group_limit") != "4" {
+			t.Errorf("wrong query parameters: %v", q)
+		}
+		if q.Get("sis_group_category_id") != "" {
+			t.Error("sis id should not be copied")
+		}
+		fmt.Fprint(w, `{"id":9,"name":"Projects (Fall)","course_id":2}`)
+	})
+	var created []string
+	mux.HandleFunc("/api/v1/group_categories/9/groups", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("sis_group_id") != "" {
+			t.Error("sis id should not be copied")
+		}
+		if q.Get("name") == "Team 1" && q.Get("join_level") != GroupInvitationOnly {
+			t.Error("join level not copied")
+		}
+		created = append(created, q.Get("name"))
+		fmt.Fprintf(w, `{"id":%d,"name":%q}`, 10+len(created), q.Get("name"))
+	})
+	mux.HandleFunc("/api/v1/group_categories/9/import", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		f, _, err := r.FormFile("attachment")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b, _ := ioutil.ReadAll(f)
+		if !strings.HasPrefix(string(b), "sis_user_id,group_name") {
+			t.Error("wrong file contents")
+		}
+		fmt.Fprint(w, `{"id":20,"workflow_state":"queued"}`)
+	})
+	mux.HandleFunc("/api/v1/progress/20", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":20,"workflow_state":"completed","completion":100}`)
+	})
+
+	gc := &GroupCategory{ID: 3, Name: "Projects", SelfSignup: "enabled", GroupLimit: 4, SisGroupCategoryID: "p", client: client}
+	cat, err := gc.Clone(&Course{ID: 2, client: client}, Opt("name", "Projects (Fall)"))
+	is.NoErr(err)
+	is.Equal(cat.ID, 9)
+	is.Equal(created, []string{"Team 1", "Team 2"})
+
+	p, err := cat.ImportMembershipCSV(strings.NewReader("sis_user_id,group_name\ns1,Team 1\n"))
+	is.NoErr(err)
+	is.Equal(p.ID, 20)
+	is.NoErr(p.Wait(context.Background()))
+	is.True(p.Done())
+}
@@ -0,0 +1,57 @@
+package canvas
+
+import (
+	"io"
+	"time"
+)
+
+// HistoryEntry is a page that a user has recently viewed.
+type HistoryEntry struct {
+	AssetCode             string    `json:"asset_code"`
+	AssetName             string    `json:"asset_name"`
+	AssetIcon             string    `json:"asset_icon"`
+	AssetReadableCategory string    `json:"asset_readable_category"`
+	ContextType           string    `json:"context_type"`
+	ContextID             int       `json:"context_id"`
+	ContextName           string    `json:"context_name"`
+	VisitedURL            string    `json:"visited_url"`
+	VisitedAt             time.Time `json:"visited_at"`
+	// InteractionSeconds is the time spent on the page.
+	InteractionSeconds float64 `json:"interaction_seconds"`
+}
+
+// Interaction returns the time spent on the page.
+func (he *HistoryEntry) Interaction() time.Duration {
+	return time.Duration(he.InteractionSeconds * float64(time.Second))
+}
+
+// History will get the user's recently viewed pages, most recent first.
+// Only the current user's history can be viewed unless the current user
+// is an admin.
+//
+// https://canvas.instructure.com/doc/api/history.html#method.history.index
+func (u *User) History(opts ...Option) (history []*HistoryEntry, err error) {
+	ch := make(chan *HistoryEntry)
+	errs := newPaginatedList(u.client, u.id("/users/%d/history"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			h := &HistoryEntry{}
+			if err := dec.Decode(h); err != nil {
+				return err
+			}
+			ch <- h
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case h := <-ch:
+			history = append(history, h)
+		case err, ok := <-errs:
+			if !ok {
+				return history, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
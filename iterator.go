@@ -0,0 +1,250 @@
+package canvas
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Iterator goes through every item of a paginated endpoint one page at a
+// time by following the rel="next" link that canvas sends with each page.
+//
+//	it := canvas.Paginate[*canvas.Assignment](c, "/courses/1/assignments")
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Value().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type Iterator[T any] struct {
+	d     doer
+	path  string
+	query params
+	next  *url.URL
+	// prepare is called on every item after it is
+	// decoded, it can be nil.
+	prepare func(T)
+
+	started bool
+	page    int
+	body    io.ReadCloser
+	dec     *arrayDecoder
+	val     T
+	err     error
+
+	// pos is the position returned by Checkpoint, it only moves
+	// forward once items have been read. skip is the number of items
+	// that still need to be skipped after resuming from a checkpoint.
+	pos  Checkpoint
+	skip int
+}
+
+// Checkpoint is the position of an Iterator. It can be saved as
+// json and given to Iterator.ResumeFrom to continue a listing that
+// was stopped part way through, e.g. by a job that runs every night
+// and cannot get through a large listing in one run.
+type Checkpoint struct {
+	// URL is the url of the page to resume at, it is empty
+	// for the first page.
+	URL string `json:"url,omitempty"`
+	// Offset is the number of items of the page that
+	// have already been read.
+	Offset int `json:"offset"`
+	// Done is true when there are no more items.
+	Done bool `json:"done"`
+}
+
+// Paginate creates an iterator for any paginated endpoint, which is useful
+// for endpoints that do not have a function in this package yet. The path
+// is relative to the api root and the options are sent with the first
+// request. Following pages use the links given by canvas.
+func Paginate[T any](c *Canvas, path string, opts ...Option) *Iterator[T] {
+	return newIterator[T](c.client, path, opts)
+}
+
+func newIterator[T any](d doer, path string, opts []Option) *Iterator[T] {
+	q := params{"per_page": {strconv.Itoa(defaultPerPage)}}
+	q.Add(opts)
+	return &Iterator[T]{d: d, path: path, query: q}
+}
+
+// newPreparedIterator creates an iterator that calls
+// prepare on every item before it is returned.
+func newPreparedIterator[T any](d doer, path string, opts []Option, prepare func(T)) *Iterator[T] {
+	it := newIterator[T](d, path, opts)
+	it.prepare = prepare
+	return it
+}
+
+// Next will move the iterator to the next item and returns false
+// when there are no more items or there was an error.
+func (it *Iterator[T]) Next() bool {
+	for it.err == nil {
+		if it.dec != nil {
+			if it.skip > 0 && it.dec.More() {
+				var raw json.RawMessage
+				if it.err = it.dec.Decode(&raw); it.err != nil {
+					break
+				}
+				it.skip--
+				continue
+			}
+			if it.dec.More() {
+				var v T
+				if it.err = it.dec.Decode(&v); it.err != nil {
+					break
+				}
+				it.pos.Offset++
+				if sc, ok := any(v).(interface{ setclient(doer) }); ok {
+					sc.setclient(it.d)
+				}
+				if it.prepare != nil {
+					it.prepare(v)
+				}
+				it.val = v
+				return true
+			}
+			if it.err = it.dec.end(); it.err == nil {
+				it.endPage()
+			}
+			it.closeBody()
+			continue
+		}
+		if it.started && it.next == nil {
+			break
+		}
+		it.err = it.fetch()
+	}
+	it.closeBody()
+	return false
+}
+
+// endPage moves the checkpoint to the start of the next page.
+func (it *Iterator[T]) endPage() {
+	it.skip = 0
+	if it.next == nil {
+		it.pos = Checkpoint{Done: true}
+	} else {
+		it.pos = Checkpoint{URL: it.next.String()}
+	}
+}
+
+// Checkpoint returns the position of the iterator. Every item returned
+// by Next so far is counted as read, so resuming from the checkpoint
+// continues with the item after the current one. Errors do not move the
+// checkpoint so the page that failed is requested again when resuming.
+// The offset within a page is only exact if the page has not changed
+// since the checkpoint was taken, which holds for logs like page views
+// that only grow at the end.
+func (it *Iterator[T]) Checkpoint() Checkpoint {
+	return it.pos
+}
+
+// ResumeFrom sets the position of the iterator to a checkpoint from an
+// earlier run. It must be called before the first call to Next and the
+// iterator should be for the same listing the checkpoint was taken from.
+//
+//	it := canvas.Paginate[*canvas.User](c, "/accounts/1/users")
+//	if err := it.ResumeFrom(saved); err != nil {
+//		return err
+//	}
+//	for it.Next() {
+//		// ...
+//		saved = it.Checkpoint()
+//	}
+func (it *Iterator[T]) ResumeFrom(cp Checkpoint) error {
+	if it.started {
+		return errors.New("cannot resume an iterator that has already started")
+	}
+	if cp.Offset < 0 {
+		return errors.New("checkpoint has a negative offset")
+	}
+	var next *url.URL
+	if cp.URL != "" {
+		u, err := url.Parse(cp.URL)
+		if err != nil {
+			return err
+		}
+		next = u
+	}
+	it.pos = cp
+	switch {
+	case cp.Done:
+		it.started = true
+	case next != nil:
+		it.started, it.next, it.skip = true, next, cp.Offset
+	default:
+		it.skip = cp.Offset
+	}
+	return nil
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.val
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close will release the current page. It only needs to be called
+// if the iterator is abandoned before Next returns false.
+func (it *Iterator[T]) Close() error {
+	it.closeBody()
+	return nil
+}
+
+func (it *Iterator[T]) closeBody() {
+	if it.body != nil {
+		it.body.Close()
+		it.body = nil
+	}
+	it.dec = nil
+}
+
+func (it *Iterator[T]) fetch() error {
+	var req *http.Request
+	if it.started {
+		req = newreq("GET", "", nil)
+		req.URL = it.next
+	} else {
+		req = newreq("GET", it.path, it.query)
+	}
+	it.started = true
+	it.page++
+	// wait for the rate limit quota the same way paginated listings do
+	if c, ok := unwrapDoer(it.d).(*client); ok {
+		if err := c.waitForQuota(req.Context(), it.path, it.page); err != nil {
+			return err
+		}
+	}
+	resp, err := do(it.d, req)
+	if err != nil {
+		return err
+	}
+	it.next, err = nextLink(resp.Header)
+	if err != nil {
+		resp.Body.Close()
+		return err
+	}
+	it.body = resp.Body
+	it.dec, err = newArrayDecoder(resp.Body)
+	return err
+}
+
+// nextLink returns the rel="next" link or nil if
+// there are no more pages.
+func nextLink(header http.Header) (*url.URL, error) {
+	for _, part := range resourceRegex.FindAllStringSubmatch(header.Get("Link"), -1) {
+		if part[2] == "next" {
+			return url.Parse(part[1])
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,110 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPaginate(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	pages := 3
+	mux.HandleFunc("/api/v1/courses/1/custom", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("per_page") != "2" {
+			t.Error("options should be sent with every page")
+		}
+		// bookmark style pages with no "last" link
+		page := 1
+		fmt.Sscanf(q.Get("page"), "bookmark:%d", &page)
+		if page < pages {
+			w.Header().Set("Link", fmt.Sprintf(
+				`<https://%s/api/v1/courses/1/custom?page=bookmark:%d&per_page=2>; rel="next"`,
+				DefaultHost, page+1))
+		}
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, page*10, page*10+1)
+	})
+	c := &Canvas{client: client}
+
+	it := Paginate[*User](c, "/courses/1/custom", Opt("per_page", 2))
+	ids := []int{}
+	for it.Next() {
+		is.True(it.Value().client != nil)
+		ids = append(ids, it.Value().ID)
+	}
+	is.NoErr(it.Err())
+	is.Equal(ids, []int{10, 11, 20, 21, 30, 31})
+	is.True(!it.Next())
+
+	mux.HandleFunc("/api/v1/broken", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"not":"an array"}`)
+	})
+	it = Paginate[*User](c, "/broken")
+	is.True(!it.Next())
+	is.True(it.Err() != nil)
+	is.NoErr(it.Close())
+}
+
+func TestIteratorCheckpoint(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var requests []string
+	mux.HandleFunc("/api/v1/page_views", func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "bookmark:%d", &page)
+		requests = append(requests, r.URL.Query().Get("page"))
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(
+				`<https://%s/api/v1/page_views?page=bookmark:%d&per_page=2>; rel="next"`,
+				DefaultHost, page+1))
+		}
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, page*10, page*10+1)
+	})
+	c := &Canvas{client: client}
+	collect := func(it *Iterator[*User]) []int {
+		ids := []int{}
+		for it.Next() {
+			ids = append(ids, it.Value().ID)
+		}
+		is.NoErr(it.Err())
+		return ids
+	}
+
+	it := Paginate[*User](c, "/page_views")
+	is.Equal(it.Checkpoint(), Checkpoint{})
+	is.True(it.Next())
+	is.Equal(it.Checkpoint(), Checkpoint{Offset: 1})
+	is.True(it.Next())
+	is.True(it.Next())
+	is.Equal(it.Value().ID, 20)
+	b, err := json.Marshal(it.Checkpoint())
+	is.NoErr(err)
+	is.NoErr(it.Close())
+	is.True(it.ResumeFrom(Checkpoint{}) != nil)
+
+	var cp Checkpoint
+	is.NoErr(json.Unmarshal(b, &cp))
+	is.Equal(cp.Offset, 1)
+	is.True(strings.Contains(cp.URL, "page=bookmark:2"))
+	requests = nil
+	it = Paginate[*User](c, "/page_views")
+	is.NoErr(it.ResumeFrom(cp))
+	is.Equal(collect(it), []int{21, 30, 31})
+	is.Equal(requests, []string{"bookmark:2", "bookmark:3"})
+	is.Equal(it.Checkpoint(), Checkpoint{Done: true})
+
+	it = Paginate[*User](c, "/page_views")
+	is.NoErr(it.ResumeFrom(Checkpoint{Done: true}))
+	is.Equal(collect(it), []int{})
+
+	it = Paginate[*User](c, "/page_views")
+	is.NoErr(it.ResumeFrom(Checkpoint{Offset: 1}))
+	is.Equal(collect(it), []int{11, 20, 21, 30, 31})
+}
@@ -0,0 +1,88 @@
+package canvas
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// SessionLink will create a link that logs the current user into the
+// canvas web interface and then sends them to returnTo. This is useful
+// for sending users from another site directly to content in canvas
+// without making them log in again. The link can only be used once and
+// expires quickly.
+//
+// https://canvas.instructure.com/doc/api/logins.html#method.login/otp.session_token
+func (c *Canvas) SessionLink(returnTo string) (string, error) {
+	var res struct {
+		SessionURL string `json:"session_url"`
+	}
+	err := getjson(c.client, &res, params{"return_to": {returnTo}}, "/login/session_token")
+	return res.SessionURL, err
+}
+
+// SessionLink will create a link that logs the
+// current user in and then sends them to returnTo.
+func SessionLink(returnTo string) (string, error) {
+	return ca.SessionLink(returnTo)
+}
+
+// Link returns the url of the course's home page.
+func (c *Course) Link() string {
+	return webURL(c.client, c.id("/courses/%d"), nil)
+}
+
+// Link returns the url of the assignment in the canvas web interface.
+func (a *Assignment) Link() string {
+	if a.HTMLURL != "" {
+		return a.HTMLURL
+	}
+	return webURL(a.client, a.path(""), nil)
+}
+
+// Link returns the url of the page in the canvas web interface.
+func (p *Page) Link() string {
+	return webURL(p.client, p.path(""), nil)
+}
+
+// Link returns the url of the discussion topic
+// in the canvas web interface.
+func (d *DiscussionTopic) Link() string {
+	if d.HTMLURL != "" {
+		return d.HTMLURL
+	}
+	return webURL(d.client, d.path(""), nil)
+}
+
+// Link returns the url of the file's preview page. The link includes the
+// file's verifier when it has one so that users who can see the content
+// the file is linked from can also see the file.
+func (f *File) Link() string {
+	var q url.Values
+	if v := f.Verifier(); v != "" {
+		q = url.Values{"verifier": {v}}
+	}
+	return webURL(f.client, fmt.Sprintf("/files/%d", f.ID), q)
+}
+
+// Verifier returns the verifier token from the file's download url or
+// an empty string if it does not have one.
+func (f *File) Verifier() string {
+	u, err := url.Parse(f.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("verifier")
+}
+
+// webURL returns the url for a path in the canvas web interface
+// on the host that d sends requests to.
+func webURL(d doer, path string, q url.Values) string {
+	u := url.URL{Scheme: "https", Host: DefaultHost, Path: path}
+	if c, ok := unwrapDoer(d).(*client); ok && c.host != "" {
+		u.Host = c.host
+	}
+	if q != nil {
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,39 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWebLinks(t *testing.T) {
+	is := is.New(t)
+	cli := &client{host: "school.instructure.com"}
+	c := &Course{ID: 1, client: cli}
+	is.Equal(c.Link(), "https://school.instructure.com/courses/1")
+	a := &Assignment{ID: 2, CourseID: 1, client: cli}
+	is.Equal(a.Link(), "https://school.instructure.com/courses/1/assignments/2")
+	p := &Page{URL: "syllabus-notes", context: "/groups/3", client: cli}
+	is.Equal(p.Link(), "https://school.instructure.com/groups/3/pages/syllabus-notes")
+	d := &DiscussionTopic{ID: 4, context: "/courses/1", client: cli}
+	is.Equal(d.Link(), "https://school.instructure.com/courses/1/discussion_topics/4")
+	f := &File{ID: 5, URL: "https://school.instructure.com/files/5/download?download_frd=1&verifier=abc", client: cli}
+	is.Equal(f.Verifier(), "abc")
+	is.Equal(f.Link(), "https://school.instructure.com/files/5?verifier=abc")
+	f = &File{ID: 6}
+	is.Equal(f.Link(), "https://"+DefaultHost+"/files/6")
+
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/login/session_token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("return_to") != c.Link() {
+			t.Error("wrong return_to")
+		}
+		fmt.Fprint(w, `{"session_url":"https://school.instructure.com/login/session_token?session_token=xyz"}`)
+	})
+	link, err := (&Canvas{client: client}).SessionLink(c.Link())
+	is.NoErr(err)
+	is.Equal(link, "https://school.instructure.com/login/session_token?session_token=xyz")
+}
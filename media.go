@@ -0,0 +1,180 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+)
+
+// Media comment types.
+const (
+	MediaAudio = "audio"
+	MediaVideo = "video"
+)
+
+// KalturaConfig is the configuration of the media service
+// that canvas uses to store audio and video.
+type KalturaConfig struct {
+	Enabled        bool   `json:"enabled"`
+	Domain         string `json:"domain"`
+	ResourceDomain string `json:"resource_domain"`
+	RTMPDomain     string `json:"rtmp_domain"`
+	PartnerID      string `json:"partner_id"`
+}
+
+// KalturaConfig will get the configuration of the canvas media service.
+//
+// https://canvas.instructure.com/doc/api/services.html#method.services_api.show_kaltura_config
+func (c *Canvas) KalturaConfig() (*KalturaConfig, error) {
+	conf := &KalturaConfig{}
+	return conf, getjson(c.client, conf, nil, "/services/kaltura")
+}
+
+// KalturaSession is a session that is used to upload audio
+// and video recordings to the canvas media service.
+type KalturaSession struct {
+	KS           string `json:"ks"`
+	SubPartnerID string `json:"subp_id"`
+	PartnerID    string `json:"partner_id"`
+	UID          string `json:"uid"`
+	ServerTime   int64  `json:"serverTime"`
+}
+
+// KalturaSession will start a media recording session. The session is
+// used to upload a recording to the media service, and the entry id
+// that the media service returns is then given to CreateMediaObject.
+//
+//	sess, err := c.KalturaSession()
+//	// upload the recording to the media service using sess.KS
+//	obj, err := c.CreateMediaObject(entryID, canvas.MediaVideo, "course_1")
+//	sub, err := assignment.MediaComment(userID, obj.MediaID, obj.MediaType)
+//
+// https://canvas.instructure.com/doc/api/services.html#method.services_api.start_kaltura_session
+func (c *Canvas) KalturaSession() (*KalturaSession, error) {
+	resp, err := post(c.client, "/services/kaltura_session", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	sess := &KalturaSession{}
+	return sess, decodeJSON(resp.Body, sess)
+}
+
+// MediaObject is an audio or video recording.
+type MediaObject struct {
+	MediaID          string        `json:"media_id"`
+	MediaType        string        `json:"media_type"`
+	Title            string        `json:"title"`
+	UserEnteredTitle string        `json:"user_entered_title"`
+	CanAddCaptions   bool          `json:"can_add_captions"`
+	MediaSources     []MediaSource `json:"media_sources"`
+	MediaTracks      []MediaTrack  `json:"media_tracks"`
+}
+
+// MediaSource is one of the formats that a media object can be played in.
+type MediaSource struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	FileExt     string `json:"fileExt"`
+	Bitrate     string `json:"bitrate"`
+	Height      string `json:"height"`
+	Width       string `json:"width"`
+}
+
+// MediaTrack is a caption or subtitle track for a media object.
+type MediaTrack struct {
+	ID        int    `json:"id"`
+	Kind      string `json:"kind"`
+	Locale    string `json:"locale"`
+	URL       string `json:"url"`
+	Content   string `json:"content"`
+	MediaID   string `json:"media_object_id"`
+	Inherited bool   `json:"inherited"`
+}
+
+// CreateMediaObject will tell canvas about a recording that has been
+// uploaded to the media service. The mediaType is either MediaAudio or
+// MediaVideo and the context code is the course or user that owns the
+// recording, like "course_1" or "user_2".
+func (c *Canvas) CreateMediaObject(entryID, mediaType, contextCode string, opts ...Option) (*MediaObject, error) {
+	q := params{
+		"id":           {entryID},
+		"type":         {mediaType},
+		"context_code": {contextCode},
+	}
+	q.Add(opts)
+	resp, err := post(c.client, "/media_objects", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	obj := &MediaObject{}
+	if err = decodeJSON(resp.Body, obj); err != nil {
+		return nil, err
+	}
+	if obj.MediaID == "" {
+		obj.MediaID = entryID
+	}
+	if obj.MediaType == "" {
+		obj.MediaType = mediaType
+	}
+	return obj, nil
+}
+
+// MediaObjects will list the course's audio and video recordings.
+//
+// https://canvas.instructure.com/doc/api/media_objects.html#method.media_objects.index
+func (c *Course) MediaObjects(opts ...Option) ([]*MediaObject, error) {
+	return listMediaObjects(c.client, c.id("/courses/%d/media_objects"), opts)
+}
+
+// MediaObjects will list the current user's audio and video recordings.
+//
+// https://canvas.instructure.com/doc/api/media_objects.html#method.media_objects.index
+func (c *Canvas) MediaObjects(opts ...Option) ([]*MediaObject, error) {
+	return listMediaObjects(c.client, "/media_objects", opts)
+}
+
+// MediaComment will add an audio or video comment to a user's
+// submission. The mediaID is the MediaID of a MediaObject.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.update
+func (a *Assignment) MediaComment(userID int, mediaID, mediaType string, opts ...Option) (*Submission, error) {
+	q := params{
+		"comment[media_comment_id]":   {mediaID},
+		"comment[media_comment_type]": {mediaType},
+	}
+	q.Add(opts)
+	resp, err := put(a.client, fmt.Sprintf(a.path("/submissions/%d"), userID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	sub := &Submission{}
+	return sub, decodeJSON(resp.Body, sub)
+}
+
+func listMediaObjects(d doer, path string, opts []Option) (objects []*MediaObject, err error) {
+	ch := make(chan *MediaObject)
+	errs := newPaginatedList(d, path, func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			obj := &MediaObject{}
+			if err := dec.Decode(obj); err != nil {
+				return err
+			}
+			ch <- obj
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case obj := <-ch:
+			objects = append(objects, obj)
+		case err, ok := <-errs:
+			if !ok {
+				return objects, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMediaComments(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: client}
+	mux.HandleFunc("/api/v1/services/kaltura", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"enabled":true,"domain":"kaltura.example.com","partner_id":"100"}`)
+	})
+	mux.HandleFunc("/api/v1/services/kaltura_session", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		fmt.Fprint(w, `{"ks":"secret","subp_id":"10000","partner_id":"100","uid":"1234_567","serverTime":1600000000}`)
+	})
+	mux.HandleFunc("/api/v1/media_objects", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("id") != "0_abc" || q.Get("type") != MediaVideo || q.Get("context_code") != "course_1" {
+			t.Errorf("wrong media object: %v", q)
+		}
+		fmt.Fprint(w, `{"media_id":"0_abc","title":"feedback"}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/media_objects", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/media_objects?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"media_id":"0_abc","media_type":"video","media_sources":[{"url":"https://example.com/v.mp4","content_type":"video/mp4"}]}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/submissions/3", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PUT")
+		q := r.URL.Query()
+		if q.Get("comment[media_comment_id]") != "0_abc" || q.Get("comment[media_comment_type]") != MediaVideo {
+			t.Errorf("wrong media comment: %v", q)
+		}
+		fmt.Fprint(w, `{"user_id":3,"assignment_id":2}`)
+	})
+
+	conf, err := c.KalturaConfig()
+	is.NoErr(err)
+	is.True(conf.Enabled)
+	sess, err := c.KalturaSession()
+	is.NoErr(err)
+	is.Equal(sess.KS, "secret")
+	is.Equal(sess.ServerTime, int64(1600000000))
+
+	obj, err := c.CreateMediaObject("0_abc", MediaVideo, "course_1")
+	is.NoErr(err)
+	is.Equal(obj.MediaType, MediaVideo) // filled in when canvas leaves it out
+
+	course := &Course{ID: 1, client: client}
+	objects, err := course.MediaObjects()
+	is.NoErr(err)
+	is.Equal(len(objects), 1)
+	is.Equal(objects[0].MediaSources[0].ContentType, "video/mp4")
+
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	sub, err := a.MediaComment(3, obj.MediaID, obj.MediaType)
+	is.NoErr(err)
+	is.Equal(sub.UserID, 3)
+}
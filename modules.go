@@ -0,0 +1,400 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Module is a course module.
+type Module struct {
+	ID                        int       `json:"id" url:"-"`
+	Name                      string    `json:"name" url:"name,omitempty"`
+	Position                  int       `json:"position" url:"position,omitempty"`
+	UnlockAt                  time.Time `json:"unlock_at" url:"unlock_at,omitempty"`
+	RequireSequentialProgress bool      `json:"require_sequential_progress" url:"require_sequential_progress,omitempty"`
+	PrerequisiteModuleIDs     []int     `json:"prerequisite_module_ids" url:"prerequisite_module_ids,brackets,omitempty"`
+	PublishFinalGrade         bool      `json:"publish_final_grade" url:"publish_final_grade,omitempty"`
+	Published                 bool      `json:"published" url:"published,omitempty"`
+
+	WorkflowState string `json:"workflow_state" url:"-"`
+	ItemsCount    int    `json:"items_count" url:"-"`
+	ItemsURL      string `json:"items_url" url:"-"`
+	// IncludedItems is only set when the modules are fetched with
+	// IncludeOpt("items") and the module is not too big, otherwise
+	// use Items.
+	IncludedItems []*ModuleItem `json:"items" url:"-"`
+	// State and CompletedAt are only set when
+	// the modules are fetched for a student.
+	State       string    `json:"state" url:"-"`
+	CompletedAt time.Time `json:"completed_at" url:"-"`
+
+	courseID int
+	client   doer
+}
+
+// Workflow returns the module's workflow state. When the state
+// was not sent it is found using Module.Published.
+func (m *Module) Workflow() WorkflowState {
+	return workflow(m.WorkflowState, m.Published, WorkflowActive)
+}
+
+func (m *Module) setclient(d doer) {
+	m.client = d
+	for _, item := range m.IncludedItems {
+		item.courseID = m.courseID
+		item.client = d
+	}
+}
+
+// Module item types.
+const (
+	ModuleItemFile         = "File"
+	ModuleItemPage         = "Page"
+	ModuleItemDiscussion   = "Discussion"
+	ModuleItemAssignment   = "Assignment"
+	ModuleItemQuiz         = "Quiz"
+	ModuleItemSubHeader    = "SubHeader"
+	ModuleItemExternalURL  = "ExternalUrl"
+	ModuleItemExternalTool = "ExternalTool"
+)
+
+// Module item completion requirement types.
+const (
+	CompletionMustView       = "must_view"
+	CompletionMustSubmit     = "must_submit"
+	CompletionMustContribute = "must_contribute"
+	CompletionMinScore       = "min_score"
+	CompletionMustMarkDone   = "must_mark_done"
+)
+
+// ModuleItem is one item in a module.
+type ModuleItem struct {
+	ID       int    `json:"id" url:"-"`
+	ModuleID int    `json:"module_id" url:"-"`
+	Title    string `json:"title" url:"title,omitempty"`
+	// Type is one of the module item types like ModuleItemPage.
+	Type      string `json:"type" url:"type,omitempty"`
+	ContentID int    `json:"content_id" url:"content_id,omitempty"`
+	Position  int    `json:"position" url:"position,omitempty"`
+	Indent    int    `json:"indent" url:"indent,omitempty"`
+	// PageURL is the page's url slug, only used for pages.
+	PageURL     string `json:"page_url" url:"page_url,omitempty"`
+	ExternalURL string `json:"external_url" url:"external_url,omitempty"`
+	NewTab      bool   `json:"new_tab" url:"new_tab,omitempty"`
+	Published   bool   `json:"published" url:"published,omitempty"`
+
+	CompletionRequirement *CompletionRequirement `json:"completion_requirement" url:"completion_requirement,omitempty"`
+
+	HTMLURL        string `json:"html_url" url:"-"`
+	URL            string `json:"url" url:"-"`
+	ContentDetails *struct {
+		PointsPossible  float64   `json:"points_possible"`
+		DueAt           time.Time `json:"due_at"`
+		UnlockAt        time.Time `json:"unlock_at"`
+		LockAt          time.Time `json:"lock_at"`
+		LockedForUser   bool      `json:"locked_for_user"`
+		LockExplanation string    `json:"lock_explanation"`
+	} `json:"content_details" url:"-"`
+
+	courseID int
+	client   doer
+}
+
+// CompletionRequirement is what a student needs to do
+// to complete a module item.
+type CompletionRequirement struct {
+	// Type is one of the completion types like CompletionMustView.
+	Type     string  `json:"type" url:"type,omitempty"`
+	MinScore float64 `json:"min_score" url:"min_score,omitempty"`
+	// Completed is only set for students.
+	Completed bool `json:"completed" url:"-"`
+}
+
+// Modules will get the course's modules.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.index
+func (c *Course) Modules(opts ...Option) <-chan *Module {
+	ch := make(moduleChan)
+	pager := c.modulespager(ch, opts)
+	go handleErrs(pager, ch, c.errorHandler)
+	return ch
+}
+
+// ListModules will get the course's modules and put them in a slice.
+func (c *Course) ListModules(opts ...Option) (modules []*Module, err error) {
+	ch := make(moduleChan)
+	errs := c.modulespager(ch, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case m := <-ch:
+			modules = append(modules, m)
+		case err, ok := <-errs:
+			if !ok {
+				return modules, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// Module will get a module given its id.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.show
+func (c *Course) Module(id int, opts ...Option) (*Module, error) {
+	m := &Module{courseID: c.ID}
+	if err := getjson(c.client, m, optEnc(opts), "/courses/%d/modules/%d", c.ID, id); err != nil {
+		return nil, err
+	}
+	m.setclient(c.client)
+	return m, nil
+}
+
+type moduleOptions struct {
+	Module `url:"module"`
+}
+
+// CreateModule will create a module in the course.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.create
+func (c *Course) CreateModule(m Module) (*Module, error) {
+	return c.sendModule("POST", c.id("/courses/%d/modules"), &m)
+}
+
+// EditModule will update the module given and
+// returns the module as it is on canvas.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.update
+func (c *Course) EditModule(m *Module) (*Module, error) {
+	return c.sendModule("PUT", fmt.Sprintf("/courses/%d/modules/%d", c.ID, m.ID), m)
+}
+
+// DeleteModule will delete a module.
+func (c *Course) DeleteModule(m *Module) (*Module, error) {
+	return c.DeleteModuleByID(m.ID)
+}
+
+// DeleteModuleByID will delete a module given its id.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_modules_api.destroy
+func (c *Course) DeleteModuleByID(id int) (*Module, error) {
+	resp, err := delete(c.client, fmt.Sprintf("/courses/%d/modules/%d", c.ID, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	m := &Module{courseID: c.ID, client: c.client}
+	return m, decodeJSON(resp.Body, m)
+}
+
+func (c *Course) sendModule(method, path string, m *Module) (*Module, error) {
+	q, err := query.Values(&moduleOptions{*m})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(c.client, newreq(method, path, q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	mod := &Module{courseID: c.ID}
+	if err = decodeJSON(resp.Body, mod); err != nil {
+		return nil, err
+	}
+	mod.setclient(c.client)
+	return mod, nil
+}
+
+// Items will get the module's items.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.index
+func (m *Module) Items(opts ...Option) <-chan *ModuleItem {
+	ch := make(moduleItemChan)
+	pager := m.itemspager(ch, opts)
+	go handleErrs(pager, ch, ConcurrentErrorHandler)
+	return ch
+}
+
+// ListItems will get the module's items and put them in a slice.
+func (m *Module) ListItems(opts ...Option) (items []*ModuleItem, err error) {
+	ch := make(moduleItemChan)
+	errs := m.itemspager(ch, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case item := <-ch:
+			items = append(items, item)
+		case err, ok := <-errs:
+			if !ok {
+				return items, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// Item will get one of the module's items given its id.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.show
+func (m *Module) Item(id int, opts ...Option) (*ModuleItem, error) {
+	item := &ModuleItem{courseID: m.courseID, client: m.client}
+	return item, getjson(m.client, item, optEnc(opts), m.path("/items/%d"), id)
+}
+
+type moduleItemOptions struct {
+	ModuleItem `url:"module_item"`
+}
+
+// CreateItem will add an item to the module. Items that are not a
+// SubHeader, ExternalUrl, or Page need a ContentID and pages need a
+// PageURL.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.create
+func (m *Module) CreateItem(item ModuleItem) (*ModuleItem, error) {
+	return m.sendItem("POST", m.path("/items"), &item)
+}
+
+// EditItem will update the item given and returns
+// the item as it is on canvas.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.update
+func (m *Module) EditItem(item *ModuleItem) (*ModuleItem, error) {
+	return m.sendItem("PUT", fmt.Sprintf(m.path("/items/%d"), item.ID), item)
+}
+
+// DeleteItem will remove an item from the module.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.destroy
+func (m *Module) DeleteItem(id int) (*ModuleItem, error) {
+	resp, err := delete(m.client, fmt.Sprintf(m.path("/items/%d"), id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	item := &ModuleItem{courseID: m.courseID, client: m.client}
+	return item, decodeJSON(resp.Body, item)
+}
+
+func (m *Module) sendItem(method, path string, item *ModuleItem) (*ModuleItem, error) {
+	q, err := query.Values(&moduleItemOptions{*item})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(m.client, newreq(method, path, q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	res := &ModuleItem{courseID: m.courseID, client: m.client}
+	return res, decodeJSON(resp.Body, res)
+}
+
+func (m *Module) path(s string) string {
+	return fmt.Sprintf("/courses/%d/modules/%d", m.courseID, m.ID) + s
+}
+
+func (m *Module) itemspager(ch chan *ModuleItem, opts []Option) *paginated {
+	return newPaginatedList(m.client, m.path("/items"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			item := &ModuleItem{courseID: m.courseID, client: m.client}
+			if err := dec.Decode(item); err != nil {
+				return err
+			}
+			ch <- item
+			return nil
+		})
+	}, opts)
+}
+
+// MarkDone will mark the item as done for the current user. It only
+// works for items with a CompletionMustMarkDone requirement.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.mark_as_done
+func (mi *ModuleItem) MarkDone() error {
+	return mi.send("PUT", "/done")
+}
+
+// MarkNotDone will undo MarkDone.
+func (mi *ModuleItem) MarkNotDone() error {
+	return mi.send("DELETE", "/done")
+}
+
+// MarkRead will mark the item as viewed by the current user
+// without the user needing to open it.
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.mark_item_read
+func (mi *ModuleItem) MarkRead() error {
+	return mi.send("POST", "/mark_read")
+}
+
+func (mi *ModuleItem) send(method, s string) error {
+	path := fmt.Sprintf("/courses/%d/modules/%d/items/%d%s", mi.courseID, mi.ModuleID, mi.ID, s)
+	resp, err := do(mi.client, newreq(method, path, nil))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// ModuleItemSequence is the position of an item in
+// the course's modules along with the items around it.
+type ModuleItemSequence struct {
+	Items []struct {
+		Prev    *ModuleItem `json:"prev"`
+		Current *ModuleItem `json:"current"`
+		Next    *ModuleItem `json:"next"`
+	} `json:"items"`
+	Modules []*Module `json:"modules"`
+}
+
+// ModuleItemSequence will find the module items for an asset. The asset
+// type can be "ModuleItem", "File", "Page", "Discussion", "Assignment",
+// "Quiz", or "ExternalTool".
+//
+// https://canvas.instructure.com/doc/api/modules.html#method.context_module_items_api.item_sequence
+func (c *Course) ModuleItemSequence(assetType string, assetID int) (*ModuleItemSequence, error) {
+	seq := &ModuleItemSequence{}
+	q := params{"asset_type": {assetType}}
+	q.Set("asset_id", fmt.Sprint(assetID))
+	if err := getjson(c.client, seq, q, "/courses/%d/module_item_sequence", c.ID); err != nil {
+		return nil, err
+	}
+	for _, m := range seq.Modules {
+		m.courseID = c.ID
+		m.setclient(c.client)
+	}
+	for _, it := range seq.Items {
+		for _, item := range []*ModuleItem{it.Prev, it.Current, it.Next} {
+			if item != nil {
+				item.courseID = c.ID
+				item.client = c.client
+			}
+		}
+	}
+	return seq, nil
+}
+
+func (c *Course) modulespager(ch chan *Module, opts []Option) *paginated {
+	return newPaginatedList(c.client, c.id("/courses/%d/modules"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			m := &Module{courseID: c.ID}
+			if err := dec.Decode(m); err != nil {
+				return err
+			}
+			m.setclient(c.client)
+			ch <- m
+			return nil
+		})
+	}, opts)
+}
+
+type moduleChan chan *Module
+
+func (mc moduleChan) Close() { close(mc) }
+
+type moduleItemChan chan *ModuleItem
+
+func (mic moduleItemChan) Close() { close(mic) }
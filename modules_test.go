@@ -0,0 +1,90 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestModules(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	link := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+	}
+	mux.HandleFunc("/api/v1/courses/1/modules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			link(w, r)
+			fmt.Fprint(w, `[{"id":1,"name":"Week 1","position":1,"items_count":2},{"id":2,"name":"Week 2","position":2}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("module[name]") != "Week 3" || q.Get("module[prerequisite_module_ids][]") != "2" {
+				t.Errorf("wrong module params: %v", q)
+			}
+			fmt.Fprint(w, `{"id":3,"name":"Week 3"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/modules/1/items", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			link(w, r)
+			fmt.Fprint(w, `[
+				{"id":10,"module_id":1,"title":"Intro","type":"Page","page_url":"intro"},
+				{"id":11,"module_id":1,"title":"HW","type":"Assignment","content_id":5,
+				 "completion_requirement":{"type":"must_mark_done"}}
+			]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("module_item[type]") != ModuleItemAssignment ||
+				q.Get("module_item[content_id]") != "6" ||
+				q.Get("module_item[completion_requirement][type]") != CompletionMinScore ||
+				q.Get("module_item[completion_requirement][min_score]") != "7" {
+				t.Errorf("wrong item params: %v", q)
+			}
+			fmt.Fprint(w, `{"id":12,"module_id":1,"type":"Assignment","content_id":6}`)
+		}
+	})
+	var done bool
+	mux.HandleFunc("/api/v1/courses/1/modules/1/items/11/done", func(w http.ResponseWriter, r *http.Request) {
+		done = r.Method == "PUT"
+		fmt.Fprint(w, `{}`)
+	})
+
+	c := &Course{ID: 1, client: client, errorHandler: func(err error) error {
+		t.Error(err)
+		return err
+	}}
+	modules, err := c.ListModules()
+	is.NoErr(err)
+	is.Equal(len(modules), 2)
+	is.Equal(modules[0].Name, "Week 1")
+	var n int
+	for range c.Modules() {
+		n++
+	}
+	is.Equal(n, 2)
+
+	items, err := modules[0].ListItems()
+	is.NoErr(err)
+	is.Equal(len(items), 2)
+	is.Equal(items[0].PageURL, "intro")
+	is.Equal(items[1].CompletionRequirement.Type, CompletionMustMarkDone)
+	is.NoErr(items[1].MarkDone())
+	is.True(done)
+
+	item, err := modules[0].CreateItem(ModuleItem{
+		Type:                  ModuleItemAssignment,
+		ContentID:             6,
+		CompletionRequirement: &CompletionRequirement{Type: CompletionMinScore, MinScore: 7},
+	})
+	is.NoErr(err)
+	is.Equal(item.ID, 12)
+
+	mod, err := c.CreateModule(Module{Name: "Week 3", PrerequisiteModuleIDs: []int{2}})
+	is.NoErr(err)
+	is.Equal(mod.ID, 3)
+}
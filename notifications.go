@@ -0,0 +1,184 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+)
+
+// Communication channel types.
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// Notification frequencies.
+const (
+	FrequencyImmediately = "immediately"
+	FrequencyDaily       = "daily"
+	FrequencyWeekly      = "weekly"
+	FrequencyNever       = "never"
+)
+
+// CommunicationChannel is a way that canvas can reach a user,
+// like an email address or a phone number.
+//
+// https://canvas.instructure.com/doc/api/communication_channels.html
+type CommunicationChannel struct {
+	ID            int    `json:"id"`
+	Address       string `json:"address"`
+	Type          string `json:"type"`
+	Position      int    `json:"position"`
+	UserID        int    `json:"user_id"`
+	WorkflowState string `json:"workflow_state"`
+
+	client doer
+}
+
+// NotificationPreference is how often a user is sent
+// a notification on a communication channel.
+//
+// https://canvas.instructure.com/doc/api/notification_preferences.html
+type NotificationPreference struct {
+	Notification string `json:"notification"`
+	Category     string `json:"category"`
+	Frequency    string `json:"frequency"`
+}
+
+// CommunicationChannels will list the user's communication channels.
+//
+// https://canvas.instructure.com/doc/api/communication_channels.html#method.communication_channels.index
+func (u *User) CommunicationChannels(opts ...Option) (channels []*CommunicationChannel, err error) {
+	ch := make(chan *CommunicationChannel)
+	errs := newPaginatedList(u.client, u.id("/users/%d/communication_channels"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			cc := &CommunicationChannel{client: u.client}
+			if err := dec.Decode(cc); err != nil {
+				return err
+			}
+			ch <- cc
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case cc := <-ch:
+			channels = append(channels, cc)
+		case err, ok := <-errs:
+			if !ok {
+				return channels, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// CreateChannel will add a communication channel for the user. The
+// channelType is one of ChannelEmail, ChannelSMS, or ChannelPush.
+// Admins can give Opt("skip_confirmation", true) so the user does not
+// have to confirm the channel.
+//
+// https://canvas.instructure.com/doc/api/communication_channels.html#method.communication_channels.create
+func (u *User) CreateChannel(address, channelType string, opts ...Option) (*CommunicationChannel, error) {
+	q := params{
+		"communication_channel[address]": {address},
+		"communication_channel[type]":    {channelType},
+	}
+	q.Add(opts)
+	resp, err := post(u.client, u.id("/users/%d/communication_channels"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	cc := &CommunicationChannel{client: u.client}
+	return cc, decodeJSON(resp.Body, cc)
+}
+
+// DeleteChannel will delete one of the user's communication channels.
+//
+// https://canvas.instructure.com/doc/api/communication_channels.html#method.communication_channels.destroy
+func (u *User) DeleteChannel(id int) error {
+	resp, err := delete(u.client, fmt.Sprintf("/users/%d/communication_channels/%d", u.ID, id), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// NotificationPreferences will get the channel's notification preferences.
+//
+// https://canvas.instructure.com/doc/api/notification_preferences.html#method.notification_preferences.index
+func (cc *CommunicationChannel) NotificationPreferences() ([]*NotificationPreference, error) {
+	var res notificationPrefsResp
+	err := getjson(cc.client, &res, nil, "/users/%d/communication_channels/%d/notification_preferences", cc.UserID, cc.ID)
+	return res.Preferences, err
+}
+
+// NotificationPreference will get the channel's
+// preference for one notification.
+//
+// https://canvas.instructure.com/doc/api/notification_preferences.html#method.notification_preferences.show
+func (cc *CommunicationChannel) NotificationPreference(notification string) (*NotificationPreference, error) {
+	var res notificationPrefsResp
+	err := getjson(cc.client, &res, nil, "/users/%d/communication_channels/%d/notification_preferences/%s",
+		cc.UserID, cc.ID, notification)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Preferences) == 0 {
+		return nil, fmt.Errorf("no notification preference for %q", notification)
+	}
+	return res.Preferences[0], nil
+}
+
+// SetNotificationPreference will change how often a notification is
+// sent on the channel. The frequency is one of the Frequency constants.
+// Canvas only lets users change their own preferences.
+//
+// https://canvas.instructure.com/doc/api/notification_preferences.html#method.notification_preferences.update
+func (cc *CommunicationChannel) SetNotificationPreference(notification, frequency string) error {
+	return cc.updatePrefs(cc.prefsPath()+"/"+notification, params{
+		"notification_preferences[frequency]": {frequency},
+	})
+}
+
+// SetNotificationPreferences will change the frequency of many
+// notifications at once. The frequencies are keyed by notification.
+// Canvas only lets users change their own preferences.
+//
+// https://canvas.instructure.com/doc/api/notification_preferences.html#method.notification_preferences.update_all
+func (cc *CommunicationChannel) SetNotificationPreferences(frequencies map[string]string) error {
+	q := params{}
+	for notification, frequency := range frequencies {
+		q.Set(fmt.Sprintf("notification_preferences[%s][frequency]", notification), frequency)
+	}
+	return cc.updatePrefs(cc.prefsPath(), q)
+}
+
+// SetNotificationCategory will change the frequency of every
+// notification in a category, like "due_date" or "announcement".
+//
+// https://canvas.instructure.com/doc/api/notification_preferences.html#method.notification_preferences.update_preferences_by_category
+func (cc *CommunicationChannel) SetNotificationCategory(category, frequency string) error {
+	path := fmt.Sprintf("/users/self/communication_channels/%d/notification_preference_categories/%s", cc.ID, category)
+	return cc.updatePrefs(path, params{
+		"notification_preferences[frequency]": {frequency},
+	})
+}
+
+func (cc *CommunicationChannel) prefsPath() string {
+	return fmt.Sprintf("/users/self/communication_channels/%d/notification_preferences", cc.ID)
+}
+
+func (cc *CommunicationChannel) updatePrefs(path string, q params) error {
+	resp, err := put(cc.client, path, q)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type notificationPrefsResp struct {
+	Preferences []*NotificationPreference `json:"notification_preferences"`
+}
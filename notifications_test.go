@@ -0,0 +1,77 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCommunicationChannels(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/2/communication_channels", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/users/2/communication_channels?page=1>; rel="last"`, DefaultHost))
+			fmt.Fprint(w, `[{"id":3,"address":"jo@example.com","type":"email","user_id":2}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("communication_channel[address]") != "5551234567" || q.Get("communication_channel[type]") != ChannelSMS ||
+				q.Get("skip_confirmation") != "true" {
+				t.Errorf("wrong channel: %v", q)
+			}
+			fmt.Fprint(w, `{"id":4,"address":"5551234567","type":"sms","user_id":2}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/users/2/communication_channels/4", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"id":4}`)
+	})
+	mux.HandleFunc("/api/v1/users/2/communication_channels/3/notification_preferences", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"notification_preferences":[{"notification":"new_announcement","category":"announcement","frequency":"daily"}]}`)
+	})
+	mux.HandleFunc("/api/v1/users/2/communication_channels/3/notification_preferences/new_announcement", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"notification_preferences":[{"notification":"new_announcement","frequency":"daily"}]}`)
+	})
+	var updates []string
+	update := func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PUT")
+		updates = append(updates, r.URL.Path+"?"+r.URL.RawQuery)
+		fmt.Fprint(w, `{"notification_preferences":[]}`)
+	}
+	mux.HandleFunc("/api/v1/users/self/communication_channels/3/notification_preferences", update)
+	mux.HandleFunc("/api/v1/users/self/communication_channels/3/notification_preferences/", update)
+	mux.HandleFunc("/api/v1/users/self/communication_channels/3/notification_preference_categories/", update)
+
+	u := &User{ID: 2, client: client}
+	channels, err := u.CommunicationChannels()
+	is.NoErr(err)
+	is.Equal(len(channels), 1)
+	cc, err := u.CreateChannel("5551234567", ChannelSMS, Opt("skip_confirmation", true))
+	is.NoErr(err)
+	is.Equal(cc.ID, 4)
+	is.NoErr(u.DeleteChannel(cc.ID))
+
+	email := channels[0]
+	prefs, err := email.NotificationPreferences()
+	is.NoErr(err)
+	is.Equal(prefs[0].Frequency, FrequencyDaily)
+	pref, err := email.NotificationPreference("new_announcement")
+	is.NoErr(err)
+	is.Equal(pref.Notification, "new_announcement")
+
+	is.NoErr(email.SetNotificationPreference("new_announcement", FrequencyNever))
+	is.NoErr(email.SetNotificationPreferences(map[string]string{
+		"new_announcement":    FrequencyWeekly,
+		"assignment_due_date": FrequencyImmediately,
+	}))
+	is.NoErr(email.SetNotificationCategory("due_date", FrequencyDaily))
+	is.Equal(updates, []string{
+		"/api/v1/users/self/communication_channels/3/notification_preferences/new_announcement?notification_preferences%5Bfrequency%5D=never",
+		"/api/v1/users/self/communication_channels/3/notification_preferences?notification_preferences%5Bassignment_due_date%5D%5Bfrequency%5D=immediately&notification_preferences%5Bnew_announcement%5D%5Bfrequency%5D=weekly",
+		"/api/v1/users/self/communication_channels/3/notification_preference_categories/due_date?notification_preferences%5Bfrequency%5D=daily",
+	})
+}
@@ -0,0 +1,207 @@
+// Package notify sends alerts about things that happen in canvas, like
+// grade changes or new submissions, to chat, email, or any http endpoint.
+//
+// Canvas Live Events can be delivered to an https endpoint. A Bridge is an
+// http.Handler that accepts those deliveries, picks out the events it
+// cares about, and fans a message out to each of its sinks.
+//
+//	b := &notify.Bridge{
+//		Filter: notify.Names("grade_change", "submission_created"),
+//		Sinks: []notify.Sink{
+//			&notify.Slack{WebhookURL: os.Getenv("SLACK_WEBHOOK")},
+//			&notify.Email{Addr: "smtp.school.edu:587", From: "lms@school.edu", To: []string{"help@school.edu"}},
+//		},
+//	}
+//	http.Handle("/canvas/events", b)
+//
+// Only the canvas raw event format is supported.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/harrybrwn/errs"
+)
+
+// Event is a canvas live event.
+//
+// https://canvas.instructure.com/doc/api/file.data_service_canvas_event_metadata.html
+type Event struct {
+	// Name is the event name, e.g. "grade_change",
+	// "submission_created", or "logged_in".
+	Name          string
+	Time          time.Time
+	UserID        string
+	RootAccountID string
+	// ContextType is "Course", "Account", etc.
+	ContextType string
+	ContextID   string
+	// Metadata and Body are the raw parts of the event.
+	Metadata map[string]interface{}
+	Body     map[string]interface{}
+}
+
+// ParseEvent will decode an event in the canvas raw format.
+func ParseEvent(r io.Reader) (*Event, error) {
+	var raw struct {
+		Metadata map[string]interface{} `json:"metadata"`
+		Body     map[string]interface{} `json:"body"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	if raw.Metadata == nil {
+		return nil, fmt.Errorf("notify: event has no metadata")
+	}
+	e := &Event{
+		Name:          str(raw.Metadata["event_name"]),
+		UserID:        str(raw.Metadata["user_id"]),
+		RootAccountID: str(raw.Metadata["root_account_id"]),
+		ContextType:   str(raw.Metadata["context_type"]),
+		ContextID:     str(raw.Metadata["context_id"]),
+		Metadata:      raw.Metadata,
+		Body:          raw.Body,
+	}
+	if t, ok := raw.Metadata["event_time"].(string); ok {
+		e.Time, _ = time.Parse(time.RFC3339Nano, t)
+	}
+	return e, nil
+}
+
+// Message is what gets sent to a sink.
+type Message struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	Event   *Event `json:"-"`
+}
+
+// DefaultMessage is used to create a message when
+// a bridge has no Format function.
+func DefaultMessage(e *Event) Message {
+	subject := "canvas: " + e.Name
+	text := fmt.Sprintf("%s at %s", e.Name, e.Time.Format(time.RFC1123))
+	if e.UserID != "" {
+		text += " by user " + e.UserID
+	}
+	if e.ContextType != "" {
+		text += fmt.Sprintf(" in %s %s", e.ContextType, e.ContextID)
+	}
+	return Message{Subject: subject, Text: text, Event: e}
+}
+
+// Sink is somewhere that messages can be sent.
+type Sink interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SinkFunc is a function that can be used as a Sink.
+type SinkFunc func(ctx context.Context, msg Message) error
+
+// Send calls the function.
+func (f SinkFunc) Send(ctx context.Context, msg Message) error { return f(ctx, msg) }
+
+// Names returns a filter that allows the events with any of the names given.
+func Names(names ...string) func(*Event) bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return func(e *Event) bool { return set[e.Name] }
+}
+
+// Bridge sends events to sinks.
+type Bridge struct {
+	// Filter decides which events are sent,
+	// all events are sent if it is nil.
+	Filter func(*Event) bool
+	// Format turns an event into a message, DefaultMessage
+	// is used if it is nil.
+	Format func(*Event) Message
+	Sinks  []Sink
+	// ErrorHandler is called with errors from ServeHTTP since they
+	// cannot be returned. Errors are ignored if it is nil.
+	ErrorHandler func(error)
+}
+
+// Notify will send the event to every sink at the same time. Events
+// that are filtered out are ignored. The errors from all of the sinks
+// are returned together.
+func (b *Bridge) Notify(ctx context.Context, e *Event) error {
+	if b.Filter != nil && !b.Filter(e) {
+		return nil
+	}
+	format := b.Format
+	if format == nil {
+		format = DefaultMessage
+	}
+	msg := format(e)
+	if msg.Event == nil {
+		msg.Event = e
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errl []error
+	)
+	for _, s := range b.Sinks {
+		wg.Add(1)
+		go func(s Sink) {
+			defer wg.Done()
+			if err := s.Send(ctx, msg); err != nil {
+				mu.Lock()
+				errl = append(errl, err)
+				mu.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+	return errs.Chain(errl...)
+}
+
+// ServeHTTP accepts live events delivered by canvas. Canvas is always
+// told that the event was accepted once it has been parsed so that
+// failing sinks do not cause the other sinks to get the event twice.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	e, err := ParseEvent(r.Body)
+	if err != nil {
+		b.handleErr(err)
+		http.Error(w, "bad event", http.StatusBadRequest)
+		return
+	}
+	if err = b.Notify(r.Context(), e); err != nil {
+		b.handleErr(err)
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (b *Bridge) handleErr(err error) {
+	if b.ErrorHandler != nil {
+		b.ErrorHandler(err)
+	}
+}
+
+// str converts a metadata value to a string, ids
+// are sometimes sent as numbers.
+func str(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const gradeChange = `{
+	"metadata": {
+		"event_name": "grade_change",
+		"event_time": "2020-09-01T12:30:00.000Z",
+		"user_id": 21,
+		"root_account_id": "1",
+		"context_type": "Course",
+		"context_id": "42"
+	},
+	"body": {"score": 9, "old_score": 7}
+}`
+
+func TestParseEvent(t *testing.T) {
+	e, err := ParseEvent(strings.NewReader(gradeChange))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "grade_change" || e.UserID != "21" || e.ContextID != "42" {
+		t.Errorf("wrong event: %+v", e)
+	}
+	if !e.Time.Equal(time.Date(2020, 9, 1, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("wrong event time: %v", e.Time)
+	}
+	if e.Body["score"] != 9.0 {
+		t.Errorf("wrong body: %v", e.Body)
+	}
+	if _, err = ParseEvent(strings.NewReader(`{"body":{}}`)); err == nil {
+		t.Error("expected an error for an event with no metadata")
+	}
+	if _, err = ParseEvent(strings.NewReader(`[`)); err == nil {
+		t.Error("expected an error for bad json")
+	}
+}
+
+// recorder is a sink that keeps every message.
+type recorder struct {
+	mu   sync.Mutex
+	msgs []Message
+	err  error
+}
+
+func (r *recorder) Send(ctx context.Context, msg Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = append(r.msgs, msg)
+	return r.err
+}
+
+func TestBridge(t *testing.T) {
+	var (
+		a       = &recorder{}
+		failing = &recorder{err: errors.New("sink is down")}
+		handled []error
+	)
+	b := &Bridge{
+		Filter:       Names("grade_change"),
+		Sinks:        []Sink{a, failing},
+		ErrorHandler: func(err error) { handled = append(handled, err) },
+	}
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		b.ServeHTTP(rec, httptest.NewRequest("POST", "/events", strings.NewReader(body)))
+		return rec
+	}
+
+	if rec := post(gradeChange); rec.Code != http.StatusAccepted {
+		t.Errorf("expected 202 even when a sink fails; got %d", rec.Code)
+	}
+	if len(a.msgs) != 1 || len(failing.msgs) != 1 {
+		t.Fatalf("every sink should get the message: %d %d", len(a.msgs), len(failing.msgs))
+	}
+	msg := a.msgs[0]
+	if msg.Subject != "canvas: grade_change" || msg.Event == nil {
+		t.Errorf("wrong message: %+v", msg)
+	}
+	if !strings.Contains(msg.Text, "by user 21") || !strings.Contains(msg.Text, "in Course 42") {
+		t.Errorf("wrong message text: %q", msg.Text)
+	}
+	if len(handled) != 1 || !strings.Contains(handled[0].Error(), "sink is down") {
+		t.Errorf("sink errors should be handled: %v", handled)
+	}
+
+	// filtered out
+	if rec := post(strings.Replace(gradeChange, "grade_change", "logged_in", 1)); rec.Code != http.StatusAccepted {
+		t.Errorf("expected 202; got %d", rec.Code)
+	}
+	if len(a.msgs) != 1 {
+		t.Error("filtered events should not be sent")
+	}
+
+	if rec := post(`not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400; got %d", rec.Code)
+	}
+	rec := httptest.NewRecorder()
+	b.ServeHTTP(rec, httptest.NewRequest("GET", "/events", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "POST" {
+		t.Errorf("expected 405; got %d", rec.Code)
+	}
+}
+
+func TestBridgeFormat(t *testing.T) {
+	r := &recorder{}
+	b := &Bridge{
+		Format: func(e *Event) Message {
+			return Message{Subject: "score changed", Text: e.UserID}
+		},
+		Sinks: []Sink{r, SinkFunc(func(ctx context.Context, msg Message) error {
+			if msg.Event == nil {
+				t.Error("the event should be set when Format leaves it out")
+			}
+			return nil
+		})},
+	}
+	e, err := ParseEvent(strings.NewReader(gradeChange))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Notify(context.Background(), e); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.msgs) != 1 || r.msgs[0].Subject != "score changed" || r.msgs[0].Text != "21" {
+		t.Errorf("wrong messages: %+v", r.msgs)
+	}
+}
@@ -0,0 +1,184 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+)
+
+// Slack sends messages to a slack incoming webhook.
+type Slack struct {
+	WebhookURL string
+	// Client is used to send the messages,
+	// http.DefaultClient is used if it is nil.
+	Client *http.Client
+}
+
+// Send will post the message to slack.
+func (s *Slack) Send(ctx context.Context, msg Message) error {
+	text := msg.Text
+	if msg.Subject != "" {
+		text = "*" + msg.Subject + "*\n" + text
+	}
+	return postJSON(ctx, s.Client, s.WebhookURL, nil, map[string]string{"text": text})
+}
+
+// Webhook posts messages as json to any url. The body has the message
+// subject and text along with the event.
+type Webhook struct {
+	URL string
+	// Header is added to each request, e.g. for authorization.
+	Header http.Header
+	// Client is used to send the messages,
+	// http.DefaultClient is used if it is nil.
+	Client *http.Client
+}
+
+// Send will post the message to the webhook url.
+func (wh *Webhook) Send(ctx context.Context, msg Message) error {
+	body := struct {
+		Message
+		Event *Event `json:"event,omitempty"`
+	}{Message: msg, Event: msg.Event}
+	return postJSON(ctx, wh.Client, wh.URL, wh.Header, body)
+}
+
+// Email sends messages with smtp. STARTTLS is used
+// when the server supports it.
+type Email struct {
+	// Addr is the smtp server's host and port.
+	Addr string
+	// Auth is optional.
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// sendMail is swapped out in tests.
+var sendMail = sendMailContext
+
+// Send will email the message. The connection to the smtp
+// server is closed if the context is cancelled.
+//
+// Messages with a subject that has line breaks are not sent so that
+// the subject cannot be used to add headers to the email.
+func (e *Email) Send(ctx context.Context, msg Message) error {
+	headers := append([]string{e.From, msg.Subject}, e.To...)
+	for _, h := range headers {
+		if strings.ContainsAny(h, "\r\n") {
+			return fmt.Errorf("notify: email header %q has a line break", h)
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", e.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return sendMail(ctx, e.Addr, e.Auth, e.From, e.To, b.Bytes())
+}
+
+// sendMailContext is smtp.SendMail but the connection
+// is closed when the context is cancelled.
+func sendMailContext(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) (err error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+	defer func() {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+	}()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("notify: smtp server does not support AUTH")
+		}
+		if err = c.Auth(a); err != nil {
+			return err
+		}
+	}
+	if err = c.Mail(from); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err = c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func postJSON(ctx context.Context, c *http.Client, url string, header http.Header, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c == nil {
+		c = http.DefaultClient
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notify: %s: %s %s", url, resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
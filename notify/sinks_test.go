@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlack(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("wrong request: %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+	s := &Slack{WebhookURL: srv.URL}
+	err := s.Send(context.Background(), Message{Subject: "grade change", Text: "hw1 was graded"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["text"] != "*grade change*\nhw1 was graded" {
+		t.Errorf("wrong slack text: %q", got["text"])
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var got struct {
+		Subject string `json:"subject"`
+		Text    string `json:"text"`
+		Event   *Event `json:"event"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Error("the webhook header should be sent")
+		}
+		if r.URL.Path == "/down" {
+			http.Error(w, "try again later", http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+	wh := &Webhook{
+		URL:    srv.URL,
+		Header: http.Header{"Authorization": {"Bearer secret"}},
+		Client: srv.Client(),
+	}
+	e := &Event{Name: "submission_created", UserID: "5"}
+	if err := wh.Send(context.Background(), DefaultMessage(e)); err != nil {
+		t.Fatal(err)
+	}
+	if got.Subject != "canvas: submission_created" || got.Event == nil || got.Event.UserID != "5" {
+		t.Errorf("wrong webhook body: %+v", got)
+	}
+
+	wh.URL = srv.URL + "/down"
+	err := wh.Send(context.Background(), DefaultMessage(e))
+	if err == nil || !strings.Contains(err.Error(), "503") || !strings.Contains(err.Error(), "try again later") {
+		t.Errorf("expected the error response; got %v", err)
+	}
+}
+
+func TestEmail(t *testing.T) {
+	defer func(fn func(context.Context, string, smtp.Auth, string, []string, []byte) error) {
+		sendMail = fn
+	}(sendMail)
+	var (
+		sent []byte
+		to   []string
+	)
+	sendMail = func(ctx context.Context, addr string, a smtp.Auth, from string, rcpt []string, msg []byte) error {
+		if addr != "smtp.school.edu:587" || from != "lms@school.edu" {
+			t.Errorf("wrong smtp args: %s %s", addr, from)
+		}
+		to, sent = rcpt, msg
+		return nil
+	}
+	e := &Email{Addr: "smtp.school.edu:587", From: "lms@school.edu", To: []string{"a@school.edu", "b@school.edu"}}
+	err := e.Send(context.Background(), Message{Subject: "grade change", Text: "line one\nline two"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(to) != 2 {
+		t.Errorf("wrong recipients: %v", to)
+	}
+	for _, want := range []string{
+		"To: a@school.edu, b@school.edu\r\n",
+		"Subject: grade change\r\n",
+		"\r\n\r\nline one\r\nline two\r\n",
+	} {
+		if !strings.Contains(string(sent), want) {
+			t.Errorf("email should contain %q:\n%s", want, sent)
+		}
+	}
+
+	sent = nil
+	err = e.Send(context.Background(), Message{Subject: "hi\r\nBcc: everyone@school.edu", Text: "spam"})
+	if err == nil {
+		t.Error("subjects with line breaks should be rejected")
+	}
+	if sent != nil {
+		t.Error("the email should not be sent")
+	}
+}
+
+// fakeSMTP accepts one message and sends it on the channel.
+func fakeSMTP(l net.Listener, msgs chan<- string) {
+	conn, err := l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+	reply("220 fake smtp")
+	var data strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake smtp")
+		case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			for {
+				line, err = r.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			msgs <- data.String()
+			reply("250 ok")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 unknown command")
+		}
+	}
+}
+
+func TestSendMailContext(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	msgs := make(chan string, 1)
+	go fakeSMTP(l, msgs)
+	err = sendMailContext(context.Background(), l.Addr().String(), nil,
+		"lms@school.edu", []string{"help@school.edu"}, []byte("Subject: hi\r\n\r\nhello\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-msgs; !strings.Contains(msg, "hello") {
+		t.Errorf("wrong message: %q", msg)
+	}
+}
+
+func TestSendMailContextCancel(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		// never say hello
+		conn, err := l.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- sendMailContext(ctx, l.Addr().String(), nil, "a@b.c", []string{"d@e.f"}, nil)
+	}()
+	select {
+	case err = <-done:
+		if err != context.DeadlineExceeded {
+			t.Errorf("expected the context error; got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("sending should stop when the context is done")
+	}
+}
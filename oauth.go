@@ -0,0 +1,287 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before a token expires
+// that it is refreshed.
+const tokenExpiryDelta = 30 * time.Second
+
+// OAuthConfig is a canvas developer key used for the OAuth2 web flow.
+// It lets an app act on behalf of many users instead of using one
+// manually generated token.
+//
+//	conf := &canvas.OAuthConfig{
+//		ClientID:     os.Getenv("CANVAS_CLIENT_ID"),
+//		ClientSecret: os.Getenv("CANVAS_CLIENT_SECRET"),
+//		RedirectURL:  "https://app.school.edu/oauth/callback",
+//	}
+//	http.Redirect(w, r, conf.AuthCodeURL(state), http.StatusFound)
+//	// then in the callback handler
+//	tok, err := conf.Exchange(r.Context(), r.URL.Query().Get("code"))
+//	c := conf.Canvas(tok)
+//
+// https://canvas.instructure.com/doc/api/file.oauth.html
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes are only needed when the developer
+	// key enforces scopes.
+	Scopes []string
+	// Host is the canvas host, DefaultHost is used if it is empty.
+	Host string
+	// Client is used to request tokens,
+	// http.DefaultClient is used if it is nil.
+	Client *http.Client
+	// TokenRefreshed is called with the new token every time a
+	// token is refreshed so that it can be saved.
+	TokenRefreshed func(*Token)
+}
+
+// Token is an OAuth2 token given to an app by canvas.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the number of seconds the token was valid
+	// for when it was issued, Expiry is set from it.
+	ExpiresIn int       `json:"expires_in"`
+	Expiry    time.Time `json:"expiry"`
+	User      struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
+// Expired returns true if the token has expired or is about to.
+// Tokens without an expiry never expire.
+func (t *Token) Expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return time.Now().Add(tokenExpiryDelta).After(t.Expiry)
+}
+
+// AuthCodeURL returns the url that users should be sent to so they can
+// authorize the app. The state is sent back to the redirect url and
+// should be checked to prevent CSRF attacks. Options like
+// Opt("force_login", 1) are added to the url.
+//
+// https://canvas.instructure.com/doc/api/file.oauth_endpoints.html#get-login-oauth2-auth
+func (oc *OAuthConfig) AuthCodeURL(state string, opts ...Option) string {
+	q := params{
+		"client_id":     {oc.ClientID},
+		"response_type": {"code"},
+		"redirect_uri":  {oc.RedirectURL},
+		"state":         {state},
+	}
+	if len(oc.Scopes) > 0 {
+		q.Set("scope", strings.Join(oc.Scopes, " "))
+	}
+	q.Add(opts)
+	u := url.URL{Scheme: "https", Host: oc.host(), Path: "/login/oauth2/auth", RawQuery: q.Encode()}
+	return u.String()
+}
+
+// Exchange will trade the code that canvas sent to the redirect
+// url for a token.
+//
+// https://canvas.instructure.com/doc/api/file.oauth_endpoints.html#post-login-oauth2-token
+func (oc *OAuthConfig) Exchange(ctx context.Context, code string) (*Token, error) {
+	return oc.token(ctx, params{
+		"grant_type":   {"authorization_code"},
+		"redirect_uri": {oc.RedirectURL},
+		"code":         {code},
+	}, "")
+}
+
+// Refresh will get a new access token using the token's refresh token.
+// Canvas does not send a new refresh token so the old one is kept.
+func (oc *OAuthConfig) Refresh(ctx context.Context, tok *Token) (*Token, error) {
+	if tok.RefreshToken == "" {
+		return nil, fmt.Errorf("canvas: token has no refresh token")
+	}
+	return oc.token(ctx, params{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {tok.RefreshToken},
+	}, tok.RefreshToken)
+}
+
+// Revoke will delete the token so that it can no longer be used. When
+// expireSessions is true the user is also logged out of canvas.
+//
+// https://canvas.instructure.com/doc/api/file.oauth_endpoints.html#delete-login-oauth2-token
+func (oc *OAuthConfig) Revoke(ctx context.Context, tok *Token, expireSessions bool) error {
+	u := oc.endpoint("/login/oauth2/token")
+	if expireSessions {
+		u.RawQuery = "expire_sessions=1"
+	}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+	resp, err := oc.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return oauthError(resp)
+	}
+	return nil
+}
+
+// Canvas creates a Canvas object that makes requests as the user that
+// the token belongs to. Expired tokens are refreshed before a request is
+// sent, and requests that canvas rejects because the token has expired
+// are sent again once with a new token.
+func (oc *OAuthConfig) Canvas(tok *Token, opts ...ClientOption) *Canvas {
+	base := newClientConfig(opts).baseClient().Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rt := &oauthTransport{rt: base, conf: oc, tok: tok}
+	return WithHost("", oc.host(), append(opts, WithTransport(rt))...)
+}
+
+func (oc *OAuthConfig) token(ctx context.Context, form params, refreshToken string) (*Token, error) {
+	form.Set("client_id", oc.ClientID)
+	form.Set("client_secret", oc.ClientSecret)
+	body := form.Encode()
+	req, err := http.NewRequestWithContext(ctx, "POST", oc.endpoint("/login/oauth2/token").String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := oc.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, oauthError(resp)
+	}
+	tok := &Token{}
+	if err = decodeJSON(resp.Body, tok); err != nil {
+		return nil, err
+	}
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = refreshToken
+	}
+	if tok.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	}
+	return tok, nil
+}
+
+func (oc *OAuthConfig) host() string {
+	if oc.Host == "" {
+		return DefaultHost
+	}
+	return oc.Host
+}
+
+func (oc *OAuthConfig) endpoint(path string) *url.URL {
+	return &url.URL{Scheme: "https", Host: oc.host(), Path: path}
+}
+
+func (oc *OAuthConfig) client() *http.Client {
+	if oc.Client == nil {
+		return http.DefaultClient
+	}
+	return oc.Client
+}
+
+// oauthError decodes the error that canvas sends
+// from the oauth endpoints.
+func oauthError(resp *http.Response) error {
+	var e struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if json.Unmarshal(b, &e) == nil && e.Error != "" {
+		return fmt.Errorf("canvas oauth: %s: %s: %s", resp.Status, e.Error, e.Description)
+	}
+	return fmt.Errorf("canvas oauth: %s: %s", resp.Status, bytes.TrimSpace(b))
+}
+
+// oauthTransport adds the oauth token to requests and refreshes it
+// when it expires.
+type oauthTransport struct {
+	rt   http.RoundTripper
+	conf *OAuthConfig
+
+	mu  sync.Mutex
+	tok *Token
+}
+
+func (ot *oauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok, err := ot.token(req.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+	resp, err := ot.rt.RoundTrip(req)
+	if err != nil || !tokenExpired(resp) || tok.RefreshToken == "" {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// the body cannot be sent again
+		return resp, nil
+	}
+	// canvas says the token has expired before we expected it to
+	if tok, err = ot.token(req.Context(), tok); err != nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+	retry := req.Clone(req.Context())
+	if req.Body != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	retry.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+	return ot.rt.RoundTrip(retry)
+}
+
+// token returns a token that has not expired. When stale is not nil
+// it is refreshed even if it does not look expired, unless another
+// request has already replaced it.
+func (ot *oauthTransport) token(ctx context.Context, stale *Token) (*Token, error) {
+	ot.mu.Lock()
+	defer ot.mu.Unlock()
+	if ot.tok.RefreshToken == "" || (stale == nil && !ot.tok.Expired()) || (stale != nil && stale != ot.tok) {
+		return ot.tok, nil
+	}
+	tok, err := ot.conf.Refresh(ctx, ot.tok)
+	if err != nil {
+		return nil, err
+	}
+	ot.tok = tok
+	if ot.conf.TokenRefreshed != nil {
+		ot.conf.TokenRefreshed(tok)
+	}
+	return tok, nil
+}
+
+// tokenExpired returns true if canvas rejected a request because its
+// access token has expired or was otherwise invalid. Canvas sends a
+// WWW-Authenticate header with these responses but not with responses
+// for users that are not allowed to do something.
+func tokenExpired(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") != ""
+}
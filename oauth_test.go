@@ -0,0 +1,113 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestOAuth(t *testing.T) {
+	is := is.New(t)
+	_, mux, server := testServer()
+	defer server.Close()
+	var refreshes, revokes int32
+	mux.HandleFunc("/login/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			atomic.AddInt32(&revokes, 1)
+			if r.Header.Get("Authorization") != "Bearer access-2" {
+				t.Error("revoke should use the token being revoked")
+			}
+			return
+		}
+		is.NoErr(r.ParseForm())
+		if r.PostForm.Get("client_id") != "id" || r.PostForm.Get("client_secret") != "secret" {
+			t.Error("the developer key should be sent")
+		}
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") != "the-code" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant","error_description":"bad code"}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"access-1","token_type":"Bearer","refresh_token":"refresh","expires_in":3600,"user":{"id":2,"name":"Sheldon"}}`)
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh" {
+				t.Error("wrong refresh token")
+			}
+			atomic.AddInt32(&refreshes, 1)
+			fmt.Fprint(w, `{"access_token":"access-2","token_type":"Bearer","expires_in":3600}`)
+		}
+	})
+	var calls int32
+	mux.HandleFunc("/api/v1/users/self/profile", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="canvas-lms"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"errors":[{"message":"Invalid access token."}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":2}`)
+	})
+	base := &TestingTransport{&http.Transport{
+		Proxy: func(r *http.Request) (*url.URL, error) { return url.Parse(server.URL) },
+	}}
+	var saved *Token
+	conf := &OAuthConfig{
+		ClientID:       "id",
+		ClientSecret:   "secret",
+		RedirectURL:    "https://app.test/callback",
+		Scopes:         []string{"url:GET|/api/v1/users/:id/profile"},
+		Client:         &http.Client{Transport: base},
+		TokenRefreshed: func(tok *Token) { saved = tok },
+	}
+
+	u, err := url.Parse(conf.AuthCodeURL("xyz", Opt("force_login", 1)))
+	is.NoErr(err)
+	is.Equal(u.Host, DefaultHost)
+	is.Equal(u.Path, "/login/oauth2/auth")
+	is.Equal(u.Query().Get("state"), "xyz")
+	is.Equal(u.Query().Get("response_type"), "code")
+	is.Equal(u.Query().Get("force_login"), "1")
+	is.Equal(u.Query().Get("scope"), "url:GET|/api/v1/users/:id/profile")
+
+	_, err = conf.Exchange(context.Background(), "wrong")
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "invalid_grant"))
+	tok, err := conf.Exchange(context.Background(), "the-code")
+	is.NoErr(err)
+	is.Equal(tok.AccessToken, "access-1")
+	is.Equal(tok.User.ID, 2)
+	is.True(!tok.Expired())
+
+	// canvas rejects the token before it expires
+	c := conf.Canvas(tok, WithTransport(base))
+	resp, err := get(c.client, "/users/self/profile", nil)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(atomic.LoadInt32(&calls), int32(2))
+	is.Equal(atomic.LoadInt32(&refreshes), int32(1))
+	is.Equal(saved.AccessToken, "access-2")
+	is.Equal(saved.RefreshToken, "refresh") // the old refresh token is kept
+
+	// expired tokens are refreshed before the request
+	expired := *tok
+	expired.Expiry = time.Now().Add(-time.Minute)
+	c = conf.Canvas(&expired, WithTransport(base))
+	resp, err = get(c.client, "/users/self/profile", nil)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(atomic.LoadInt32(&calls), int32(3))
+	is.Equal(atomic.LoadInt32(&refreshes), int32(2))
+
+	is.NoErr(conf.Revoke(context.Background(), saved, false))
+	is.Equal(atomic.LoadInt32(&revokes), int32(1))
+}
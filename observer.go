@@ -0,0 +1,91 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+)
+
+// ObservedCourses will get the courses that the current user is observing
+// along with the students being observed in each course. Use
+// Course.ObservedUsers to get the students.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.index
+func (c *Canvas) ObservedCourses(opts ...Option) ([]*Course, error) {
+	opts = append(opts, EnrollmentTypeFilter(ObserverEnrollment), CourseIncludes(IncludeObservedUsers))
+	return getCourses(c.client, "/courses", optEnc(opts))
+}
+
+// ObservedCourses will get the courses that the current user is observing.
+func ObservedCourses(opts ...Option) ([]*Course, error) {
+	return ca.ObservedCourses(opts...)
+}
+
+// Observees will get the users being observed by the current user.
+//
+// https://canvas.instructure.com/doc/api/user_observees.html#method.user_observees.index
+func (c *Canvas) Observees(opts ...Option) ([]*User, error) {
+	return collectUsers(c.client, "/users/self/observees", opts)
+}
+
+// Observees will get the users being observed by the current user.
+func Observees(opts ...Option) ([]*User, error) {
+	return ca.Observees(opts...)
+}
+
+// ObservedUserGrades will get the student enrollments, which hold the
+// course grades, for a user being observed by the current user.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (c *Canvas) ObservedUserGrades(observeeID int, opts ...Option) ([]*Enrollment, error) {
+	opts = append(opts, ArrayOpt("type", string(StudentEnrollment)))
+	return collectEnrollments(c.client, fmt.Sprintf("/users/%d/enrollments", observeeID), opts)
+}
+
+// ObservedUserGrades will get the student enrollments for a
+// user being observed by the current user.
+func ObservedUserGrades(observeeID int, opts ...Option) ([]*Enrollment, error) {
+	return ca.ObservedUserGrades(observeeID, opts...)
+}
+
+// ObservedUsers returns the students being observed by the current user
+// in this course. The course must have been fetched using the
+// IncludeObservedUsers preset.
+func (c *Course) ObservedUsers() []*User {
+	users := make([]*User, 0)
+	seen := make(map[int]bool)
+	for _, e := range c.Enrollments {
+		if e.Type != string(ObserverEnrollment) || e.ObservedUser == nil || seen[e.ObservedUser.ID] {
+			continue
+		}
+		seen[e.ObservedUser.ID] = true
+		e.ObservedUser.client = c.client
+		users = append(users, e.ObservedUser)
+	}
+	return users
+}
+
+func collectEnrollments(d doer, path string, opts []Option) (enrollments []*Enrollment, err error) {
+	ch := make(chan *Enrollment)
+	errs := newPaginatedList(d, path, func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			e := &Enrollment{client: d}
+			if err := dec.Decode(e); err != nil {
+				return err
+			}
+			ch <- e
+			return nil
+		})
+	}, opts).start()
+	var errl []error
+	for {
+		select {
+		case e := <-ch:
+			enrollments = append(enrollments, e)
+		case err, ok := <-errs:
+			if !ok {
+				return enrollments, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
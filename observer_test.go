@@ -0,0 +1,50 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestObserver(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: client}
+	link := func(w http.ResponseWriter, path string) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1%s?page=1>; rel="last"`, DefaultHost, path))
+	}
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("enrollment_type") != "observer" || q.Get("include[]") != "observed_users" {
+			t.Error("wrong query parameters")
+		}
+		link(w, "/courses")
+		fmt.Fprint(w, `[{"id":1,"enrollments":[
+			{"type":"ObserverEnrollment","associated_user_id":7,"observed_user":{"id":7,"name":"Kid One"}},
+			{"type":"ObserverEnrollment","associated_user_id":8,"observed_user":{"id":8,"name":"Kid Two"}},
+			{"type":"ObserverEnrollment","associated_user_id":7,"observed_user":{"id":7,"name":"Kid One"}}
+		]}]`)
+	})
+	mux.HandleFunc("/api/v1/users/7/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type[]") != "StudentEnrollment" {
+			t.Error("should only get student enrollments")
+		}
+		link(w, "/users/7/enrollments")
+		fmt.Fprint(w, `[{"course_id":1,"user_id":7,"type":"StudentEnrollment","grades":{"current_score":91.5}}]`)
+	})
+
+	courses, err := c.ObservedCourses()
+	is.NoErr(err)
+	is.Equal(len(courses), 1)
+	users := courses[0].ObservedUsers()
+	is.Equal(len(users), 2)
+	is.Equal(users[0].Name, "Kid One")
+
+	grades, err := c.ObservedUserGrades(users[0].ID)
+	is.NoErr(err)
+	is.Equal(len(grades), 1)
+	is.Equal(grades[0].Grades.CurrentScore, 91.5)
+}
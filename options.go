@@ -10,18 +10,50 @@ import (
 // Course options are given when requesting courses in order to
 // filter out certain courses that may not be wanted in the query.
 var (
-	CompletedCourses        Option = Opt("enrollment_state", "completed")
-	ActiveCourses           Option = Opt("enrollment_state", "active")
-	InvitedOrPendingCourses Option = Opt("enrollment_state", "invited_or_pending")
+	CompletedCourses        Option = EnrollmentStateFilter(EnrollmentCompleted)
+	ActiveCourses           Option = EnrollmentStateFilter(EnrollmentActive)
+	InvitedOrPendingCourses Option = EnrollmentStateFilter(EnrollmentInvitedOrPending)
 )
 
+// CourseStateFilter is an Option that filters a course listing by the
+// courses' workflow states. Valid states are WorkflowUnpublished,
+// WorkflowAvailable, WorkflowCompleted, and WorkflowDeleted.
+func CourseStateFilter(states ...WorkflowState) Option {
+	vals := make([]string, len(states))
+	for i, s := range states {
+		vals[i] = string(s)
+	}
+	return ArrayOpt("state", vals...)
+}
+
+// Announcement options are given to Canvas.Announcements. By default only
+// the announcements from the last two weeks are listed.
+var (
+	// ActiveAnnouncements only lists announcements that are published.
+	ActiveAnnouncements Option = Opt("active_only", true)
+	// LatestAnnouncements only lists the newest announcement of each course.
+	LatestAnnouncements Option = Opt("latest_only", true)
+)
+
+// AnnouncementsSince is an Option that only lists the
+// announcements posted on or after the date given.
+func AnnouncementsSince(t time.Time) Option {
+	return DateOpt("start_date", t)
+}
+
+// AnnouncementsUntil is an Option that only lists the
+// announcements posted on or before the date given.
+func AnnouncementsUntil(t time.Time) Option {
+	return DateOpt("end_date", t)
+}
+
 // Enrollment options are given to filter out different types of people
 var (
-	OptTeacher  Option = Opt("enrollment_type", "teacher")
-	OptStudent  Option = Opt("enrollment_type", "student")
-	OptTA       Option = Opt("enrollment_type", "ta")
-	OptObserver Option = Opt("enrollment_type", "observer")
-	OptDesigner Option = Opt("enrollment_type", "designer")
+	OptTeacher  Option = EnrollmentTypeFilter(TeacherEnrollment)
+	OptStudent  Option = EnrollmentTypeFilter(StudentEnrollment)
+	OptTA       Option = EnrollmentTypeFilter(TAEnrollment)
+	OptObserver Option = EnrollmentTypeFilter(ObserverEnrollment)
+	OptDesigner Option = EnrollmentTypeFilter(DesignerEnrollment)
 )
 
 // Option is a key value pair used
@@ -65,6 +97,111 @@ func IncludeOpt(vals ...string) Option {
 	}
 }
 
+// CourseInclude is an "include[]" value that is valid when getting
+// or listing courses. Use CourseIncludes to turn them into an Option.
+type CourseInclude string
+
+// Include presets for course endpoints.
+const (
+	IncludeTerm CourseInclude = "term"
+	// IncludeTotalScores also includes the current grading period scores.
+	IncludeTotalScores       CourseInclude = "total_scores"
+	IncludeSyllabus          CourseInclude = "syllabus_body"
+	IncludeCourseProgress    CourseInclude = "course_progress"
+	IncludeTeachers          CourseInclude = "teachers"
+	IncludeTotalStudents     CourseInclude = "total_students"
+	IncludeNeedsGradingCount CourseInclude = "needs_grading_count"
+	IncludeSections          CourseInclude = "sections"
+	IncludeFavorites         CourseInclude = "favorites"
+	IncludeObservedUsers     CourseInclude = "observed_users"
+)
+
+// CourseIncludes combines course include presets into one Option.
+//
+//	c.Courses(canvas.CourseIncludes(canvas.IncludeTerm, canvas.IncludeSyllabus))
+func CourseIncludes(presets ...CourseInclude) Option {
+	return includes(presets, map[CourseInclude][]string{
+		IncludeTotalScores: {"total_scores", "current_grading_period_scores"},
+	})
+}
+
+// AssignmentInclude is an "include[]" value that is valid when
+// getting or listing assignments. Use AssignmentIncludes to turn
+// them into an Option.
+type AssignmentInclude string
+
+// Include presets for assignment endpoints.
+const (
+	IncludeSubmission AssignmentInclude = "submission"
+	IncludeAllDates   AssignmentInclude = "all_dates"
+	IncludeOverrides  AssignmentInclude = "overrides"
+	// IncludeScoreStatistics also includes the submission
+	// because canvas needs it for the statistics.
+	IncludeScoreStatistics AssignmentInclude = "score_statistics"
+	IncludeCanEdit         AssignmentInclude = "can_edit"
+)
+
+// AssignmentIncludes combines assignment include presets into one Option.
+//
+//	c.Assignments(canvas.AssignmentIncludes(canvas.IncludeSubmission, canvas.IncludeAllDates))
+func AssignmentIncludes(presets ...AssignmentInclude) Option {
+	return includes(presets, map[AssignmentInclude][]string{
+		IncludeScoreStatistics: {"submission", "score_statistics"},
+	})
+}
+
+// OptAllDates is an Option that will include every set of dates for an
+// assignment in Assignment.AllDates.
+var OptAllDates Option = AssignmentIncludes(IncludeAllDates)
+
+// UserInclude is an "include[]" value that is valid when getting
+// or listing users. Use UserIncludes to turn them into an Option.
+type UserInclude string
+
+// Include presets for user endpoints.
+const (
+	IncludeEnrollments UserInclude = "enrollments"
+	IncludeEmail       UserInclude = "email"
+	IncludeAvatarURL   UserInclude = "avatar_url"
+	IncludeLastLogin   UserInclude = "last_login"
+)
+
+// UserIncludes combines user include presets into one Option.
+func UserIncludes(presets ...UserInclude) Option {
+	return includes(presets, nil)
+}
+
+// includes joins include presets into one "include[]" Option, presets
+// that need more than one value are expanded using the expand map.
+func includes[T ~string](presets []T, expand map[T][]string) Option {
+	seen := make(map[string]bool)
+	vals := make([]string, 0, len(presets))
+	for _, p := range presets {
+		group, ok := expand[p]
+		if !ok {
+			group = []string{string(p)}
+		}
+		for _, v := range group {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			vals = append(vals, v)
+		}
+	}
+	return IncludeOpt(vals...)
+}
+
+// ExcludeFields is an Option that leaves fields out of the responses of
+// endpoints that take exclude_response_fields[]. The assignment groups
+// listing can leave out "description" and "rubric" which are the
+// largest fields of the assignments it includes.
+//
+//	c.AssignmentGroups(canvas.IncludeOpt("assignments"), canvas.ExcludeFields("description", "rubric"))
+func ExcludeFields(fields ...string) Option {
+	return ArrayOpt("exclude_response_fields", fields...)
+}
+
 // SortOpt returns a sorting option
 func SortOpt(schemes ...string) Option {
 	return ArrayOpt("sort", schemes...)
@@ -152,6 +289,9 @@ func (oe optEnc) Encode() string {
 	}
 	var buf strings.Builder
 	for _, o := range oe {
+		if o.Name() == "" {
+			continue
+		}
 		if buf.Len() > 0 {
 			buf.WriteByte('&')
 		}
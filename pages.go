@@ -0,0 +1,213 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Page is a wiki page.
+type Page struct {
+	ID    int    `json:"page_id" url:"-"`
+	URL   string `json:"url" url:"-"`
+	Title string `json:"title" url:"title,omitempty"`
+	Body  string `json:"body" url:"body,omitempty"`
+	// EditingRoles is a comma separated list of the roles that can
+	// edit the page, any of "teachers", "students", "members", or
+	// "public".
+	EditingRoles   string    `json:"editing_roles" url:"editing_roles,omitempty"`
+	Published      bool      `json:"published" url:"published,omitempty"`
+	FrontPage      bool      `json:"front_page" url:"front_page,omitempty"`
+	PublishAt      time.Time `json:"publish_at" url:"publish_at,omitempty"`
+	NotifyOfUpdate bool      `json:"-" url:"notify_of_update,omitempty"`
+
+	CreatedAt        time.Time `json:"created_at" url:"-"`
+	UpdatedAt        time.Time `json:"updated_at" url:"-"`
+	HideFromStudents bool      `json:"hide_from_students" url:"-"`
+	LastEditedBy     *User     `json:"last_edited_by" url:"-"`
+	LockedForUser    bool      `json:"locked_for_user" url:"-"`
+	LockInfo         *LockInfo `json:"lock_info" url:"-"`
+	LockExplanation  string    `json:"lock_explanation" url:"-"`
+	TodoDate         time.Time `json:"todo_date" url:"-"`
+
+	// context is the api path of the course or group that
+	// the page belongs to.
+	context string
+	client  doer
+}
+
+// PageRevision is a saved version of a page.
+type PageRevision struct {
+	ID        int       `json:"revision_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Latest    bool      `json:"latest"`
+	EditedBy  *User     `json:"edited_by"`
+	// URL, Title, and Body are only set when
+	// a single revision is fetched.
+	URL   string `json:"url"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Pages will get the course's wiki pages. Page bodies are not
+// included unless IncludeOpt("body") is used.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.index
+func (c *Course) Pages(opts ...Option) ([]*Page, error) {
+	return listPages(c.client, c.id("/courses/%d"), opts)
+}
+
+// Page will get a page given its url or id.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show
+func (c *Course) Page(urlOrID string, opts ...Option) (*Page, error) {
+	return getPage(c.client, c.id("/courses/%d"), urlOrID, opts)
+}
+
+// CreatePage will create a page in the course.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.create
+func (c *Course) CreatePage(p Page) (*Page, error) {
+	return sendPage(c.client, c.id("/courses/%d"), "POST", "/pages", &p)
+}
+
+// EditPage will update a page. The page is found using its url.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.update
+func (c *Course) EditPage(p *Page) (*Page, error) {
+	return sendPage(c.client, c.id("/courses/%d"), "PUT", "/pages/"+p.URL, p)
+}
+
+// DeletePage will delete a page given its url or id.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.destroy
+func (c *Course) DeletePage(urlOrID string) (*Page, error) {
+	return deletePage(c.client, c.id("/courses/%d"), urlOrID)
+}
+
+// Workflow returns the page's workflow state. Pages do not have a
+// workflow_state field so it is found using Page.Published.
+func (p *Page) Workflow() WorkflowState {
+	return workflow("", p.Published, WorkflowActive)
+}
+
+// ListRevisions will get the page's revisions, newest first.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.revisions
+func (p *Page) ListRevisions(opts ...Option) (revs []*PageRevision, err error) {
+	ch := make(chan *PageRevision)
+	errs := newPaginatedList(p.client, p.path("/revisions"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			rev := &PageRevision{}
+			if err := dec.Decode(rev); err != nil {
+				return err
+			}
+			ch <- rev
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case rev := <-ch:
+			revs = append(revs, rev)
+		case err, ok := <-errs:
+			if !ok {
+				return revs, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// Revision will get one of the page's revisions. An id of
+// zero will get the latest revision.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.show_revision
+func (p *Page) Revision(id int, opts ...Option) (*PageRevision, error) {
+	path := p.path("/revisions/latest")
+	if id > 0 {
+		path = p.path(fmt.Sprintf("/revisions/%d", id))
+	}
+	rev := &PageRevision{}
+	return rev, getjson(p.client, rev, optEnc(opts), "%s", path)
+}
+
+// RevertToRevision will change the page back to an older
+// revision. The new revision is returned.
+//
+// https://canvas.instructure.com/doc/api/pages.html#method.wiki_pages_api.revert
+func (p *Page) RevertToRevision(id int) (*PageRevision, error) {
+	resp, err := post(p.client, p.path(fmt.Sprintf("/revisions/%d", id)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	rev := &PageRevision{}
+	return rev, decodeJSON(resp.Body, rev)
+}
+
+func (p *Page) path(s string) string {
+	return p.context + "/pages/" + p.URL + s
+}
+
+type pageOptions struct {
+	Page `url:"wiki_page"`
+}
+
+func listPages(d doer, context string, opts []Option) (pages []*Page, err error) {
+	ch := make(chan *Page)
+	errs := newPaginatedList(d, context+"/pages", func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			p := &Page{context: context, client: d}
+			if err := dec.Decode(p); err != nil {
+				return err
+			}
+			ch <- p
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case p := <-ch:
+			pages = append(pages, p)
+		case err, ok := <-errs:
+			if !ok {
+				return pages, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+func getPage(d doer, context, urlOrID string, opts []Option) (*Page, error) {
+	p := &Page{context: context, client: d}
+	return p, getjson(d, p, optEnc(opts), "%s/pages/%s", context, urlOrID)
+}
+
+func sendPage(d doer, context, method, path string, p *Page) (*Page, error) {
+	q, err := query.Values(&pageOptions{*p})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(d, newreq(method, context+path, q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	page := &Page{context: context, client: d}
+	return page, decodeJSON(resp.Body, page)
+}
+
+func deletePage(d doer, context, urlOrID string) (*Page, error) {
+	resp, err := delete(d, context+"/pages/"+urlOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	p := &Page{context: context, client: d}
+	return p, decodeJSON(resp.Body, p)
+}
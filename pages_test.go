@@ -0,0 +1,77 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPages(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	link := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+	}
+	mux.HandleFunc("/api/v1/courses/1/pages", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			link(w, r)
+			fmt.Fprint(w, `[{"page_id":1,"url":"syllabus","title":"Syllabus","front_page":true}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("wiki_page[title]") != "Week 1" || q.Get("wiki_page[published]") != "true" {
+				t.Errorf("wrong page params: %v", q)
+			}
+			fmt.Fprint(w, `{"page_id":2,"url":"week-1","title":"Week 1","published":true}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/pages/syllabus", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"page_id":1,"url":"syllabus","title":"Syllabus","body":"<p>hi</p>"}`)
+		case "PUT":
+			if r.URL.Query().Get("wiki_page[body]") != "<p>new</p>" {
+				t.Error("wrong body")
+			}
+			fmt.Fprint(w, `{"page_id":1,"url":"syllabus","body":"<p>new</p>"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/pages/syllabus/revisions", func(w http.ResponseWriter, r *http.Request) {
+		link(w, r)
+		fmt.Fprint(w, `[{"revision_id":3,"latest":true},{"revision_id":2}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/pages/syllabus/revisions/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST; got %s", r.Method)
+		}
+		fmt.Fprint(w, `{"revision_id":4,"latest":true,"body":"<p>hi</p>"}`)
+	})
+
+	c := &Course{ID: 1, client: client}
+	pages, err := c.Pages()
+	is.NoErr(err)
+	is.Equal(len(pages), 1)
+	is.True(pages[0].FrontPage)
+
+	p, err := c.Page("syllabus")
+	is.NoErr(err)
+	is.Equal(p.Body, "<p>hi</p>")
+	p.Body = "<p>new</p>"
+	p, err = c.EditPage(p)
+	is.NoErr(err)
+	is.Equal(p.Body, "<p>new</p>")
+
+	revs, err := p.ListRevisions()
+	is.NoErr(err)
+	is.Equal(len(revs), 2)
+	rev, err := p.RevertToRevision(revs[1].ID)
+	is.NoErr(err)
+	is.Equal(rev.ID, 4)
+
+	p, err = c.CreatePage(Page{Title: "Week 1", Published: true})
+	is.NoErr(err)
+	is.Equal(p.URL, "week-1")
+}
@@ -1,6 +1,7 @@
 package canvas
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/harrybrwn/errs"
 )
@@ -24,18 +26,34 @@ func newPaginatedList(
 	send sendFunc,
 	parameters []Option,
 ) *paginated {
-	if parameters == nil {
-		parameters = []Option{}
+	if dd, ok := d.(*defaultsDoer); ok {
+		// The defaults go first so that they can change things like
+		// per_page and the pager options.
+		parameters = append(append([]Option{}, dd.opts...), parameters...)
 	}
-	return &paginated{
+	p := &paginated{
 		do:      d,
 		path:    path,
-		opts:    parameters,
+		opts:    make([]Option, 0, len(parameters)),
 		send:    send,
 		perpage: defaultPerPage,
 		wg:      new(sync.WaitGroup),
 		errs:    make(chan error),
+		done:    make(chan struct{}),
+		ctx:     context.Background(),
 	}
+	if c, ok := unwrapDoer(d).(*client); ok {
+		p.concurrency = c.concurrency
+		p.pageHook = c.hooks.PageFetched
+	}
+	for _, opt := range parameters {
+		if po, ok := opt.(*pagerOption); ok {
+			po.apply(p)
+			continue
+		}
+		p.opts = append(p.opts, opt)
+	}
+	return p
 }
 
 type paginated struct {
@@ -46,42 +64,137 @@ type paginated struct {
 
 	perpage int
 	errs    chan error
+	// prefetch is the max number of pages that are being
+	// fetched or sent at once, zero means no limit.
+	prefetch int
+	// concurrency is the max number of pages that are
+	// being fetched at once, zero means no limit.
+	concurrency int
+	// pages is the number of pages, it is
+	// zero until the first page is received.
+	pages    int
+	pageHook func(PageEvent)
+	// inOrder will make sure that pages are sent in order
+	inOrder bool
+	// turns[i] is closed when page i has been sent, only
+	// used when inOrder is true.
+	turns []chan struct{}
+
+	// done is closed when the pager should stop
+	// requesting new pages.
+	done     chan struct{}
+	stopOnce sync.Once
+	// ctx is used for every page request, the pager
+	// stops once the context is done.
+	ctx context.Context
 
 	wg *sync.WaitGroup
 }
 
+// InOrder is an Option for paginated listings that guarantees
+// results are sent in the same order that canvas returns them. Pages
+// are still fetched concurrently but each page will wait for the
+// page before it to be received before sending its own results.
+var InOrder Option = &pagerOption{apply: func(p *paginated) {
+	p.inOrder = true
+}}
+
+// WithPrefetch is an Option for paginated listings that limits
+// the number of pages being fetched or waiting to be received to n
+// pages at a time. Lower values use less memory and make fewer
+// concurrent requests, higher values make listings faster. By default
+// all pages are requested at once.
+func WithPrefetch(n int) Option {
+	return &pagerOption{apply: func(p *paginated) {
+		p.prefetch = n
+	}}
+}
+
+// WithConcurrency is a ClientOption that limits the number of pages a
+// paginated listing will request at the same time. Listings of large
+// accounts can have hundreds of pages and requesting them all at once
+// can use up the rate limit quota quickly. By default there is no limit.
+// WithPrefetch can be used to limit a single listing.
+func WithConcurrency(n int) ClientOption {
+	return func(cc *clientConfig) {
+		cc.concurrency = n
+	}
+}
+
+// pagerOption is an Option that configures the pager
+// and is never sent to canvas.
+type pagerOption struct {
+	apply func(*paginated)
+}
+
+func (po *pagerOption) Name() string    { return "" }
+func (po *pagerOption) Value() []string { return nil }
+
+// limiter is a counting semaphore, a nil limiter has no limit.
+type limiter chan struct{}
+
+func newLimiter(n int) limiter {
+	if n <= 0 {
+		return nil
+	}
+	return make(limiter, n)
+}
+
+func (l limiter) acquire() {
+	if l != nil {
+		l <- struct{}{}
+	}
+}
+
+func (l limiter) release() {
+	if l != nil {
+		<-l
+	}
+}
+
 type closable interface {
 	Close()
 }
 
 type errorHandlerFunc func(error) error
 
-// Possible bug: ok so this function should be run in a sperate goroutine.
-// When an error is found and the send channel 'ch' is closed, some
-// objects may be sent on the channel after it is closed because it was
-// closed in a seperate goroutine.
-func handleErrs(errs <-chan error, ch closable, handle errorHandlerFunc) {
-	var err error
-	for {
-		select {
-		case e := <-errs:
-			// If e is nil, the error channel has been closed and we stop
-			// otherwise we handle the error.
-			if e != nil {
-				// If the user defined error returns an error then we stop,
-				// if it returns nil, then the user wants to keep going and
-				// handle the error one their side.
-				err = handle(e)
-				if err != nil {
-					goto Stop
-				}
-				continue // don't stop just for one error
-			}
-		Stop:
-			ch.Close() // ch should be a chan wrapped in a type
-			return
+// handleErrs starts the pager and passes every error to the error handler.
+// If the handler returns an error then the pager is stopped. The channel
+// is only closed once all the pages have finished sending so that nothing
+// is ever sent on a closed channel.
+func handleErrs(p *paginated, ch closable, handle errorHandlerFunc) {
+	for e := range p.start() {
+		// If the user defined error handler returns an error then we
+		// stop, if it returns nil, then the user wants to keep going
+		// and handle the error on their side.
+		if e != nil && handle(e) != nil {
+			p.stop()
+		}
+	}
+	ch.Close() // ch should be a chan wrapped in a type
+}
+
+// joinErrs combines all the errors received
+// from a pager into one error.
+func joinErrs(errl []error) error {
+	return errs.Chain(errl...)
+}
+
+// streamArray will read a json array from r one element at a time
+// calling decode for each element. This means that each element can be
+// sent as soon as it is decoded and we never hold an entire page
+// of large objects in memory.
+func streamArray(r io.Reader, decode func(decoder) error) error {
+	dec, err := newArrayDecoder(r)
+	if err != nil {
+		return err
+	}
+	for dec.More() {
+		if err = decode(dec); err != nil {
+			return err
 		}
 	}
+	return dec.end()
 }
 
 type pageReader interface {
@@ -104,7 +217,7 @@ func (p *pagereader) Read(b []byte) (int, error) {
 
 // returns <number of pages>, <first response>
 func (p *paginated) firstReq() (int, *http.Response, error) {
-	resp, err := get(p.do, p.path, p.getPageQuery(1))
+	resp, err := p.getPage(1)
 	if err != nil {
 		return -1, nil, err
 	}
@@ -115,6 +228,9 @@ func (p *paginated) firstReq() (int, *http.Response, error) {
 	return n, resp, nil
 }
 
+// start will begin requesting pages. The error channel returned is
+// always closed after every page has been sent, so it must be read
+// until it is closed even after an error is received.
 func (p *paginated) start() <-chan error {
 	n, resp, err := p.firstReq() // n pages and first request
 	if err != nil || n == -1 {
@@ -124,31 +240,56 @@ func (p *paginated) start() <-chan error {
 		}()
 		return p.errs
 	}
-	p.wg.Add(n)
+	p.pages = n
+	p.initTurns(n)
+	lim := newLimiter(p.prefetch)
+	fetching := newLimiter(p.concurrency)
+	lim.acquire()
 
+	p.wg.Add(2)
 	go func() {
+		p.waitTurn(1)
 		if err = p.send(&pagereader{0, resp.Body}); err != nil {
 			p.errs <- err
 		}
 		resp.Body.Close()
+		p.doneTurn(1)
+		lim.release()
 		p.wg.Done()
 	}()
-	// Already made a request for page 1, so start on 2
-	for page := 2; page <= n; page++ {
-		go func(page int) {
-			defer p.wg.Done()
-			resp, err := get(p.do, p.path, p.getPageQuery(page))
-			if err != nil {
-				p.errs <- err
-				return // stop bc we won't have data to send
-			}
-			// Using page - 1 because pagereaders index from 0 not 1
-			if err = p.send(&pagereader{page - 1, resp.Body}); err != nil {
-				p.errs <- err
+	go func() {
+		defer p.wg.Done()
+		// Already made a request for page 1, so start on 2
+		for page := 2; page <= n; page++ {
+			lim.acquire()
+			if p.stopped() {
+				lim.release()
+				return
 			}
-			resp.Body.Close()
-		}(page)
-	}
+			p.wg.Add(1)
+			go func(page int) {
+				defer p.wg.Done()
+				defer lim.release()
+				defer p.doneTurn(page)
+				fetching.acquire()
+				resp, err := p.getPage(page)
+				fetching.release()
+				p.waitTurn(page)
+				if err != nil {
+					p.errs <- err
+					return // stop bc we won't have data to send
+				}
+				defer resp.Body.Close()
+				if p.stopped() {
+					return
+				}
+				// Using page - 1 because pagereaders index from 0 not 1
+				if err = p.send(&pagereader{page - 1, resp.Body}); err != nil {
+					p.errs <- err
+				}
+			}(page)
+		}
+	}()
 	go func() {
 		p.wg.Wait()
 		p.Close()
@@ -156,10 +297,74 @@ func (p *paginated) start() <-chan error {
 	return p.errs
 }
 
+// stop tells the pager to stop requesting new pages. Pages that are
+// already being sent will finish and the error channel will still be
+// closed once they are done.
+func (p *paginated) stop() {
+	p.stopOnce.Do(func() { close(p.done) })
+}
+
+func (p *paginated) stopped() bool {
+	if p.ctx.Err() != nil {
+		return true
+	}
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *paginated) initTurns(n int) {
+	if !p.inOrder {
+		return
+	}
+	p.turns = make([]chan struct{}, n+1)
+	for i := range p.turns {
+		p.turns[i] = make(chan struct{})
+	}
+	close(p.turns[0])
+}
+
+// waitTurn blocks until all the pages before
+// this one have been sent.
+func (p *paginated) waitTurn(page int) {
+	if p.turns != nil {
+		<-p.turns[page-1]
+	}
+}
+
+func (p *paginated) doneTurn(page int) {
+	if p.turns != nil {
+		close(p.turns[page])
+	}
+}
+
 func (p *paginated) Close() {
 	close(p.errs)
 }
 
+func (p *paginated) getPage(page int) (*http.Response, error) {
+	if err := p.backpressure(page); err != nil {
+		return nil, err
+	}
+	req := newreq("GET", p.path, p.getPageQuery(page)).WithContext(p.ctx)
+	if p.pageHook == nil {
+		return do(p.do, applyRequestOptions(req, p.opts))
+	}
+	start := time.Now()
+	resp, err := do(p.do, applyRequestOptions(req, p.opts))
+	p.pageHook(PageEvent{
+		Path:     p.path,
+		Page:     page,
+		Pages:    p.pages,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+	return resp, err
+}
+
 func (p *paginated) getPageQuery(page int) params {
 	q := params{
 		"page":     {strconv.Itoa(page)},
@@ -3,11 +3,16 @@ package canvas
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"runtime"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/harrybrwn/errs"
 )
@@ -103,7 +108,7 @@ func TestPaginationErrors(t *testing.T) {
 			send, nil,
 		)
 		p.perpage = 4
-		go handleErrs(p.start(), ch, func(e error) error {
+		go handleErrs(p, ch, func(e error) error {
 			if e != testerror {
 				t.Error("should only be handling the error I sent")
 			}
@@ -145,13 +150,13 @@ func TestPaginationErrors(t *testing.T) {
 			send, nil,
 		)
 		p.perpage = 4
-		go handleErrs(p.start(), ch, func(e error) error {
+		go handleErrs(p, ch, func(e error) error {
 			if e == nil {
 				t.Error("expected error")
 			}
-			err, ok := e.(*AuthError)
-			if !ok {
-				t.Errorf("expected an auth error; got %T", err)
+			var err *AuthError
+			if !errors.As(e, &err) {
+				t.Errorf("expected an auth error; got %T", e)
 			}
 			return nil
 		})
@@ -168,3 +173,156 @@ func TestPaginationErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestStreamArray(t *testing.T) {
+	ids := make([]int, 0)
+	err := streamArray(strings.NewReader(`[{"id":1},{"id":2},{"id":3}]`), func(dec decoder) error {
+		f := &File{}
+		if err := dec.Decode(f); err != nil {
+			return err
+		}
+		ids = append(ids, f.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Errorf("got wrong ids: %v", ids)
+	}
+	err = streamArray(strings.NewReader(`{"errors":[{"message":"no"}]}`), func(decoder) error {
+		t.Error("should not decode an object")
+		return nil
+	})
+	if err == nil {
+		t.Error("expected an error for a non-array response")
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	client, mux, server := testServer()
+	defer server.Close()
+	var inflight, max int32
+	mux.HandleFunc("/api/v1/users/self/files", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		multiPageHandler(t, 5, 2, "file.json")(w, r)
+	})
+	canv := &Canvas{client: client}
+	count := 0
+	for f := range canv.Files(WithPrefetch(1)) {
+		if f.ID != 569 {
+			t.Error("got wrong file")
+		}
+		count++
+	}
+	if count != 10 {
+		t.Errorf("expected 10 files; got %d", count)
+	}
+	if max != 1 {
+		t.Errorf("expected only one page in flight at a time; got %d", max)
+	}
+}
+
+func multiPageHandler(t *testing.T, pages, perpage int, file string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		u := *r.URL
+		u.Scheme, u.Host = "https", DefaultHost
+		q := u.Query()
+		q.Set("page", fmt.Sprintf("%d", pages))
+		u.RawQuery = q.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="last"`, u.String()))
+		handlePagingatedList(t, perpage, file)(&noLinkWriter{w}, r)
+	}
+}
+
+// noLinkWriter keeps handlePagingatedList from overwriting the Link header.
+type noLinkWriter struct{ http.ResponseWriter }
+
+func (nl *noLinkWriter) Header() http.Header { return http.Header{} }
+
+func (nl *noLinkWriter) WriteHeader(code int) { nl.ResponseWriter.WriteHeader(code) }
+
+func TestInOrder(t *testing.T) {
+	client, mux, server := testServer()
+	defer server.Close()
+	pages := 5
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		var page int
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		// later pages respond first
+		time.Sleep(time.Duration(pages-page) * 5 * time.Millisecond)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments?page=%d>; rel="last"`, DefaultHost, pages))
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, page*10, page*10+1)
+	})
+	course := &Course{ID: 1, client: client, errorHandler: defaultErrorHandler}
+	prev := 0
+	count := 0
+	for a := range course.Assignments(InOrder) {
+		if a.ID < prev {
+			t.Errorf("got assignment %d after %d", a.ID, prev)
+		}
+		prev = a.ID
+		count++
+	}
+	if count != pages*2 {
+		t.Errorf("expected %d assignments; got %d", pages*2, count)
+	}
+}
+
+func TestListDrain(t *testing.T) {
+	client, mux, server := testServer()
+	defer server.Close()
+	pages := 5
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		var page int
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments?page=%d>; rel="last"`, DefaultHost, pages))
+		if page%2 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"something went wrong"}`)
+			return
+		}
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, page*10, page*10+1)
+	})
+	course := &Course{ID: 1, client: client}
+	goroutines := runtime.NumGoroutine()
+
+	asses, err := course.ListAssignments()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(asses) != 6 {
+		t.Errorf("expected the 6 assignments from the good pages; got %d", len(asses))
+	}
+	if strings.Count(err.Error(), "something went wrong") != 2 {
+		t.Errorf("expected both page errors; got %v", err)
+	}
+
+	var handled int32
+	course.errorHandler = func(e error) error {
+		atomic.AddInt32(&handled, 1)
+		return e
+	}
+	for range course.Assignments(WithPrefetch(1)) {
+	}
+	if atomic.LoadInt32(&handled) == 0 {
+		t.Error("error handler was not called")
+	}
+
+	// give the server's connection goroutines a chance to exit
+	server.CloseClientConnections()
+	for i := 0; i < 50 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > goroutines {
+		t.Errorf("leaked %d goroutines", n-goroutines)
+	}
+}
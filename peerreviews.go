@@ -0,0 +1,110 @@
+package canvas
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Peer review workflow states.
+const (
+	PeerReviewAssigned  = "assigned"
+	PeerReviewCompleted = "completed"
+)
+
+// PeerReview is a request for a student to review another
+// student's submission.
+//
+// https://canvas.instructure.com/doc/api/peer_reviews.html
+type PeerReview struct {
+	ID int `json:"id"`
+	// UserID is the student whose submission is being reviewed.
+	UserID int `json:"user_id"`
+	// AssessorID is the student doing the review.
+	AssessorID int `json:"assessor_id"`
+	// AssetID is the id of the submission being reviewed.
+	AssetID       int    `json:"asset_id"`
+	AssetType     string `json:"asset_type"`
+	WorkflowState string `json:"workflow_state"`
+	// User and Assessor are only set when using
+	// the PeerReviewUsers option.
+	User     *User `json:"user"`
+	Assessor *User `json:"assessor"`
+	// Comments are the comments the reviewer left on the submission,
+	// they are only set when using the PeerReviewComments option.
+	Comments []*SubmissionComment `json:"submission_comments"`
+}
+
+// Completed returns true when the reviewer has finished the review.
+func (pr *PeerReview) Completed() bool {
+	return pr.WorkflowState == PeerReviewCompleted
+}
+
+// SubmissionComment is a comment left on a submission.
+type SubmissionComment struct {
+	ID          int       `json:"id"`
+	AuthorID    int       `json:"author_id"`
+	AuthorName  string    `json:"author_name"`
+	Comment     string    `json:"comment"`
+	CreatedAt   time.Time `json:"created_at"`
+	EditedAt    time.Time `json:"edited_at"`
+	Attachments []*File   `json:"attachments"`
+}
+
+// PeerReviewComments is an Option that includes the
+// reviewers' comments as PeerReview.Comments.
+var PeerReviewComments Option = IncludeOpt("submission_comments")
+
+// PeerReviewUsers is an Option that includes the user being
+// reviewed and the reviewer as PeerReview.User and PeerReview.Assessor.
+var PeerReviewUsers Option = IncludeOpt("user")
+
+// ListPeerReviews will get the peer reviews assigned for the assignment.
+// It is not called PeerReviews because of Assignment.PeerReviews, which
+// is true when the assignment has peer reviews turned on.
+//
+// https://canvas.instructure.com/doc/api/peer_reviews.html#method.peer_reviews_api.index
+func (a *Assignment) ListPeerReviews(opts ...Option) (reviews []*PeerReview, err error) {
+	return reviews, getjson(a.client, &reviews, optEnc(opts), a.path("/peer_reviews"))
+}
+
+// SubmissionPeerReviews will get the peer reviews assigned for one submission.
+//
+// https://canvas.instructure.com/doc/api/peer_reviews.html#method.peer_reviews_api.index
+func (a *Assignment) SubmissionPeerReviews(submissionID int, opts ...Option) (reviews []*PeerReview, err error) {
+	path := a.path(fmt.Sprintf("/submissions/%d/peer_reviews", submissionID))
+	return reviews, getjson(a.client, &reviews, optEnc(opts), path)
+}
+
+// AssignPeerReview will assign the user with reviewerID to
+// review the submission.
+//
+// https://canvas.instructure.com/doc/api/peer_reviews.html#method.peer_reviews_api.create
+func (a *Assignment) AssignPeerReview(submissionID, reviewerID int) (*PeerReview, error) {
+	resp, err := post(
+		a.client,
+		a.path(fmt.Sprintf("/submissions/%d/peer_reviews", submissionID)),
+		params{"user_id": {strconv.Itoa(reviewerID)}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	pr := &PeerReview{}
+	return pr, decodeJSON(resp.Body, pr)
+}
+
+// DeletePeerReview will remove the reviewer's peer review of the submission.
+//
+// https://canvas.instructure.com/doc/api/peer_reviews.html#method.peer_reviews_api.destroy
+func (a *Assignment) DeletePeerReview(submissionID, reviewerID int) error {
+	resp, err := delete(
+		a.client,
+		a.path(fmt.Sprintf("/submissions/%d/peer_reviews", submissionID)),
+		params{"user_id": {strconv.Itoa(reviewerID)}},
+	)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,58 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPeerReviews(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/peer_reviews", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		include := r.URL.Query()["include[]"]
+		if len(include) != 2 || include[0] != "submission_comments" || include[1] != "user" {
+			t.Errorf("wrong includes: %v", include)
+		}
+		fmt.Fprint(w, `[{"id":1,"user_id":10,"assessor_id":11,"asset_id":5,"asset_type":"Submission","workflow_state":"completed",
+			"user":{"id":10,"name":"Ann"},"assessor":{"id":11,"name":"Bob"},
+			"submission_comments":[{"id":3,"author_id":11,"comment":"nice work"}]}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/submissions/5/peer_reviews", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"id":1,"asset_id":5,"assessor_id":11}]`)
+		case "POST", "DELETE":
+			if r.URL.Query().Get("user_id") != "12" {
+				t.Error("wrong reviewer")
+			}
+			fmt.Fprint(w, `{"id":2,"user_id":10,"assessor_id":12,"asset_id":5,"workflow_state":"assigned"}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	reviews, err := a.ListPeerReviews(PeerReviewComments, PeerReviewUsers)
+	is.NoErr(err)
+	is.Equal(len(reviews), 1)
+	pr := reviews[0]
+	is.True(pr.Completed())
+	is.Equal(pr.Assessor.Name, "Bob")
+	is.Equal(pr.Comments[0].Comment, "nice work")
+	is.Equal(pr.Comments[0].AuthorID, pr.AssessorID)
+
+	reviews, err = a.SubmissionPeerReviews(5)
+	is.NoErr(err)
+	is.Equal(reviews[0].AssetID, 5)
+
+	pr, err = a.AssignPeerReview(5, 12)
+	is.NoErr(err)
+	is.Equal(pr.AssessorID, 12)
+	is.True(!pr.Completed())
+	is.NoErr(a.DeletePeerReview(5, 12))
+}
@@ -0,0 +1,341 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Plannable types are the types of items that show up in a user's planner.
+const (
+	PlannableAnnouncement  = "announcement"
+	PlannableAssignment    = "assignment"
+	PlannableDiscussion    = "discussion_topic"
+	PlannableQuiz          = "quiz"
+	PlannableWikiPage      = "wiki_page"
+	PlannableNote          = "planner_note"
+	PlannableCalendarEvent = "calendar_event"
+	PlannableAssessmentReq = "assessment_request"
+)
+
+// PlannerOverride changes how an item shows up in the current
+// user's planner.
+//
+// https://canvas.instructure.com/doc/api/planner.html#PlannerOverride
+type PlannerOverride struct {
+	ID             int       `json:"id"`
+	PlannableType  string    `json:"plannable_type"`
+	PlannableID    int       `json:"plannable_id"`
+	UserID         int       `json:"user_id"`
+	WorkflowState  string    `json:"workflow_state"`
+	MarkedComplete bool      `json:"marked_complete"`
+	Dismissed      bool      `json:"dismissed"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	DeletedAt      time.Time `json:"deleted_at"`
+
+	client doer
+}
+
+// PlannerOverrides will list the current user's planner overrides.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_overrides.index
+func (c *Canvas) PlannerOverrides(opts ...Option) ([]*PlannerOverride, error) {
+	overrides := make([]*PlannerOverride, 0)
+	if err := getjson(c.client, &overrides, optEnc(opts), "/planner/overrides"); err != nil {
+		return nil, err
+	}
+	for _, o := range overrides {
+		o.client = c.client
+	}
+	return overrides, nil
+}
+
+// CreatePlannerOverride will create an override for an item in the
+// current user's planner. The plannableType is one of the Plannable
+// constants and options like Opt("marked_complete", true) or
+// Opt("dismissed", true) set what the override does.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_overrides.create
+func (c *Canvas) CreatePlannerOverride(plannableType string, plannableID int, opts ...Option) (*PlannerOverride, error) {
+	q := params{
+		"plannable_type": {plannableType},
+		"plannable_id":   {strconv.Itoa(plannableID)},
+	}
+	q.Add(opts)
+	resp, err := post(c.client, "/planner/overrides", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	o := &PlannerOverride{client: c.client}
+	return o, decodeJSON(resp.Body, o)
+}
+
+// DismissPlannerItem will hide an item from the current user's
+// planner opportunities and to-do list.
+func (c *Canvas) DismissPlannerItem(plannableType string, plannableID int) (*PlannerOverride, error) {
+	return c.CreatePlannerOverride(plannableType, plannableID, Opt("dismissed", true))
+}
+
+// SnoozePlannerItem will move an item to a new date in the current user's
+// planner. Canvas does not let planner items be moved so the item is marked
+// complete and a planner note with the item's title is added on the new
+// date. The note links back to the item when it is in a course, and the
+// courseID can be zero for items that are not.
+func (c *Canvas) SnoozePlannerItem(plannableType string, plannableID int, title string, courseID int, until time.Time) (*PlannerNote, error) {
+	note, err := c.CreatePlannerNote(title, until, plannerNoteLink(plannableType, plannableID, courseID)...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.CreatePlannerOverride(plannableType, plannableID, Opt("marked_complete", true)); err != nil {
+		return note, fmt.Errorf("could not complete snoozed %s %d: %w", plannableType, plannableID, err)
+	}
+	return note, nil
+}
+
+// Update will change the override. Only the marked_complete
+// and dismissed fields can be changed.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_overrides.update
+func (po *PlannerOverride) Update(markedComplete, dismissed bool) error {
+	resp, err := put(po.client, fmt.Sprintf("/planner/overrides/%d", po.ID), params{
+		"marked_complete": {strconv.FormatBool(markedComplete)},
+		"dismissed":       {strconv.FormatBool(dismissed)},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, po)
+}
+
+// Delete will delete the override so that the item
+// shows up in the planner like it normally would.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_overrides.destroy
+func (po *PlannerOverride) Delete() error {
+	resp, err := delete(po.client, fmt.Sprintf("/planner/overrides/%d", po.ID), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// PlannerNote is a note that a user added to their planner.
+//
+// https://canvas.instructure.com/doc/api/planner.html#PlannerNote
+type PlannerNote struct {
+	ID               int       `json:"id"`
+	Title            string    `json:"title"`
+	Details          string    `json:"details"`
+	UserID           int       `json:"user_id"`
+	WorkflowState    string    `json:"workflow_state"`
+	CourseID         int       `json:"course_id"`
+	TodoDate         time.Time `json:"todo_date"`
+	LinkedObjectType string    `json:"linked_object_type"`
+	LinkedObjectID   int       `json:"linked_object_id"`
+	LinkedObjectURL  string    `json:"linked_object_url"`
+
+	client doer
+}
+
+// CreatePlannerNote will add a note to the current user's planner on
+// the todo date. Options like Opt("details", "...") or
+// Opt("course_id", 1) can be given.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_notes.create
+func (c *Canvas) CreatePlannerNote(title string, todo time.Time, opts ...Option) (*PlannerNote, error) {
+	q := params{
+		"title":     {title},
+		"todo_date": {todo.Format(time.RFC3339)},
+	}
+	q.Add(opts)
+	resp, err := post(c.client, "/planner_notes", q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	n := &PlannerNote{client: c.client}
+	return n, decodeJSON(resp.Body, n)
+}
+
+// plannerNoteLink returns the options that link a planner
+// note to an item in a course.
+func plannerNoteLink(plannableType string, plannableID, courseID int) []Option {
+	if courseID == 0 {
+		return nil
+	}
+	opts := []Option{Opt("course_id", courseID)}
+	switch plannableType {
+	case PlannableAnnouncement, PlannableAssignment, PlannableDiscussion, PlannableWikiPage, PlannableQuiz:
+		// canvas can only link notes to these
+		opts = append(opts,
+			Opt("linked_object_type", plannableType),
+			Opt("linked_object_id", plannableID),
+		)
+	}
+	return opts
+}
+
+// PlannerItem is an item in a user's planner, like
+// an assignment that is due or a planner note.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner.index
+type PlannerItem struct {
+	ContextType     string             `json:"context_type"`
+	ContextName     string             `json:"context_name"`
+	CourseID        int                `json:"course_id"`
+	GroupID         int                `json:"group_id"`
+	UserID          int                `json:"user_id"`
+	PlannableID     int                `json:"plannable_id"`
+	PlannableType   string             `json:"plannable_type"`
+	PlannableDate   time.Time          `json:"plannable_date"`
+	Plannable       Plannable          `json:"plannable"`
+	PlannerOverride *PlannerOverride   `json:"planner_override"`
+	NewActivity     bool               `json:"new_activity"`
+	Submissions     PlannerSubmissions `json:"submissions"`
+	HTMLURL         string             `json:"html_url"`
+
+	client doer
+}
+
+// Plannable is the object that a planner item is for. Only
+// the fields that are shared by all plannable types are here.
+type Plannable struct {
+	ID             int       `json:"id"`
+	Title          string    `json:"title"`
+	Details        string    `json:"details"`
+	DueAt          time.Time `json:"due_at"`
+	TodoDate       time.Time `json:"todo_date"`
+	PointsPossible float64   `json:"points_possible"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// PlannerSubmissions is the user's submission status
+// for a planner item.
+type PlannerSubmissions struct {
+	Submitted     bool `json:"submitted"`
+	Excused       bool `json:"excused"`
+	Graded        bool `json:"graded"`
+	Late          bool `json:"late"`
+	Missing       bool `json:"missing"`
+	NeedsGrading  bool `json:"needs_grading"`
+	HasFeedback   bool `json:"has_feedback"`
+	RedoRequested bool `json:"redo_request"`
+}
+
+// UnmarshalJSON decodes the submission status. Canvas
+// sends false for items that cannot be submitted.
+func (ps *PlannerSubmissions) UnmarshalJSON(b []byte) error {
+	if string(b) == "false" || string(b) == "null" {
+		*ps = PlannerSubmissions{}
+		return nil
+	}
+	type submissions PlannerSubmissions
+	return json.Unmarshal(b, (*submissions)(ps))
+}
+
+// Planner will list the items in the user's planner between start and
+// end, either of which can be zero to leave that end of the range open.
+// Observers can list the planners of the students they observe.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner.index
+func (u *User) Planner(start, end time.Time, opts ...Option) ([]*PlannerItem, error) {
+	if !start.IsZero() {
+		opts = append(opts, DateOpt("start_date", start))
+	}
+	if !end.IsZero() {
+		opts = append(opts, DateOpt("end_date", end))
+	}
+	// the planner is paginated with bookmarks instead of page numbers
+	it := newIterator[*PlannerItem](u.client, u.id("/users/%d/planner/items"), opts)
+	defer it.Close()
+	items := make([]*PlannerItem, 0)
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// Complete will mark the item as complete in the current user's planner.
+func (pi *PlannerItem) Complete() error {
+	return pi.override(true, pi.PlannerOverride != nil && pi.PlannerOverride.Dismissed)
+}
+
+// Dismiss will hide the item from the current user's
+// planner opportunities and to-do list.
+func (pi *PlannerItem) Dismiss() error {
+	return pi.override(pi.PlannerOverride != nil && pi.PlannerOverride.MarkedComplete, true)
+}
+
+func (pi *PlannerItem) override(markedComplete, dismissed bool) error {
+	if pi.PlannerOverride != nil && pi.PlannerOverride.ID != 0 {
+		pi.PlannerOverride.client = pi.client
+		return pi.PlannerOverride.Update(markedComplete, dismissed)
+	}
+	c := &Canvas{client: pi.client}
+	o, err := c.CreatePlannerOverride(pi.PlannableType, pi.PlannableID,
+		Opt("marked_complete", markedComplete),
+		Opt("dismissed", dismissed),
+	)
+	if err != nil {
+		return err
+	}
+	pi.PlannerOverride = o
+	return nil
+}
+
+func (pi *PlannerItem) setclient(d doer) {
+	pi.client = d
+}
+
+// PlannerNotes will list the current user's planner notes. Options like
+// DateOpt("start_date", t) and Opt("context_codes[]", "course_1") narrow
+// down the notes.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_notes.index
+func (c *Canvas) PlannerNotes(opts ...Option) ([]*PlannerNote, error) {
+	notes := make([]*PlannerNote, 0)
+	if err := getjson(c.client, &notes, optEnc(opts), "/planner_notes"); err != nil {
+		return nil, err
+	}
+	for _, n := range notes {
+		n.client = c.client
+	}
+	return notes, nil
+}
+
+// PlannerNote will get one of the current user's planner notes.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_notes.show
+func (c *Canvas) PlannerNote(id int) (*PlannerNote, error) {
+	n := &PlannerNote{client: c.client}
+	return n, getjson(c.client, n, nil, "/planner_notes/%d", id)
+}
+
+// Update will change the note. Options like Opt("title", "...") or
+// DateOpt("todo_date", t) set the fields that are changed.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_notes.update
+func (pn *PlannerNote) Update(opts ...Option) error {
+	resp, err := put(pn.client, fmt.Sprintf("/planner_notes/%d", pn.ID), optEnc(opts))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSON(resp.Body, pn)
+}
+
+// Delete will delete the note.
+//
+// https://canvas.instructure.com/doc/api/planner.html#method.planner_notes.destroy
+func (pn *PlannerNote) Delete() error {
+	resp, err := delete(pn.client, fmt.Sprintf("/planner_notes/%d", pn.ID), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
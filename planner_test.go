@@ -0,0 +1,139 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPlannerOverrides(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: client}
+	var overrides []string
+	mux.HandleFunc("/api/v1/planner/overrides", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"id":1,"plannable_type":"assignment","plannable_id":2,"dismissed":true}]`)
+		case "POST":
+			is.Equal(q.Get("plannable_type"), PlannableAssignment)
+			overrides = append(overrides, q.Encode())
+			fmt.Fprintf(w, `{"id":3,"plannable_type":"assignment","plannable_id":%s,"dismissed":%t,"marked_complete":%t}`,
+				q.Get("plannable_id"), q.Get("dismissed") == "true", q.Get("marked_complete") == "true")
+		}
+	})
+	mux.HandleFunc("/api/v1/planner/overrides/3", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			q := r.URL.Query()
+			fmt.Fprintf(w, `{"id":3,"dismissed":%s,"marked_complete":%s}`, q.Get("dismissed"), q.Get("marked_complete"))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/api/v1/planner_notes", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("title") != "hw 1" || q.Get("todo_date") != "2026-03-02T09:00:00Z" || q.Get("course_id") != "5" ||
+			q.Get("linked_object_type") != PlannableAssignment || q.Get("linked_object_id") != "2" {
+			t.Errorf("wrong planner note: %v", q)
+		}
+		fmt.Fprint(w, `{"id":8,"title":"hw 1","course_id":5,"todo_date":"2026-03-02T09:00:00Z"}`)
+	})
+
+	list, err := c.PlannerOverrides()
+	is.NoErr(err)
+	is.True(list[0].Dismissed)
+
+	o, err := c.DismissPlannerItem(PlannableAssignment, 2)
+	is.NoErr(err)
+	is.True(o.Dismissed)
+	is.NoErr(o.Update(true, false))
+	is.True(o.MarkedComplete)
+	is.True(!o.Dismissed)
+	is.NoErr(o.Delete())
+
+	until := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	note, err := c.SnoozePlannerItem(PlannableAssignment, 2, "hw 1", 5, until)
+	is.NoErr(err)
+	is.Equal(note.ID, 8)
+	is.True(note.TodoDate.Equal(until))
+	is.Equal(len(overrides), 2)
+	is.Equal(overrides[1], "marked_complete=true&plannable_id=2&plannable_type=assignment")
+}
+
+func TestPlanner(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/2/planner/items", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("page") == "" {
+			is.Equal(q.Get("start_date"), "2026-03-01T00:00:00Z")
+			is.Equal(q.Get("end_date"), "2026-03-08T00:00:00Z")
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/users/2/planner/items?page=bookmark:abc>; rel="next"`, DefaultHost))
+			fmt.Fprint(w, `[{"plannable_id":5,"plannable_type":"assignment","course_id":1,"submissions":{"submitted":true,"late":true},
+				"plannable":{"id":5,"title":"hw 1","points_possible":10}}]`)
+			return
+		}
+		is.Equal(q.Get("page"), "bookmark:abc")
+		fmt.Fprint(w, `[{"plannable_id":6,"plannable_type":"planner_note","submissions":false,
+			"planner_override":{"id":9,"plannable_type":"planner_note","plannable_id":6,"marked_complete":true}}]`)
+	})
+	mux.HandleFunc("/api/v1/planner/overrides", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("plannable_id") != "5" || q.Get("marked_complete") != "true" || q.Get("dismissed") != "false" {
+			t.Errorf("wrong override: %v", q)
+		}
+		fmt.Fprint(w, `{"id":10,"plannable_type":"assignment","plannable_id":5,"marked_complete":true}`)
+	})
+	mux.HandleFunc("/api/v1/planner/overrides/9", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "PUT")
+		q := r.URL.Query()
+		fmt.Fprintf(w, `{"id":9,"marked_complete":%s,"dismissed":%s}`, q.Get("marked_complete"), q.Get("dismissed"))
+	})
+	mux.HandleFunc("/api/v1/planner_notes", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":6,"title":"study"}]`)
+	})
+	mux.HandleFunc("/api/v1/planner_notes/6", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":6,"title":"study"}`)
+		case "PUT":
+			fmt.Fprintf(w, `{"id":6,"title":%q}`, r.URL.Query().Get("title"))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	u := &User{ID: 2, client: client}
+	items, err := u.Planner(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.Equal(len(items), 2)
+	is.Equal(items[0].Plannable.Title, "hw 1")
+	is.True(items[0].Submissions.Late)
+	is.True(!items[1].Submissions.Submitted)
+
+	is.NoErr(items[0].Complete())
+	is.Equal(items[0].PlannerOverride.ID, 10)
+	is.NoErr(items[1].Dismiss())
+	is.True(items[1].PlannerOverride.Dismissed)
+	is.True(items[1].PlannerOverride.MarkedComplete)
+
+	c := &Canvas{client: client}
+	notes, err := c.PlannerNotes()
+	is.NoErr(err)
+	is.Equal(len(notes), 1)
+	note, err := c.PlannerNote(6)
+	is.NoErr(err)
+	is.NoErr(note.Update(Opt("title", "read ch 2")))
+	is.Equal(note.Title, "read ch 2")
+	is.NoErr(note.Delete())
+}
@@ -0,0 +1,395 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Polls returns the polls created by the current user.
+//
+// https://canvas.instructure.com/doc/api/polls.html#method.polling/polls.index
+func (c *Canvas) Polls(opts ...Option) (polls []*Poll, err error) {
+	ch := make(chan *Poll)
+	errs := newPaginatedList(c.client, "/polls", func(r io.Reader) error {
+		var res pollsResp
+		if err := decodeJSON(r, &res); err != nil {
+			return err
+		}
+		for _, p := range res.Polls {
+			p.client = c.client
+			ch <- p
+		}
+		return nil
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case p := <-ch:
+			polls = append(polls, p)
+		case err, ok := <-errs:
+			if !ok {
+				return polls, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// Polls returns the polls created by the current user.
+func Polls(opts ...Option) ([]*Poll, error) {
+	return ca.Polls(opts...)
+}
+
+// Poll will get a poll by id.
+//
+// https://canvas.instructure.com/doc/api/polls.html#method.polling/polls.show
+func (c *Canvas) Poll(id int) (*Poll, error) {
+	var res pollsResp
+	if err := getjson(c.client, &res, nil, "/polls/%d", id); err != nil {
+		return nil, err
+	}
+	return res.first(c.client)
+}
+
+// CreatePoll will create a new poll. The description is optional.
+//
+// https://canvas.instructure.com/doc/api/polls.html#method.polling/polls.create
+func (c *Canvas) CreatePoll(question, description string) (*Poll, error) {
+	q := params{"polls[][question]": {question}}
+	if description != "" {
+		q.Set("polls[][description]", description)
+	}
+	return sendPoll(c.client, "POST", "/polls", q)
+}
+
+// CreatePoll will create a new poll.
+func CreatePoll(question, description string) (*Poll, error) {
+	return ca.CreatePoll(question, description)
+}
+
+// OpenPollSessions returns the poll sessions that are open to
+// the current user.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.opened
+func (c *Canvas) OpenPollSessions() ([]*PollSession, error) {
+	return getPollSessions(c.client, "/poll_sessions/opened")
+}
+
+// ClosedPollSessions returns the poll sessions that the current
+// user can see but are closed.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.closed
+func (c *Canvas) ClosedPollSessions() ([]*PollSession, error) {
+	return getPollSessions(c.client, "/poll_sessions/closed")
+}
+
+// Poll is a question that students can answer
+// during a poll session.
+type Poll struct {
+	ID           int            `json:"id,string"`
+	Question     string         `json:"question"`
+	Description  string         `json:"description"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UserID       int            `json:"user_id,string"`
+	TotalResults map[string]int `json:"total_results"`
+
+	client doer
+}
+
+// Update will change the poll's question and description.
+//
+// https://canvas.instructure.com/doc/api/polls.html#method.polling/polls.update
+func (p *Poll) Update(question, description string) error {
+	q := params{"polls[][question]": {question}}
+	if description != "" {
+		q.Set("polls[][description]", description)
+	}
+	poll, err := sendPoll(p.client, "PUT", p.path(""), q)
+	if err != nil {
+		return err
+	}
+	*p = *poll
+	return nil
+}
+
+// Delete will delete the poll.
+//
+// https://canvas.instructure.com/doc/api/polls.html#method.polling/polls.destroy
+func (p *Poll) Delete() error {
+	resp, err := delete(p.client, p.path(""), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Choices returns the poll's choices.
+//
+// https://canvas.instructure.com/doc/api/poll_choices.html#method.polling/poll_choices.index
+func (p *Poll) Choices() ([]*PollChoice, error) {
+	var res pollChoicesResp
+	if err := getjson(p.client, &res, nil, "%s", p.path("/poll_choices")); err != nil {
+		return nil, err
+	}
+	return res.Choices, nil
+}
+
+// CreateChoice will add a choice to the poll. Choices are
+// shown in order of their position.
+//
+// https://canvas.instructure.com/doc/api/poll_choices.html#method.polling/poll_choices.create
+func (p *Poll) CreateChoice(text string, correct bool, position int) (*PollChoice, error) {
+	resp, err := post(p.client, p.path("/poll_choices"), params{
+		"poll_choices[][text]":       {text},
+		"poll_choices[][is_correct]": {strconv.FormatBool(correct)},
+		"poll_choices[][position]":   {strconv.Itoa(position)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res pollChoicesResp
+	if err = decodeJSON(resp.Body, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Choices) == 0 {
+		return nil, fmt.Errorf("no poll choice returned")
+	}
+	return res.Choices[0], nil
+}
+
+// DeleteChoice will remove a choice from the poll.
+//
+// https://canvas.instructure.com/doc/api/poll_choices.html#method.polling/poll_choices.destroy
+func (p *Poll) DeleteChoice(id int) error {
+	resp, err := delete(p.client, p.path(fmt.Sprintf("/poll_choices/%d", id)), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Sessions returns the poll's sessions.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.index
+func (p *Poll) Sessions() ([]*PollSession, error) {
+	return getPollSessions(p.client, p.path("/poll_sessions"))
+}
+
+// Session will get one of the poll's sessions along with its
+// results and submissions.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.show
+func (p *Poll) Session(id int) (*PollSession, error) {
+	sessions, err := getPollSessions(p.client, p.path(fmt.Sprintf("/poll_sessions/%d", id)))
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("no poll session returned")
+	}
+	return sessions[0], nil
+}
+
+// CreateSession will create a session of the poll for a course.
+// The sectionID is optional and can be zero. The session must
+// be opened before students can answer the poll.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.create
+func (p *Poll) CreateSession(courseID, sectionID int, publicResults bool) (*PollSession, error) {
+	q := params{
+		"poll_sessions[][course_id]":          {strconv.Itoa(courseID)},
+		"poll_sessions[][has_public_results]": {strconv.FormatBool(publicResults)},
+	}
+	if sectionID != 0 {
+		q.Set("poll_sessions[][course_section_id]", strconv.Itoa(sectionID))
+	}
+	resp, err := post(p.client, p.path("/poll_sessions"), q)
+	if err != nil {
+		return nil, err
+	}
+	return decodePollSession(p.client, resp.Body)
+}
+
+func (p *Poll) path(s string) string {
+	return fmt.Sprintf("/polls/%d%s", p.ID, s)
+}
+
+// PollChoice is one of the answers to a poll.
+type PollChoice struct {
+	ID        int    `json:"id,string"`
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+	Position  int    `json:"position"`
+	PollID    int    `json:"poll_id,string"`
+}
+
+// PollSession is a poll being given to a course or section.
+type PollSession struct {
+	ID               int       `json:"id,string"`
+	PollID           int       `json:"poll_id,string"`
+	CourseID         int       `json:"course_id,string"`
+	CourseSectionID  int       `json:"course_section_id,string"`
+	IsPublished      bool      `json:"is_published"`
+	HasPublicResults bool      `json:"has_public_results"`
+	HasSubmitted     bool      `json:"has_submitted"`
+	CreatedAt        time.Time `json:"created_at"`
+	// Results maps choice ids to the number of
+	// students that chose them.
+	Results         map[string]int    `json:"results"`
+	PollSubmissions []*PollSubmission `json:"poll_submissions"`
+
+	client doer
+}
+
+// Open will open the session so that students can submit answers.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.open
+func (ps *PollSession) Open() error {
+	return ps.setState("/open")
+}
+
+// Close will close the session so that no more answers are accepted.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.close
+func (ps *PollSession) Close() error {
+	return ps.setState("/close")
+}
+
+// Delete will delete the poll session.
+//
+// https://canvas.instructure.com/doc/api/poll_sessions.html#method.polling/poll_sessions.destroy
+func (ps *PollSession) Delete() error {
+	resp, err := delete(ps.client, ps.path(""), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Submit will answer the poll as the current user.
+//
+// https://canvas.instructure.com/doc/api/poll_submissions.html#method.polling/poll_submissions.create
+func (ps *PollSession) Submit(choiceID int) (*PollSubmission, error) {
+	resp, err := post(ps.client, ps.path("/poll_submissions"), params{
+		"poll_submissions[][poll_choice_id]": {strconv.Itoa(choiceID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return decodePollSubmission(resp.Body)
+}
+
+// Submission will get a single submission for the session.
+//
+// https://canvas.instructure.com/doc/api/poll_submissions.html#method.polling/poll_submissions.show
+func (ps *PollSession) Submission(id int) (*PollSubmission, error) {
+	resp, err := get(ps.client, ps.path(fmt.Sprintf("/poll_submissions/%d", id)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return decodePollSubmission(resp.Body)
+}
+
+func (ps *PollSession) setState(action string) error {
+	resp, err := get(ps.client, ps.path(action), nil)
+	if err != nil {
+		return err
+	}
+	s, err := decodePollSession(ps.client, resp.Body)
+	if err != nil {
+		return err
+	}
+	*ps = *s
+	return nil
+}
+
+func (ps *PollSession) path(s string) string {
+	return fmt.Sprintf("/polls/%d/poll_sessions/%d%s", ps.PollID, ps.ID, s)
+}
+
+// PollSubmission is a student's answer to a poll.
+type PollSubmission struct {
+	ID           int       `json:"id,string"`
+	PollChoiceID int       `json:"poll_choice_id,string"`
+	UserID       int       `json:"user_id,string"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// canvas wraps everything in the polls api in an object
+
+type pollsResp struct {
+	Polls []*Poll `json:"polls"`
+}
+
+func (pr *pollsResp) first(d doer) (*Poll, error) {
+	if len(pr.Polls) == 0 {
+		return nil, fmt.Errorf("no poll returned")
+	}
+	pr.Polls[0].client = d
+	return pr.Polls[0], nil
+}
+
+type pollChoicesResp struct {
+	Choices []*PollChoice `json:"poll_choices"`
+}
+
+type pollSessionsResp struct {
+	Sessions []*PollSession `json:"poll_sessions"`
+}
+
+type pollSubmissionsResp struct {
+	Submissions []*PollSubmission `json:"poll_submissions"`
+}
+
+func sendPoll(d doer, method, path string, q params) (*Poll, error) {
+	resp, err := do(d, newreq(method, path, q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res pollsResp
+	if err = decodeJSON(resp.Body, &res); err != nil {
+		return nil, err
+	}
+	return res.first(d)
+}
+
+func getPollSessions(d doer, path string) ([]*PollSession, error) {
+	var res pollSessionsResp
+	if err := getjson(d, &res, nil, "%s", path); err != nil {
+		return nil, err
+	}
+	for _, s := range res.Sessions {
+		s.client = d
+	}
+	return res.Sessions, nil
+}
+
+func decodePollSession(d doer, body io.ReadCloser) (*PollSession, error) {
+	defer body.Close()
+	var res pollSessionsResp
+	if err := decodeJSON(body, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Sessions) == 0 {
+		return nil, fmt.Errorf("no poll session returned")
+	}
+	res.Sessions[0].client = d
+	return res.Sessions[0], nil
+}
+
+func decodePollSubmission(r io.Reader) (*PollSubmission, error) {
+	var res pollSubmissionsResp
+	if err := decodeJSON(r, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Submissions) == 0 {
+		return nil, fmt.Errorf("no poll submission returned")
+	}
+	return res.Submissions[0], nil
+}
@@ -0,0 +1,102 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPolls(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Canvas{client: client}
+	mux.HandleFunc("/api/v1/polls", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/polls?page=1>; rel="last"`, DefaultHost))
+			fmt.Fprint(w, `{"polls":[{"id":"1","question":"2+2?"},{"id":"2","question":"3+3?"}]}`)
+		case "POST":
+			if q.Get("polls[][question]") != "2+2?" || q.Get("polls[][description]") != "math" {
+				t.Errorf("wrong poll: %v", q)
+			}
+			fmt.Fprint(w, `{"polls":[{"id":"1","question":"2+2?","description":"math","user_id":"5"}]}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/polls/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			fmt.Fprintf(w, `{"polls":[{"id":"1","question":%q}]}`, r.URL.Query().Get("polls[][question]"))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/api/v1/polls/1/poll_choices", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"poll_choices":[{"id":"3","text":"4","is_correct":true,"position":1,"poll_id":"1"}]}`)
+		case "POST":
+			if q.Get("poll_choices[][text]") != "5" || q.Get("poll_choices[][is_correct]") != "false" || q.Get("poll_choices[][position]") != "2" {
+				t.Errorf("wrong choice: %v", q)
+			}
+			fmt.Fprint(w, `{"poll_choices":[{"id":"4","text":"5","is_correct":false,"position":2,"poll_id":"1"}]}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/polls/1/poll_sessions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("poll_sessions[][course_id]") != "10" || q.Get("poll_sessions[][course_section_id]") != "" ||
+			q.Get("poll_sessions[][has_public_results]") != "true" {
+			t.Errorf("wrong session: %v", q)
+		}
+		fmt.Fprint(w, `{"poll_sessions":[{"id":"7","poll_id":"1","course_id":"10","course_section_id":null,"is_published":false}]}`)
+	})
+	mux.HandleFunc("/api/v1/polls/1/poll_sessions/7/open", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"poll_sessions":[{"id":"7","poll_id":"1","course_id":"10","is_published":true}]}`)
+	})
+	mux.HandleFunc("/api/v1/polls/1/poll_sessions/7/close", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"poll_sessions":[{"id":"7","poll_id":"1","course_id":"10","is_published":false,"results":{"3":12,"4":2}}]}`)
+	})
+	mux.HandleFunc("/api/v1/polls/1/poll_sessions/7/poll_submissions", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query().Get("poll_submissions[][poll_choice_id]"), "3")
+		fmt.Fprint(w, `{"poll_submissions":[{"id":"9","poll_choice_id":"3","user_id":"5"}]}`)
+	})
+
+	polls, err := c.Polls()
+	is.NoErr(err)
+	is.Equal(len(polls), 2)
+
+	p, err := c.CreatePoll("2+2?", "math")
+	is.NoErr(err)
+	is.Equal(p.ID, 1)
+	is.Equal(p.UserID, 5)
+	is.NoErr(p.Update("what is 2+2?", ""))
+	is.Equal(p.Question, "what is 2+2?")
+
+	choices, err := p.Choices()
+	is.NoErr(err)
+	is.Equal(len(choices), 1)
+	is.True(choices[0].IsCorrect)
+	choice, err := p.CreateChoice("5", false, 2)
+	is.NoErr(err)
+	is.Equal(choice.ID, 4)
+
+	s, err := p.CreateSession(10, 0, true)
+	is.NoErr(err)
+	is.Equal(s.ID, 7)
+	is.Equal(s.CourseSectionID, 0)
+	is.NoErr(s.Open())
+	is.True(s.IsPublished)
+
+	sub, err := s.Submit(3)
+	is.NoErr(err)
+	is.Equal(sub.PollChoiceID, 3)
+
+	is.NoErr(s.Close())
+	is.True(!s.IsPublished)
+	is.Equal(s.Results["3"], 12)
+	is.NoErr(p.Delete())
+}
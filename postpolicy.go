@@ -0,0 +1,184 @@
+package canvas
+
+import (
+	"errors"
+	"strconv"
+)
+
+const postGradesMutation = `mutation PostGrades($assignmentId: ID!, $studentIds: [ID!], $gradedOnly: Boolean) {
+  postAssignmentGrades(input: {assignmentId: $assignmentId, onlyStudentIds: $studentIds, gradedOnly: $gradedOnly}) {
+    progress { _id }
+    errors { attribute message }
+  }
+}`
+
+const hideGradesMutation = `mutation HideGrades($assignmentId: ID!, $studentIds: [ID!]) {
+  hideAssignmentGrades(input: {assignmentId: $assignmentId, onlyStudentIds: $studentIds}) {
+    progress { _id }
+    errors { attribute message }
+  }
+}`
+
+const postSectionGradesMutation = `mutation PostSectionGrades($assignmentId: ID!, $sectionIds: [ID!]!, $gradedOnly: Boolean) {
+  postAssignmentGradesForSections(input: {assignmentId: $assignmentId, sectionIds: $sectionIds, gradedOnly: $gradedOnly}) {
+    progress { _id }
+    errors { attribute message }
+  }
+}`
+
+const hideSectionGradesMutation = `mutation HideSectionGrades($assignmentId: ID!, $sectionIds: [ID!]!) {
+  hideAssignmentGradesForSections(input: {assignmentId: $assignmentId, sectionIds: $sectionIds}) {
+    progress { _id }
+    errors { attribute message }
+  }
+}`
+
+// PostGrades will make the assignment's grades and comments visible to
+// students. If no user IDs are given then every student's grades are
+// posted. Posting happens in the background on canvas and the Progress
+// that tracks it is returned.
+//
+// https://canvas.instructure.com/doc/api/file.graphql.html
+func (a *Assignment) PostGrades(userIDs ...int) (*Progress, error) {
+	return a.postOrHide(postGradesMutation, "postAssignmentGrades", a.gradeVars("studentIds", userIDs))
+}
+
+// PostGradedOnly is the same as PostGrades except that only the
+// submissions that have been graded are posted. Ungraded submissions
+// stay hidden so students do not see a blank grade.
+func (a *Assignment) PostGradedOnly(userIDs ...int) (*Progress, error) {
+	vars := a.gradeVars("studentIds", userIDs)
+	vars["gradedOnly"] = true
+	return a.postOrHide(postGradesMutation, "postAssignmentGrades", vars)
+}
+
+// HideGrades will hide the assignment's grades and comments from
+// students. If no user IDs are given then every student's grades are
+// hidden. The Progress that tracks it is returned.
+func (a *Assignment) HideGrades(userIDs ...int) (*Progress, error) {
+	return a.postOrHide(hideGradesMutation, "hideAssignmentGrades", a.gradeVars("studentIds", userIDs))
+}
+
+// PostSectionGrades will post the grades of the students in the given
+// course sections. If gradedOnly is true then only graded submissions
+// are posted.
+func (a *Assignment) PostSectionGrades(gradedOnly bool, sectionIDs ...int) (*Progress, error) {
+	if len(sectionIDs) == 0 {
+		return nil, errors.New("no sections given")
+	}
+	vars := a.gradeVars("sectionIds", sectionIDs)
+	vars["gradedOnly"] = gradedOnly
+	return a.postOrHide(postSectionGradesMutation, "postAssignmentGradesForSections", vars)
+}
+
+// HideSectionGrades will hide the grades of the students
+// in the given course sections.
+func (a *Assignment) HideSectionGrades(sectionIDs ...int) (*Progress, error) {
+	if len(sectionIDs) == 0 {
+		return nil, errors.New("no sections given")
+	}
+	return a.postOrHide(hideSectionGradesMutation, "hideAssignmentGradesForSections", a.gradeVars("sectionIds", sectionIDs))
+}
+
+// gradeVars returns the mutation variables for the
+// assignment with the ids given as the named variable.
+func (a *Assignment) gradeVars(name string, ids []int) map[string]interface{} {
+	vars := map[string]interface{}{"assignmentId": strconv.Itoa(a.ID)}
+	if len(ids) > 0 {
+		strs := make([]string, len(ids))
+		for i, id := range ids {
+			strs[i] = strconv.Itoa(id)
+		}
+		vars[name] = strs
+	}
+	return vars
+}
+
+func (a *Assignment) postOrHide(mutation, name string, vars map[string]interface{}) (*Progress, error) {
+	var data map[string]struct {
+		Progress *struct {
+			ID string `json:"_id"`
+		} `json:"progress"`
+		Errors mutationErrors `json:"errors"`
+	}
+	if err := graphql(a.client, mutation, vars, &data); err != nil {
+		return nil, err
+	}
+	res := data[name]
+	if err := res.Errors.err(); err != nil {
+		return nil, err
+	}
+	if res.Progress == nil {
+		return nil, errors.New("no progress returned")
+	}
+	id, err := strconv.Atoi(res.Progress.ID)
+	if err != nil {
+		return nil, err
+	}
+	p := &Progress{ID: id, WorkflowState: ProgressQueued, client: a.client}
+	return p, nil
+}
+
+const assignmentPostPolicyMutation = `mutation SetPostPolicy($id: ID!, $postManually: Boolean!) {
+  setAssignmentPostPolicy(input: {assignmentId: $id, postManually: $postManually}) {
+    postPolicy { postManually }
+    errors { attribute message }
+  }
+}`
+
+const coursePostPolicyMutation = `mutation SetPostPolicy($id: ID!, $postManually: Boolean!) {
+  setCoursePostPolicy(input: {courseId: $id, postManually: $postManually}) {
+    postPolicy { postManually }
+    errors { attribute message }
+  }
+}`
+
+// SetPostPolicy will change whether grades for the assignment are
+// posted manually or automatically as soon as they are entered.
+func (a *Assignment) SetPostPolicy(postManually bool) error {
+	err := setPostPolicy(a.client, assignmentPostPolicyMutation, "setAssignmentPostPolicy", a.ID, postManually)
+	if err == nil {
+		a.PostManually = postManually
+	}
+	return err
+}
+
+// SetPostPolicy will set the default post policy for the course's
+// assignments. Assignments that have their own post policy are not
+// changed.
+func (c *Course) SetPostPolicy(postManually bool) error {
+	return setPostPolicy(c.client, coursePostPolicyMutation, "setCoursePostPolicy", c.ID, postManually)
+}
+
+// PostManually returns true if the course's grades are posted
+// manually by default.
+func (c *Course) PostManually() (bool, error) {
+	var data struct {
+		Course *struct {
+			PostPolicy *struct {
+				PostManually bool `json:"postManually"`
+			} `json:"postPolicy"`
+		} `json:"course"`
+	}
+	err := graphql(c.client, `query PostPolicy($id: ID!) {
+  course(id: $id) { postPolicy { postManually } }
+}`, map[string]interface{}{"id": strconv.Itoa(c.ID)}, &data)
+	if err != nil || data.Course == nil || data.Course.PostPolicy == nil {
+		return false, err
+	}
+	return data.Course.PostPolicy.PostManually, nil
+}
+
+func setPostPolicy(d doer, mutation, name string, id int, postManually bool) error {
+	var data map[string]struct {
+		Errors mutationErrors `json:"errors"`
+	}
+	err := graphql(d, mutation, map[string]interface{}{
+		"id":           strconv.Itoa(id),
+		"postManually": postManually,
+	}, &data)
+	if err != nil {
+		return err
+	}
+	return data[name].Errors.err()
+}
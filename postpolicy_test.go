@@ -0,0 +1,111 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPostPolicy(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		switch {
+		case strings.Contains(body.Query, "postAssignmentGrades"):
+			if body.Variables["assignmentId"] != "2" || fmt.Sprint(body.Variables["studentIds"]) != "[7 8]" {
+				t.Errorf("wrong variables: %v", body.Variables)
+			}
+			fmt.Fprint(w, `{"data":{"postAssignmentGrades":{"progress":{"_id":"44"},"errors":null}}}`)
+		case strings.Contains(body.Query, "hideAssignmentGrades"):
+			if _, ok := body.Variables["studentIds"]; ok {
+				t.Error("should hide grades for everyone")
+			}
+			fmt.Fprint(w, `{"data":{"hideAssignmentGrades":{"progress":null,"errors":[{"attribute":"assignment","message":"not found"}]}}}`)
+		case strings.Contains(body.Query, "setCoursePostPolicy"):
+			if body.Variables["postManually"] != true {
+				t.Error("expected postManually")
+			}
+			fmt.Fprint(w, `{"data":{"setCoursePostPolicy":{"postPolicy":{"postManually":true}}}}`)
+		default:
+			fmt.Fprint(w, `{"errors":[{"message":"unknown query"}]}`)
+		}
+	})
+	a := &Assignment{ID: 2, client: client}
+	p, err := a.PostGrades(7, 8)
+	is.NoErr(err)
+	is.Equal(p.ID, 44)
+	_, err = a.HideGrades()
+	is.True(err != nil)
+	is.Equal(err.Error(), "graphql: assignment: not found")
+
+	c := &Course{ID: 1, client: client}
+	is.NoErr(c.SetPostPolicy(true))
+	_, err = c.PostManually()
+	is.True(err != nil)
+	_, ok := err.(*GraphQLError)
+	is.True(ok)
+}
+
+func TestPostGradedOnly(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var mutations []string
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		v := body.Variables
+		switch {
+		case strings.Contains(body.Query, "postAssignmentGradesForSections"):
+			if v["gradedOnly"] != false || fmt.Sprint(v["sectionIds"]) != "[3 4]" {
+				t.Errorf("wrong variables: %v", v)
+			}
+			mutations = append(mutations, "post sections")
+			fmt.Fprint(w, `{"data":{"postAssignmentGradesForSections":{"progress":{"_id":"2"}}}}`)
+		case strings.Contains(body.Query, "hideAssignmentGradesForSections"):
+			if fmt.Sprint(v["sectionIds"]) != "[3]" {
+				t.Errorf("wrong variables: %v", v)
+			}
+			mutations = append(mutations, "hide sections")
+			fmt.Fprint(w, `{"data":{"hideAssignmentGradesForSections":{"progress":{"_id":"3"}}}}`)
+		case strings.Contains(body.Query, "postAssignmentGrades"):
+			if v["gradedOnly"] != true || v["assignmentId"] != "2" {
+				t.Errorf("wrong variables: %v", v)
+			}
+			mutations = append(mutations, "post graded")
+			fmt.Fprint(w, `{"data":{"postAssignmentGrades":{"progress":{"_id":"1"}}}}`)
+		}
+	})
+	a := &Assignment{ID: 2, client: client}
+	p, err := a.PostGradedOnly()
+	is.NoErr(err)
+	is.Equal(p.ID, 1)
+	p, err = a.PostSectionGrades(false, 3, 4)
+	is.NoErr(err)
+	is.Equal(p.ID, 2)
+	p, err = a.HideSectionGrades(3)
+	is.NoErr(err)
+	is.Equal(p.ID, 3)
+	_, err = a.HideSectionGrades()
+	is.True(err != nil)
+	is.Equal(mutations, []string{"post graded", "post sections", "hide sections"})
+}
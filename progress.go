@@ -0,0 +1,139 @@
+package canvas
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Progress workflow states.
+const (
+	ProgressQueued    = "queued"
+	ProgressRunning   = "running"
+	ProgressCompleted = "completed"
+	ProgressFailed    = "failed"
+)
+
+// progressPollInterval is the time waited before the first
+// request when waiting for a job to finish. The wait doubles
+// after every request up to maxPollInterval.
+var progressPollInterval = time.Second
+
+// maxPollInterval is the longest time waited
+// between requests when waiting for a job.
+const maxPollInterval = 30 * time.Second
+
+// Progress is the progress of a job that canvas runs in the background.
+//
+// https://canvas.instructure.com/doc/api/progress.html
+type Progress struct {
+	ID            int         `json:"id"`
+	ContextID     int         `json:"context_id"`
+	ContextType   string      `json:"context_type"`
+	UserID        int         `json:"user_id"`
+	Tag           string      `json:"tag"`
+	Completion    float64     `json:"completion"`
+	WorkflowState string      `json:"workflow_state"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	Message       string      `json:"message"`
+	Results       interface{} `json:"results"`
+	URL           string      `json:"url"`
+
+	client doer
+}
+
+// Done returns true when the job has either completed or failed.
+func (p *Progress) Done() bool {
+	return p.WorkflowState == ProgressCompleted || p.WorkflowState == ProgressFailed
+}
+
+// Err returns an error if the job failed.
+func (p *Progress) Err() error {
+	if p.WorkflowState != ProgressFailed {
+		return nil
+	}
+	if p.Message == "" {
+		return errors.New("job failed")
+	}
+	return errors.New(p.Message)
+}
+
+// Refresh will get the latest progress from canvas.
+//
+// https://canvas.instructure.com/doc/api/progress.html#method.progress.show
+func (p *Progress) Refresh() error {
+	return getjson(p.client, p, nil, "/progress/%d", p.ID)
+}
+
+// Wait will check on the job until it is done or the context is
+// cancelled. An error is returned if the job failed.
+func (p *Progress) Wait(ctx context.Context) error {
+	if err := poll(ctx, p.Done, p.Refresh); err != nil {
+		return err
+	}
+	return p.Err()
+}
+
+// Poll will check on the job every interval until it is done or the
+// context is cancelled. The job's completion, a percentage from 0 to
+// 100, is sent to fn after every check and fn can be nil. An error is
+// returned if the job failed. Use Wait to back off between checks
+// instead of using a fixed interval.
+func (p *Progress) Poll(ctx context.Context, interval time.Duration, fn func(completion float64)) error {
+	if interval <= 0 {
+		interval = progressPollInterval
+	}
+	if fn != nil {
+		fn(p.Completion)
+	}
+	err := pollEvery(ctx, interval, interval, p.Done, func() error {
+		if err := p.Refresh(); err != nil {
+			return err
+		}
+		if fn != nil {
+			fn(p.Completion)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return p.Err()
+}
+
+// Progress will get the progress of a background job by id.
+//
+// https://canvas.instructure.com/doc/api/progress.html#method.progress.show
+func (c *Canvas) Progress(id int) (*Progress, error) {
+	p := &Progress{ID: id, client: c.client}
+	return p, p.Refresh()
+}
+
+// poll waits for a job that canvas runs in the background. It calls
+// refresh until done returns true, backing off between each call, and
+// stops early if the context is cancelled.
+func poll(ctx context.Context, done func() bool, refresh func() error) error {
+	return pollEvery(ctx, progressPollInterval, maxPollInterval, done, refresh)
+}
+
+// pollEvery is poll with a custom starting and
+// maximum wait between calls to refresh.
+func pollEvery(ctx context.Context, wait, max time.Duration, done func() bool, refresh func() error) error {
+	for !done() {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		if err := refresh(); err != nil {
+			return err
+		}
+		if wait *= 2; wait > max {
+			wait = max
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Course batch update events.
+const (
+	CourseOffer    = "offer"
+	CourseConclude = "conclude"
+	CourseDelete   = "delete"
+	CourseUndelete = "undelete"
+)
+
+// batchCourseLimit is the most courses canvas
+// will update in one batch update request.
+const batchCourseLimit = 500
+
+// Publish will publish the course so that students can see it.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.update
+func (c *Course) Publish() error {
+	return c.event(CourseOffer)
+}
+
+// Unpublish will unpublish the course. Courses with
+// graded submissions cannot be unpublished.
+func (c *Course) Unpublish() error {
+	return c.event("claim")
+}
+
+func (c *Course) event(event string) error {
+	resp, err := put(c.client, c.id("/courses/%d"), params{"course[event]": {event}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var res struct {
+		WorkflowState string `json:"workflow_state"`
+	}
+	if err = decodeJSON(resp.Body, &res); err != nil {
+		return err
+	}
+	c.WorkflowState = res.WorkflowState
+	return nil
+}
+
+// BatchUpdateCourses will apply an event like CourseOffer or
+// CourseConclude to many of the account's courses and wait for canvas
+// to finish. Canvas updates at most 500 courses at once so larger
+// batches are sent in more than one request. A batch that fails does not
+// stop the others, every batch that was started is waited for and all of
+// the errors are returned together.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.batch_update
+func (a *Account) BatchUpdateCourses(ctx context.Context, event string, courseIDs ...int) error {
+	var (
+		jobs []*Progress
+		errl []error
+	)
+	for start := 0; start < len(courseIDs); start += batchCourseLimit {
+		end := start + batchCourseLimit
+		if end > len(courseIDs) {
+			end = len(courseIDs)
+		}
+		q := params{"event": {event}}
+		for _, id := range courseIDs[start:end] {
+			q["course_ids[]"] = append(q["course_ids[]"], strconv.Itoa(id))
+		}
+		p, err := a.startBatch(q)
+		if err != nil {
+			errl = append(errl, fmt.Errorf("courses %d to %d: %w", start, end-1, err))
+			continue
+		}
+		jobs = append(jobs, p)
+	}
+	for _, p := range jobs {
+		if err := p.Wait(ctx); err != nil {
+			errl = append(errl, fmt.Errorf("progress %d: %w", p.ID, err))
+		}
+	}
+	return joinErrs(errl)
+}
+
+func (a *Account) startBatch(q params) (*Progress, error) {
+	resp, err := put(a.cli, fmt.Sprintf("/accounts/%d/courses", a.ID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	p := &Progress{client: a.cli}
+	return p, decodeJSON(resp.Body, p)
+}
@@ -0,0 +1,96 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPublish(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("course[event]") {
+		case "offer":
+			fmt.Fprint(w, `{"id":1,"workflow_state":"available"}`)
+		case "claim":
+			fmt.Fprint(w, `{"id":1,"workflow_state":"unpublished"}`)
+		default:
+			t.Error("wrong event")
+		}
+	})
+	c := &Course{ID: 1, client: client}
+	is.NoErr(c.Publish())
+	is.True(c.IsPublished())
+	is.NoErr(c.Unpublish())
+	is.Equal(c.Workflow(), WorkflowUnpublished)
+}
+
+func TestBatchUpdateCourses(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	defer func(d time.Duration) { progressPollInterval = d }(progressPollInterval)
+	progressPollInterval = time.Millisecond
+	var batches, polls int32
+	mux.HandleFunc("/api/v1/accounts/1/courses", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != "PUT" || q.Get("event") != CourseOffer {
+			t.Error("wrong batch update")
+		}
+		n := atomic.AddInt32(&batches, 1)
+		if n == 1 && len(q["course_ids[]"]) != batchCourseLimit {
+			t.Errorf("expected %d courses; got %d", batchCourseLimit, len(q["course_ids[]"]))
+		}
+		fmt.Fprintf(w, `{"id":%d,"workflow_state":"queued"}`, n)
+	})
+	mux.HandleFunc("/api/v1/progress/", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) < 3 {
+			fmt.Fprint(w, `{"id":1,"workflow_state":"running","completion":50}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"workflow_state":"completed","completion":100}`)
+	})
+	ids := make([]int, 600)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	a := &Account{ID: 1, cli: client}
+	is.NoErr(a.BatchUpdateCourses(context.Background(), CourseOffer, ids...))
+	is.Equal(atomic.LoadInt32(&batches), int32(2))
+
+	mux.HandleFunc("/api/v1/accounts/2/courses", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":9,"workflow_state":"failed","message":"not allowed"}`)
+	})
+	err := (&Account{ID: 2, cli: client}).BatchUpdateCourses(context.Background(), CourseDelete, 1)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "not allowed"))
+
+	// every started batch is waited on even when others fail
+	var started int32
+	mux.HandleFunc("/api/v1/accounts/3/courses", func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&started, 1) {
+		case 1:
+			fmt.Fprint(w, `{"id":10,"workflow_state":"failed","message":"first batch failed"}`)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"errors":[{"message":"second batch failed"}]}`)
+		default:
+			fmt.Fprint(w, `{"id":11,"workflow_state":"queued"}`)
+		}
+	})
+	pollsBefore := atomic.LoadInt32(&polls)
+	err = (&Account{ID: 3, cli: client}).BatchUpdateCourses(context.Background(), CourseConclude, make([]int, 1200)...)
+	is.True(err != nil)
+	is.Equal(atomic.LoadInt32(&started), int32(3))
+	is.True(atomic.LoadInt32(&polls) > pollsBefore) // the third batch was waited on
+	is.True(strings.Contains(err.Error(), "first batch failed"))
+	is.True(strings.Contains(err.Error(), "courses 500 to 999"))
+}
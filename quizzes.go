@@ -0,0 +1,307 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// QuizQuestion is a question in a quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html
+type QuizQuestion struct {
+	ID     int `json:"id" url:"-"`
+	QuizID int `json:"quiz_id" url:"-"`
+	// QuestionType is one of "multiple_choice_question",
+	// "true_false_question", "short_answer_question", "essay_question",
+	// "numerical_question", "matching_question", and a few others.
+	QuestionType      string       `json:"question_type" url:"question_type,omitempty"`
+	QuestionName      string       `json:"question_name" url:"question_name,omitempty"`
+	QuestionText      string       `json:"question_text" url:"question_text,omitempty"`
+	Position          int          `json:"position" url:"position,omitempty"`
+	PointsPossible    float64      `json:"points_possible" url:"points_possible,omitempty"`
+	QuizGroupID       int          `json:"quiz_group_id" url:"quiz_group_id,omitempty"`
+	CorrectComments   string       `json:"correct_comments" url:"correct_comments,omitempty"`
+	IncorrectComments string       `json:"incorrect_comments" url:"incorrect_comments,omitempty"`
+	NeutralComments   string       `json:"neutral_comments" url:"neutral_comments,omitempty"`
+	Answers           []QuizAnswer `json:"answers" url:"-"`
+}
+
+// QuizAnswer is one of the possible answers to a quiz question.
+type QuizAnswer struct {
+	ID     int    `json:"id" url:"-"`
+	Text   string `json:"text" url:"answer_text,omitempty"`
+	HTML   string `json:"html" url:"answer_html,omitempty"`
+	Weight int    `json:"weight" url:"answer_weight"`
+	// Comments is shown to students that pick the answer.
+	Comments string `json:"comments" url:"answer_comments,omitempty"`
+	// Exact and Margin are used by numerical questions.
+	Exact  float64 `json:"exact" url:"exact,omitempty"`
+	Margin float64 `json:"margin" url:"margin,omitempty"`
+}
+
+type quizQuestionOptions struct {
+	QuizQuestion `url:"question"`
+}
+
+// values encodes the question, the answers are added by hand because
+// they are a list of objects which canvas wants indexed like
+// question[answers][0][answer_text].
+func (qq *QuizQuestion) values() (url.Values, error) {
+	vals, err := query.Values(&quizQuestionOptions{*qq})
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range qq.Answers {
+		av, err := query.Values(&a)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range av {
+			vals[fmt.Sprintf("question[answers][%d][%s]", i, k)] = v
+		}
+	}
+	return vals, nil
+}
+
+// Quiz submission workflow states.
+const (
+	QuizSubmissionUntaken       = "untaken"
+	QuizSubmissionPendingReview = "pending_review"
+	QuizSubmissionComplete      = "complete"
+	QuizSubmissionSettingsOnly  = "settings_only"
+	QuizSubmissionPreview       = "preview"
+)
+
+// QuizSubmission is a user's attempt at a quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html
+type QuizSubmission struct {
+	ID                        int       `json:"id"`
+	QuizID                    int       `json:"quiz_id"`
+	UserID                    int       `json:"user_id"`
+	SubmissionID              int       `json:"submission_id"`
+	StartedAt                 time.Time `json:"started_at"`
+	FinishedAt                time.Time `json:"finished_at"`
+	EndAt                     time.Time `json:"end_at"`
+	Attempt                   int       `json:"attempt"`
+	ExtraAttempts             int       `json:"extra_attempts"`
+	ExtraTime                 int       `json:"extra_time"`
+	ManuallyUnlocked          bool      `json:"manually_unlocked"`
+	TimeSpent                 int       `json:"time_spent"`
+	Score                     float64   `json:"score"`
+	ScoreBeforeRegrade        float64   `json:"score_before_regrade"`
+	KeptScore                 float64   `json:"kept_score"`
+	FudgePoints               float64   `json:"fudge_points"`
+	HasSeenResults            bool      `json:"has_seen_results"`
+	WorkflowState             string    `json:"workflow_state"`
+	OverdueAndNeedsSubmission bool      `json:"overdue_and_needs_submission"`
+	// ValidationToken is only given to the user taking the quiz and
+	// is needed to answer questions and complete the submission.
+	ValidationToken string `json:"validation_token"`
+}
+
+// QuizStatistics are the statistics for all of a quiz's submissions.
+//
+// https://canvas.instructure.com/doc/api/quiz_statistics.html
+type QuizStatistics struct {
+	ID                    int       `json:"id"`
+	URL                   string    `json:"url"`
+	HTMLURL               string    `json:"html_url"`
+	MultipleAttemptsExist bool      `json:"multiple_attempts_exist"`
+	GeneratedAt           time.Time `json:"generated_at"`
+	IncludesAllVersions   bool      `json:"includes_all_versions"`
+	PointsPossible        float64   `json:"points_possible"`
+	SubmissionStatistics  struct {
+		ScoreAverage          float64 `json:"score_average"`
+		ScoreHigh             float64 `json:"score_high"`
+		ScoreLow              float64 `json:"score_low"`
+		ScoreStdev            float64 `json:"score_stdev"`
+		DurationAverage       float64 `json:"duration_average"`
+		UniqueCount           int     `json:"unique_count"`
+		CorrectCountAverage   float64 `json:"correct_count_average"`
+		IncorrectCountAverage float64 `json:"incorrect_count_average"`
+	} `json:"submission_statistics"`
+	// QuestionStatistics are different for each question type.
+	QuestionStatistics []map[string]interface{} `json:"question_statistics"`
+}
+
+// Questions will get the quiz's questions.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.index
+func (q *Quiz) Questions(opts ...Option) (questions []*QuizQuestion, err error) {
+	ch := make(chan *QuizQuestion)
+	errs := newPaginatedList(q.client, q.path("/questions"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			qq := &QuizQuestion{}
+			if err := dec.Decode(qq); err != nil {
+				return err
+			}
+			ch <- qq
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case qq := <-ch:
+			questions = append(questions, qq)
+		case err, ok := <-errs:
+			if !ok {
+				return questions, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// CreateQuestion will add a question to the quiz.
+//
+// https://canvas.instructure.com/doc/api/quiz_questions.html#method.quizzes/quiz_questions.create
+func (q *Quiz) CreateQuestion(question QuizQuestion) (*QuizQuestion, error) {
+	vals, err := question.values()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := post(q.client, q.path("/questions"), vals)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	qq := &QuizQuestion{}
+	return qq, decodeJSON(resp.Body, qq)
+}
+
+// Submissions will get all of the quiz's submissions.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.index
+func (q *Quiz) Submissions(opts ...Option) (subs []*QuizSubmission, err error) {
+	ch := make(chan *QuizSubmission)
+	errs := newPaginatedList(q.client, q.path("/submissions"), func(r io.Reader) error {
+		var res quizSubmissions
+		if err := decodeJSON(r, &res); err != nil {
+			return err
+		}
+		for _, s := range res.Submissions {
+			ch <- s
+		}
+		return nil
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case s := <-ch:
+			subs = append(subs, s)
+		case err, ok := <-errs:
+			if !ok {
+				return subs, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// StartSubmission will start taking the quiz as the current user. Use
+// Opt("access_code", code) for quizzes that need an access code.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.create
+func (q *Quiz) StartSubmission(opts ...Option) (*QuizSubmission, error) {
+	return q.sendSubmission(q.path("/submissions"), optEnc(opts))
+}
+
+// AnswerQuestions will save answers to the questions in a submission
+// that has been started. Answers are keyed by question id and their
+// format depends on the question type, e.g. the id of the chosen answer
+// for multiple choice questions or the text for essay questions.
+//
+// https://canvas.instructure.com/doc/api/quiz_submission_questions.html#method.quizzes/quiz_submission_questions.answer
+func (q *Quiz) AnswerQuestions(sub *QuizSubmission, answers map[int]interface{}) error {
+	type answer struct {
+		ID     string      `json:"id"`
+		Answer interface{} `json:"answer"`
+	}
+	body := struct {
+		Attempt         int      `json:"attempt"`
+		ValidationToken string   `json:"validation_token"`
+		Questions       []answer `json:"quiz_questions"`
+	}{Attempt: sub.Attempt, ValidationToken: sub.ValidationToken}
+	for id, a := range answers {
+		body.Questions = append(body.Questions, answer{ID: fmt.Sprint(id), Answer: a})
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req := newreq("POST", fmt.Sprintf("/quiz_submissions/%d/questions", sub.ID), nil)
+	req.Header = http.Header{"Content-Type": {"application/json"}}
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.ContentLength = int64(len(b))
+	resp, err := do(q.client, req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// CompleteSubmission will turn in a submission so that it can be graded.
+//
+// https://canvas.instructure.com/doc/api/quiz_submissions.html#method.quizzes/quiz_submissions_api.complete
+func (q *Quiz) CompleteSubmission(sub *QuizSubmission, opts ...Option) (*QuizSubmission, error) {
+	p := params{
+		"attempt":          {fmt.Sprint(sub.Attempt)},
+		"validation_token": {sub.ValidationToken},
+	}
+	p.Add(opts)
+	return q.sendSubmission(q.path(fmt.Sprintf("/submissions/%d/complete", sub.ID)), p)
+}
+
+// Statistics will get the statistics for the quiz's submissions.
+//
+// https://canvas.instructure.com/doc/api/quiz_statistics.html#method.quizzes/quiz_statistics.index
+func (q *Quiz) Statistics(opts ...Option) (*QuizStatistics, error) {
+	var res struct {
+		Statistics []*QuizStatistics `json:"quiz_statistics"`
+	}
+	if err := getjson(q.client, &res, optEnc(opts), "%s", q.path("/statistics")); err != nil {
+		return nil, err
+	}
+	if len(res.Statistics) == 0 {
+		return &QuizStatistics{}, nil
+	}
+	return res.Statistics[0], nil
+}
+
+// quizSubmissions is how canvas wraps quiz submissions.
+type quizSubmissions struct {
+	Submissions []*QuizSubmission `json:"quiz_submissions"`
+}
+
+func (q *Quiz) sendSubmission(path string, vals encoder) (*QuizSubmission, error) {
+	resp, err := post(q.client, path, vals)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res quizSubmissions
+	if err = decodeJSON(resp.Body, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Submissions) == 0 {
+		return nil, fmt.Errorf("no quiz submission returned")
+	}
+	return res.Submissions[0], nil
+}
+
+func (q *Quiz) path(s string) string {
+	return fmt.Sprintf("/courses/%d/quizzes/%d", q.courseID, q.ID) + s
+}
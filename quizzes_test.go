@@ -0,0 +1,117 @@
+package canvas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestQuizzes(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/questions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `[{"id":10,"quiz_id":5,"question_type":"true_false_question"},{"id":11,"quiz_id":5}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("question[question_name]") != "Q3" ||
+				q.Get("question[question_type]") != "multiple_choice_question" ||
+				q.Get("question[answers][0][answer_text]") != "yes" ||
+				q.Get("question[answers][0][answer_weight]") != "100" ||
+				q.Get("question[answers][1][answer_text]") != "no" ||
+				q.Get("question[answers][1][answer_weight]") != "0" {
+				t.Errorf("wrong question: %v", q)
+			}
+			fmt.Fprint(w, `{"id":12,"quiz_id":5,"question_name":"Q3","answers":[{"id":1,"text":"yes","weight":100},{"id":2,"text":"no"}]}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/submissions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `{"quiz_submissions":[{"id":20,"user_id":2,"workflow_state":"complete","score":4}]}`)
+		case "POST":
+			if r.URL.Query().Get("access_code") != "secret" {
+				t.Error("no access code")
+			}
+			fmt.Fprint(w, `{"quiz_submissions":[{"id":21,"quiz_id":5,"attempt":1,"validation_token":"tok","workflow_state":"untaken"}]}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/quiz_submissions/21/questions", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		var body struct {
+			Attempt         int    `json:"attempt"`
+			ValidationToken string `json:"validation_token"`
+			Questions       []struct {
+				ID     string      `json:"id"`
+				Answer interface{} `json:"answer"`
+			} `json:"quiz_questions"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if body.Attempt != 1 || body.ValidationToken != "tok" || len(body.Questions) != 1 ||
+			body.Questions[0].ID != "10" || body.Questions[0].Answer != "true" {
+			t.Errorf("wrong answers: %+v", body)
+		}
+		fmt.Fprint(w, `{"quiz_submission_questions":[]}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/submissions/21/complete", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("attempt") != "1" || q.Get("validation_token") != "tok" {
+			t.Errorf("wrong params: %v", q)
+		}
+		fmt.Fprint(w, `{"quiz_submissions":[{"id":21,"quiz_id":5,"attempt":1,"workflow_state":"complete","score":1}]}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5/statistics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"quiz_statistics":[{"id":30,"points_possible":2,"submission_statistics":{"score_average":1.5,"unique_count":2}}]}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"title":"Quiz"}`)
+	})
+
+	c := &Course{ID: 1, client: client}
+	quiz, err := c.Quiz(5)
+	is.NoErr(err)
+	is.Equal(quiz.Title, "Quiz")
+
+	questions, err := quiz.Questions()
+	is.NoErr(err)
+	is.Equal(len(questions), 2)
+	is.Equal(questions[0].QuestionType, "true_false_question")
+
+	question, err := quiz.CreateQuestion(QuizQuestion{
+		QuestionName: "Q3",
+		QuestionType: "multiple_choice_question",
+		Answers:      []QuizAnswer{{Text: "yes", Weight: 100}, {Text: "no"}},
+	})
+	is.NoErr(err)
+	is.Equal(question.ID, 12)
+	is.Equal(len(question.Answers), 2)
+
+	subs, err := quiz.Submissions()
+	is.NoErr(err)
+	is.Equal(len(subs), 1)
+	is.Equal(subs[0].WorkflowState, QuizSubmissionComplete)
+	is.Equal(subs[0].Score, 4.0)
+
+	sub, err := quiz.StartSubmission(Opt("access_code", "secret"))
+	is.NoErr(err)
+	is.Equal(sub.ValidationToken, "tok")
+	is.NoErr(quiz.AnswerQuestions(sub, map[int]interface{}{10: "true"}))
+	sub, err = quiz.CompleteSubmission(sub)
+	is.NoErr(err)
+	is.Equal(sub.WorkflowState, QuizSubmissionComplete)
+
+	stats, err := quiz.Statistics()
+	is.NoErr(err)
+	is.Equal(stats.ID, 30)
+	is.Equal(stats.SubmissionStatistics.ScoreAverage, 1.5)
+}
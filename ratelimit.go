@@ -0,0 +1,250 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultRateLimitThreshold is the X-Rate-Limit-Remaining value that
+// paginated listings slow down at. Canvas starts every client with
+// a bucket of 700 units.
+const defaultRateLimitThreshold = 100
+
+// WithRateLimitThreshold sets the rate limit quota left that
+// paginated listings will pause at. Canvas sends the quota left in the
+// X-Rate-Limit-Remaining header of every response and once it drops
+// below the threshold the pager waits for the quota to refill before
+// requesting more pages. This keeps long crawls from being throttled.
+// The default threshold is 100 and a threshold of zero turns this off.
+// Use WithHooks to find out when listings are paused.
+func WithRateLimitThreshold(threshold float64) ClientOption {
+	return func(cc *clientConfig) {
+		cc.rateLimitThreshold = threshold
+	}
+}
+
+// Hooks are functions that are called when the client does
+// something that is worth knowing about, like waiting for the
+// rate limit. Any of the hooks can be nil.
+type Hooks struct {
+	// PagerPaused is called when a paginated listing
+	// waits for the rate limit quota to refill.
+	PagerPaused func(PagerEvent)
+	// PagerResumed is called when a paused listing starts
+	// requesting pages again.
+	PagerResumed func(PagerEvent)
+	// Throttled is called every time canvas throttles a request,
+	// including requests that are retried.
+	Throttled func(*RateLimitError)
+	// PageFetched is called after each page of a paginated listing
+	// is requested. It is called from the goroutines fetching the
+	// pages so it may be called concurrently.
+	PageFetched func(PageEvent)
+}
+
+// WithHooks sets hooks that are called by the client.
+func WithHooks(h Hooks) ClientOption {
+	return func(cc *clientConfig) {
+		cc.hooks = h
+	}
+}
+
+// PagerEvent is sent to the pager hooks.
+type PagerEvent struct {
+	// Path is the api path of the listing.
+	Path string
+	// Page is the page that is about to be requested.
+	Page int
+	// Remaining is the estimated rate limit quota left.
+	Remaining float64
+	// Wait is how long the listing is paused for.
+	Wait time.Duration
+}
+
+// PageEvent is sent to the PageFetched hook.
+type PageEvent struct {
+	// Path is the api path of the listing.
+	Path string
+	// Page is the page that was requested and Pages is the number
+	// of pages in the listing, which is zero for the first page.
+	Page  int
+	Pages int
+	// Duration is how long the request took.
+	Duration time.Duration
+	// Err is the error from the request if it failed.
+	Err error
+}
+
+// RateLimitError is returned when canvas throttles a request. Canvas
+// responds with 403 Forbidden (Rate Limit Exceeded) instead of 429
+// Too Many Requests. RateLimitErrors match ErrRateLimited when used
+// with errors.Is.
+type RateLimitError struct {
+	// Wait is how long to wait before trying again. It is found from
+	// the Retry-After header or from the time it takes for the quota
+	// to refill enough to pay for the request.
+	Wait time.Duration
+	// Remaining is the rate limit quota left.
+	Remaining float64
+	// Cost is the cost of the request that was throttled.
+	Cost float64
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v: retry in %v", ErrRateLimited, e.Wait)
+}
+
+// Is returns true when target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+func newRateLimitError(resp *http.Response) *RateLimitError {
+	e := &RateLimitError{Wait: throttleWait(resp.Header)}
+	e.Remaining, _ = strconv.ParseFloat(resp.Header.Get("X-Rate-Limit-Remaining"), 64)
+	e.Cost, _ = strconv.ParseFloat(resp.Header.Get("X-Request-Cost"), 64)
+	if e.Wait <= 0 {
+		e.Wait = defaultRetryWait
+	}
+	return e
+}
+
+// throttleWait returns how long a throttled request should wait before
+// it is sent again. Canvas only lets requests through once the quota
+// left is positive, so the wait covers the quota that is owed plus the
+// cost of the request. Zero is returned if canvas did not say.
+func throttleWait(h http.Header) time.Duration {
+	if after, ok := retryAfter(h.Get("Retry-After")); ok {
+		return after
+	}
+	var owed float64
+	if remaining, err := strconv.ParseFloat(h.Get("X-Rate-Limit-Remaining"), 64); err == nil && remaining < 0 {
+		owed = -remaining
+	}
+	if cost, err := strconv.ParseFloat(h.Get("X-Request-Cost"), 64); err == nil && cost > 0 {
+		owed += cost
+	}
+	return time.Duration(owed / rateLimitLeakRate * float64(time.Second))
+}
+
+// observe keeps track of the rate limit quota after every
+// response and calls the Throttled hook.
+func (c *client) observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	c.rate.record(resp)
+	if resp.StatusCode != http.StatusForbidden || !isThrottled(resp) {
+		return
+	}
+	e := newRateLimitError(resp)
+	c.rate.throttled(e.Wait)
+	if c.hooks.Throttled != nil {
+		c.hooks.Throttled(e)
+	}
+}
+
+// rateLimit keeps track of the rate limit quota left
+// from the X-Rate-Limit-Remaining header.
+type rateLimit struct {
+	threshold float64
+
+	mu        sync.Mutex
+	remaining float64
+	at        time.Time
+}
+
+// record saves the quota left after a response.
+func (rl *rateLimit) record(resp *http.Response) {
+	if rl == nil || resp == nil {
+		return
+	}
+	n, err := strconv.ParseFloat(resp.Header.Get("X-Rate-Limit-Remaining"), 64)
+	if err != nil {
+		return
+	}
+	rl.mu.Lock()
+	rl.remaining, rl.at = n, time.Now()
+	rl.mu.Unlock()
+}
+
+// throttled makes paginated listings wait at least as long as a
+// throttled request has to.
+func (rl *rateLimit) throttled(wait time.Duration) {
+	if rl == nil || rl.threshold <= 0 {
+		return
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	owed := rl.threshold - wait.Seconds()*rateLimitLeakRate
+	if rl.at.IsZero() || owed < rl.remaining {
+		rl.remaining = owed
+	}
+	rl.at = time.Now()
+}
+
+// wait returns the estimated quota left and how long to wait
+// for it to refill up to the threshold. Requests that are made
+// while waiting refill the quota more slowly so the wait is
+// reserved up front.
+func (rl *rateLimit) wait() (float64, time.Duration) {
+	if rl == nil || rl.threshold <= 0 {
+		return 0, 0
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if rl.at.IsZero() {
+		return 0, 0
+	}
+	now := time.Now()
+	// canvas leaks units out of the bucket over time
+	remaining := rl.remaining + now.Sub(rl.at).Seconds()*rateLimitLeakRate
+	if remaining >= rl.threshold {
+		return remaining, 0
+	}
+	wait := time.Duration((rl.threshold - remaining) / rateLimitLeakRate * float64(time.Second))
+	if wait > defaultMaxRetryWait {
+		wait = defaultMaxRetryWait
+	}
+	// assume the quota has refilled once the wait is over
+	rl.remaining, rl.at = rl.threshold, now.Add(wait)
+	return remaining, wait
+}
+
+// backpressure pauses the pager while the client's
+// rate limit quota is too low.
+func (p *paginated) backpressure(page int) error {
+	c, ok := unwrapDoer(p.do).(*client)
+	if !ok {
+		return nil
+	}
+	return c.waitForQuota(p.ctx, p.path, page)
+}
+
+// waitForQuota waits until the client's rate limit quota has refilled
+// up to the threshold and calls the pager hooks if it had to wait.
+func (c *client) waitForQuota(ctx context.Context, path string, page int) error {
+	remaining, wait := c.rate.wait()
+	if wait <= 0 {
+		return nil
+	}
+	ev := PagerEvent{Path: path, Page: page, Remaining: remaining, Wait: wait}
+	if c.hooks.PagerPaused != nil {
+		c.hooks.PagerPaused(ev)
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if c.hooks.PagerResumed != nil {
+		c.hooks.PagerResumed(ev)
+	}
+	return nil
+}
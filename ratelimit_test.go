@@ -0,0 +1,206 @@
+package canvas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPagerBackpressure(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses?page=3>; rel="last"`, DefaultHost))
+		// one unit below the threshold takes 100ms to refill
+		w.Header().Set("X-Rate-Limit-Remaining", "99")
+		fmt.Fprintf(w, `[{"id":%s}]`, r.URL.Query().Get("page"))
+	})
+	var (
+		mu      sync.Mutex
+		paused  []PagerEvent
+		resumed int
+	)
+	c := &Canvas{client: &client{
+		Client: *httpClient,
+		rate:   &rateLimit{threshold: 100},
+		hooks: Hooks{
+			PagerPaused: func(ev PagerEvent) {
+				mu.Lock()
+				paused = append(paused, ev)
+				mu.Unlock()
+			},
+			PagerResumed: func(PagerEvent) {
+				mu.Lock()
+				resumed++
+				mu.Unlock()
+			},
+		},
+	}}
+	start := time.Now()
+	courses, err := c.Courses(WithPrefetch(1))
+	is.NoErr(err)
+	is.Equal(len(courses), 3)
+	is.Equal(len(paused), 2) // pages 2 and 3 wait
+	is.Equal(resumed, 2)
+	is.Equal(paused[0].Path, "/courses")
+	is.Equal(paused[0].Page, 2)
+	is.True(paused[0].Remaining < 100)
+	is.True(paused[0].Wait > 50*time.Millisecond)
+	is.True(time.Since(start) >= 2*50*time.Millisecond)
+
+	// turned off
+	c.client.(*client).rate.threshold = 0
+	paused = nil
+	_, err = c.Courses()
+	is.NoErr(err)
+	is.Equal(len(paused), 0)
+
+	is.Equal(newClientConfig(nil).rateLimitThreshold, float64(defaultRateLimitThreshold))
+	is.Equal(newClientConfig([]ClientOption{WithRateLimitThreshold(0)}).rateLimitThreshold, 0.0)
+}
+
+func TestIteratorBackpressure(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses?page=%d>; rel="next"`, DefaultHost, page+1))
+		}
+		// one unit below the threshold takes 100ms to refill
+		w.Header().Set("X-Rate-Limit-Remaining", "99")
+		fmt.Fprintf(w, `[{"id":%d}]`, page)
+	})
+	var paused []PagerEvent
+	c := &Canvas{client: &client{
+		Client: *httpClient,
+		rate:   &rateLimit{threshold: 100},
+		hooks: Hooks{
+			PagerPaused: func(ev PagerEvent) { paused = append(paused, ev) },
+		},
+	}}
+	start := time.Now()
+	it := Paginate[*Course](c, "/courses")
+	var n int
+	for it.Next() {
+		n++
+		is.Equal(it.Value().ID, n)
+	}
+	is.NoErr(it.Err())
+	is.Equal(n, 3)
+	is.Equal(len(paused), 2) // pages 2 and 3 wait
+	is.Equal(paused[0].Path, "/courses")
+	is.Equal(paused[0].Page, 2)
+	is.Equal(paused[1].Page, 3)
+	is.True(time.Since(start) >= 2*50*time.Millisecond)
+}
+
+func TestPagerConcurrency(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	var inflight, most int32
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses?page=8>; rel="last"`, DefaultHost))
+		fmt.Fprintf(w, `[{"id":%s}]`, r.URL.Query().Get("page"))
+	})
+	var (
+		mu     sync.Mutex
+		events []PageEvent
+	)
+	c := &Canvas{client: &client{
+		Client:      *httpClient,
+		concurrency: 2,
+		hooks: Hooks{PageFetched: func(ev PageEvent) {
+			mu.Lock()
+			events = append(events, ev)
+			mu.Unlock()
+		}},
+	}}
+	courses, err := c.Courses()
+	is.NoErr(err)
+	is.Equal(len(courses), 8)
+	is.True(atomic.LoadInt32(&most) <= 2)
+	is.Equal(len(events), 8)
+	for _, ev := range events {
+		is.Equal(ev.Path, "/courses")
+		is.NoErr(ev.Err)
+		is.True(ev.Duration >= 10*time.Millisecond)
+		if ev.Page == 1 {
+			is.Equal(ev.Pages, 0)
+		} else {
+			is.Equal(ev.Pages, 8)
+		}
+	}
+	is.Equal(newClientConfig([]ClientOption{WithConcurrency(3)}).concurrency, 3)
+}
+
+func TestRateLimitError(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/throttled", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit-Remaining", "-5")
+		w.Header().Set("X-Request-Cost", "15")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "403 Forbidden (Rate Limit Exceeded)\n")
+	})
+	mux.HandleFunc("/api/v1/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"status":"unauthorized","message":"user not authorized to perform that action"}`)
+	})
+	var throttled []*RateLimitError
+	c := &client{
+		Client: *httpClient,
+		rate:   &rateLimit{threshold: 100},
+		hooks:  Hooks{Throttled: func(e *RateLimitError) { throttled = append(throttled, e) }},
+	}
+
+	_, err := get(c, "/throttled", nil)
+	var apiErr *APIError
+	is.True(errors.As(err, &apiErr))
+	is.True(IsRateLimit(err))
+	is.True(IsRateLimit(apiErr))
+	is.True(errors.Is(err, ErrRateLimitExceeded))
+	var rlErr *RateLimitError
+	is.True(errors.As(err, &rlErr))
+	is.Equal(rlErr.Wait, 2*time.Second) // 5 owed plus 15 for the request
+	is.Equal(rlErr.Remaining, -5.0)
+	is.Equal(len(throttled), 1)
+	// the pager waits for the quota to refill
+	_, wait := c.rate.wait()
+	is.True(wait >= 2*time.Second)
+
+	_, err = get(c, "/forbidden", nil)
+	is.True(err != nil)
+	is.True(!IsRateLimit(err))
+	var e *Error
+	is.True(errors.As(err, &e))
+	is.Equal(e.Message, "user not authorized to perform that action")
+
+	h := http.Header{}
+	h.Set("Retry-After", "3")
+	is.Equal(throttleWait(h), 3*time.Second)
+	is.Equal(throttleWait(http.Header{}), time.Duration(0))
+}
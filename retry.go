@@ -0,0 +1,260 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultRetryWait is the time waited before the first retry.
+	defaultRetryWait = 500 * time.Millisecond
+	// defaultMaxRetryWait is the longest time waited between retries.
+	defaultMaxRetryWait = 30 * time.Second
+
+	// rateLimitLeakRate is the number of units that canvas
+	// removes from a rate limit bucket every second.
+	rateLimitLeakRate = 10
+)
+
+// WithRetries will retry failed requests up to n times.
+//
+// Requests that only read data (GET, HEAD, and OPTIONS) are retried
+// after network errors and 429, 502, 503, and 504 responses. Requests
+// that change data are only retried when it is certain that canvas
+// never received them, either because a connection could not be made
+// or because canvas responded with 429 Too Many Requests. This means
+// that retries will never create duplicate assignments or comments. Use
+// the ForceRetry option to retry a request that changes data anyway.
+//
+// Canvas throttles clients that make too many requests by responding
+// with 403 Forbidden (Rate Limit Exceeded). These responses are always
+// retried since canvas never processed the request.
+//
+// The wait between retries doubles after every attempt and includes
+// some random jitter so that concurrent requests do not all retry at
+// once. The Retry-After header is honored when canvas sends it and
+// throttled requests wait long enough for their cost, found in the
+// X-Request-Cost header, to drain from the rate limit bucket. See
+// WithRetryBackoff to change how long requests wait.
+//
+// File uploads that fail part way through are checked for on canvas
+// before being sent again.
+func WithRetries(n int) ClientOption {
+	return func(cc *clientConfig) {
+		cc.retries = n
+	}
+}
+
+// WithRetryBackoff sets the time waited before the first retry and the
+// longest time that will be waited between any two retries. Requests
+// that canvas asks to wait longer than max using Retry-After are not
+// retried. It has no effect unless WithRetries is also used.
+func WithRetryBackoff(base, max time.Duration) ClientOption {
+	return func(cc *clientConfig) {
+		cc.retryWait = base
+		cc.maxRetryWait = max
+	}
+}
+
+// ForceRetry is an Option that marks a request as safe to retry even
+// if it changes data on canvas. Only use this for requests that can
+// be repeated without side effects. It has no effect unless the client
+// was created using WithRetries.
+var ForceRetry Option = &requestOption{apply: withForceRetry}
+
+// requestOption is an Option that changes the http request
+// and is never sent to canvas.
+type requestOption struct {
+	apply func(*http.Request) *http.Request
+}
+
+func (ro *requestOption) Name() string    { return "" }
+func (ro *requestOption) Value() []string { return nil }
+
+type forceRetryKey struct{}
+
+func withForceRetry(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), forceRetryKey{}, true))
+}
+
+func isForceRetry(r *http.Request) bool {
+	force, _ := r.Context().Value(forceRetryKey{}).(bool)
+	return force
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// retries returns the number of times requests
+// should be retried by the doer.
+func retries(d doer) int {
+	if c, ok := unwrapDoer(d).(*client); ok {
+		return c.retries
+	}
+	return 0
+}
+
+// waitRetry sleeps for the doer's retry backoff before the
+// next attempt. It returns early if ctx is cancelled.
+func waitRetry(ctx context.Context, d doer, attempt int) error {
+	c, ok := unwrapDoer(d).(*client)
+	if !ok {
+		return ctx.Err()
+	}
+	wait, _ := c.retryDelay(attempt, nil)
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *client) doRetry(r *http.Request) (*http.Response, error) {
+	safe := idempotent(r.Method) || isForceRetry(r)
+	for attempt := 0; ; attempt++ {
+		req := r
+		if attempt > 0 && r.Body != nil {
+			body, err := r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = r.Clone(r.Context())
+			req.Body = body
+		}
+		// A request was never sent if we never got a connection.
+		var connected bool
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+			GotConn: func(httptrace.GotConnInfo) { connected = true },
+		}))
+		resp, err := c.Client.Do(req)
+		c.observe(resp)
+		if attempt >= c.retries || !shouldRetry(r, resp, err, safe, connected) {
+			return resp, err
+		}
+		wait, ok := c.retryDelay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+	}
+}
+
+// retryDelay returns the time to wait before the next attempt. It
+// returns false if canvas asked for a longer wait than is allowed.
+func (c *client) retryDelay(attempt int, resp *http.Response) (time.Duration, bool) {
+	max := c.maxRetryWait
+	if max <= 0 {
+		max = defaultMaxRetryWait
+	}
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return after, after <= max
+		}
+	}
+	wait := c.retryWait
+	for i := 0; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	var jitter time.Duration
+	if half := int64(wait / 2); half > 0 {
+		jitter = time.Duration(rand.Int63n(half + 1))
+	}
+	if resp != nil && resp.StatusCode == http.StatusForbidden {
+		// the request cost has to drain from the bucket before
+		// anything else is allowed so never wait less than that
+		if drain := throttleWait(resp.Header); drain > 0 {
+			if drain+jitter > max {
+				return max, true
+			}
+			return drain + jitter, true
+		}
+	}
+	// wait somewhere between half and all of the backoff
+	return wait - wait/2 + jitter, true
+}
+
+// retryAfter parses the Retry-After header which is
+// either a number of seconds or an http date.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// isThrottled returns true if the response is canvas
+// rejecting a request because of its rate limit.
+func isThrottled(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	if remaining := resp.Header.Get("X-Rate-Limit-Remaining"); remaining != "" {
+		if n, err := strconv.ParseFloat(remaining, 64); err == nil && n <= 0 {
+			return true
+		}
+	}
+	// Peek at the body and put it back so that
+	// other 403 errors can still be decoded.
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+	return bytes.Contains(bytes.ToLower(b), []byte("rate limit exceeded"))
+}
+
+func shouldRetry(r *http.Request, resp *http.Response, err error, safe, connected bool) bool {
+	if r.Body != nil && r.GetBody == nil {
+		// we can't send the body again
+		return false
+	}
+	if err != nil {
+		if r.Context().Err() != nil {
+			return false
+		}
+		return safe || !connected
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return isThrottled(resp)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return safe
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package canvas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func retryClient(c *http.Client, n int) *client {
+	return &client{Client: *c, retries: n, retryWait: time.Millisecond}
+}
+
+func TestRetries(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	c := retryClient(httpClient, 3)
+
+	var hits int32
+	failFirst := func(n int32, status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&hits, 1) <= n {
+				w.WriteHeader(status)
+				fmt.Fprint(w, `{"message":"try again"}`)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		}
+	}
+	mux.HandleFunc("/api/v1/unavailable", failFirst(2, http.StatusServiceUnavailable))
+	mux.HandleFunc("/api/v1/throttled", failFirst(1, http.StatusTooManyRequests))
+
+	resp, err := get(c, "/unavailable", nil)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(atomic.LoadInt32(&hits), int32(3))
+
+	// post requests might have been processed by canvas
+	atomic.StoreInt32(&hits, 0)
+	_, err = post(c, "/unavailable", nil)
+	is.True(err != nil)
+	is.Equal(atomic.LoadInt32(&hits), int32(1))
+
+	atomic.StoreInt32(&hits, 0)
+	resp, err = post(c, "/unavailable", optEnc{ForceRetry})
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(atomic.LoadInt32(&hits), int32(3))
+
+	// 429 means the request was never processed
+	atomic.StoreInt32(&hits, 0)
+	resp, err = post(c, "/throttled", nil)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(atomic.LoadInt32(&hits), int32(2))
+
+	atomic.StoreInt32(&hits, 0)
+	_, err = get(retryClient(httpClient, 1), "/unavailable", nil)
+	is.True(err != nil)
+	is.Equal(atomic.LoadInt32(&hits), int32(2))
+}
+
+func TestRetries_NotSent(t *testing.T) {
+	is := is.New(t)
+	var hits, dials int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+	c := &client{
+		Client: http.Client{Transport: &TestingTransport{&http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				if atomic.AddInt32(&dials, 1) == 1 {
+					return nil, errors.New("connection refused")
+				}
+				return net.Dial("tcp", server.Listener.Addr().String())
+			},
+		}}},
+		host:      "canvas.test",
+		retries:   2,
+		retryWait: time.Millisecond,
+	}
+	resp, err := post(c, "/assignments", nil)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(atomic.LoadInt32(&hits), int32(1))
+	is.Equal(atomic.LoadInt32(&dials), int32(2))
+}
+
+func TestUploadRetry(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	var uploads int32
+	mux.HandleFunc("/api/v1/users/self/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			if r.URL.Query().Get("search_term") != "notes.txt" {
+				t.Error("should search for the file name")
+			}
+			fmt.Fprintf(w, `[{"id":3,"display_name":"notes.txt","size":5,"created_at":%q}]`,
+				time.Now().UTC().Format(time.RFC3339))
+			return
+		}
+		fmt.Fprint(w, `{"upload_url":"https://uploads.example.com/upload","file_param":"file"}`)
+	})
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&uploads, 1)
+		// the file is saved but the response fails
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	c := &Canvas{client: retryClient(httpClient, 3)}
+	file, err := c.UploadFile("notes.txt", strings.NewReader("hello"))
+	is.NoErr(err)
+	is.Equal(file.ID, 3)
+	is.Equal(atomic.LoadInt32(&uploads), int32(1))
+}
+
+func TestUploadRetryBackoff(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	var uploads int32
+	mux.HandleFunc("/api/v1/users/self/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `{"upload_url":"https://uploads.example.com/upload","file_param":"file"}`)
+	})
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&uploads, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":4,"display_name":"notes.txt"}`)
+	})
+	rc := retryClient(httpClient, 3)
+	rc.retryWait = 20 * time.Millisecond
+	c := &Canvas{client: rc}
+	start := time.Now()
+	file, err := c.UploadFile("notes.txt", strings.NewReader("hello"))
+	is.NoErr(err)
+	is.Equal(file.ID, 4)
+	is.Equal(atomic.LoadInt32(&uploads), int32(3))
+	// waits at least half of 20ms then half of 40ms
+	is.True(time.Since(start) >= 30*time.Millisecond)
+}
+
+func TestRetries_RateLimit(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	c := retryClient(httpClient, 3)
+	var hits int32
+	mux.HandleFunc("/api/v1/throttled", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("X-Request-Cost", "0.01")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "403 Forbidden (Rate Limit Exceeded)\n")
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/api/v1/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"status":"unauthorized"}`)
+	})
+
+	// throttled requests were never processed so posts are retried
+	resp, err := post(c, "/throttled", nil)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(atomic.LoadInt32(&hits), int32(2))
+
+	atomic.StoreInt32(&hits, 0)
+	_, err = get(c, "/forbidden", nil)
+	is.True(err != nil)
+	is.Equal(atomic.LoadInt32(&hits), int32(1))
+}
+
+func TestRetryDelay(t *testing.T) {
+	is := is.New(t)
+	c := &client{retryWait: 100 * time.Millisecond, maxRetryWait: time.Second}
+	between := func(d, min, max time.Duration) bool { return d >= min && d <= max }
+
+	wait, ok := c.retryDelay(0, nil)
+	is.True(ok)
+	is.True(between(wait, 50*time.Millisecond, 100*time.Millisecond))
+	wait, _ = c.retryDelay(2, nil)
+	is.True(between(wait, 200*time.Millisecond, 400*time.Millisecond))
+	wait, _ = c.retryDelay(20, nil)
+	is.True(between(wait, 500*time.Millisecond, time.Second)) // capped
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "1")
+	wait, ok = c.retryDelay(0, resp)
+	is.True(ok)
+	is.Equal(wait, time.Second)
+	resp.Header.Set("Retry-After", "120")
+	_, ok = c.retryDelay(0, resp)
+	is.True(!ok) // longer than the max wait
+
+	// the request cost has to drain from the bucket
+	resp = &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("X-Request-Cost", "6")
+	for i := 0; i < 20; i++ {
+		wait, _ = c.retryDelay(0, resp)
+		is.True(between(wait, 600*time.Millisecond, 650*time.Millisecond))
+	}
+}
@@ -0,0 +1,418 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rubric is a rubric that can be used to grade assignments.
+type Rubric struct {
+	ID                        int              `json:"id"`
+	Title                     string           `json:"title"`
+	ContextID                 int              `json:"context_id"`
+	ContextType               string           `json:"context_type"`
+	PointsPossible            float64          `json:"points_possible"`
+	Reusable                  bool             `json:"reusable"`
+	ReadOnly                  bool             `json:"read_only"`
+	FreeFormCriterionComments bool             `json:"free_form_criterion_comments"`
+	HideScoreTotal            bool             `json:"hide_score_total"`
+	Data                      []RubricCriteria `json:"data"`
+	// Associations are only set when the rubric is fetched with
+	// IncludeOpt("assignment_associations") or IncludeOpt("course_associations").
+	Associations []*RubricAssociation `json:"associations"`
+}
+
+// RubricAssociation links a rubric to an assignment or course.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#RubricAssociation
+type RubricAssociation struct {
+	ID       int `json:"id"`
+	RubricID int `json:"rubric_id"`
+	// AssociationID is the id of the assignment or course and
+	// AssociationType is "Assignment", "Course", or "Account".
+	AssociationID   int    `json:"association_id"`
+	AssociationType string `json:"association_type"`
+	UseForGrading   bool   `json:"use_for_grading"`
+	// Purpose is "grading" or "bookmark".
+	Purpose            string `json:"purpose"`
+	HideScoreTotal     bool   `json:"hide_score_total"`
+	HidePoints         bool   `json:"hide_points"`
+	HideOutcomeResults bool   `json:"hide_outcome_results"`
+}
+
+// Rubrics will get the course's rubrics.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics_api.index
+func (c *Course) Rubrics(opts ...Option) (rubrics []*Rubric, err error) {
+	ch := make(chan *Rubric)
+	errs := newPaginatedList(c.client, c.id("/courses/%d/rubrics"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			rb := &Rubric{}
+			if err := dec.Decode(rb); err != nil {
+				return err
+			}
+			ch <- rb
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case rb := <-ch:
+			rubrics = append(rubrics, rb)
+		case err, ok := <-errs:
+			if !ok {
+				return rubrics, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// Rubric import states.
+const (
+	RubricImportCreated             = "created"
+	RubricImportImporting           = "importing"
+	RubricImportSucceeded           = "succeeded"
+	RubricImportSucceededWithErrors = "succeeded_with_errors"
+	RubricImportFailed              = "failed"
+)
+
+// RubricImport is the status of a rubric csv import.
+type RubricImport struct {
+	ID            int    `json:"id"`
+	CourseID      int    `json:"course_id"`
+	WorkflowState string `json:"workflow_state"`
+	// Progress is the percent of the import that is done.
+	Progress   int `json:"progress"`
+	ErrorCount int `json:"error_count"`
+	ErrorData  []struct {
+		Message string `json:"message"`
+	} `json:"error_data"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Done returns true if the import has stopped running.
+func (ri *RubricImport) Done() bool {
+	switch ri.WorkflowState {
+	case RubricImportSucceeded, RubricImportSucceededWithErrors, RubricImportFailed:
+		return true
+	}
+	return false
+}
+
+// Err returns an error made from the import's error
+// messages or nil if there were no errors.
+func (ri *RubricImport) Err() error {
+	if ri.ErrorCount == 0 && len(ri.ErrorData) == 0 && ri.WorkflowState != RubricImportFailed {
+		return nil
+	}
+	msgs := make([]string, 0, len(ri.ErrorData))
+	for _, e := range ri.ErrorData {
+		msgs = append(msgs, e.Message)
+	}
+	if len(msgs) == 0 {
+		return fmt.Errorf("rubric import %d: %s", ri.ID, ri.WorkflowState)
+	}
+	return fmt.Errorf("rubric import %d: %s", ri.ID, strings.Join(msgs, ", "))
+}
+
+// ImportRubricCSV will upload a csv file of rubrics to the course. The
+// import runs in the background on canvas so the import returned should
+// be checked using RubricImport or WaitRubricImport. The format is the
+// same as the one written by WriteRubricCSV.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics_api.upload
+func (c *Course) ImportRubricCSV(r io.Reader) (*RubricImport, error) {
+	req, err := newAttachmentReq(c.id("/courses/%d/rubrics/upload"), "rubrics.csv", r)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(c.client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	imp := &RubricImport{}
+	return imp, decodeJSON(resp.Body, imp)
+}
+
+// newAttachmentReq creates a POST request that sends the contents of r
+// as the "attachment" file of a multipart form. The body is buffered so
+// that the request can be retried.
+func newAttachmentReq(path, filename string, r io.Reader) (*http.Request, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	form, err := w.CreateFormFile("attachment", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(form, r); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	b := body.Bytes()
+	req := newreq("POST", path, nil)
+	req.Header = http.Header{"Content-Type": {w.FormDataContentType()}}
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.ContentLength = int64(len(b))
+	return req, nil
+}
+
+// RubricImport will get the status of a rubric import.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubrics_api.upload_status
+func (c *Course) RubricImport(id int) (imp *RubricImport, err error) {
+	imp = &RubricImport{}
+	return imp, getjson(c.client, imp, nil, "/courses/%d/rubrics/upload/%d", c.ID, id)
+}
+
+// WaitRubricImport will check on a rubric import until it is done or
+// the context is cancelled. An error is returned if the import finished
+// with errors.
+func (c *Course) WaitRubricImport(ctx context.Context, id int) (*RubricImport, error) {
+	imp, err := c.RubricImport(id)
+	if err != nil {
+		return nil, err
+	}
+	err = poll(ctx, imp.Done, func() error {
+		return getjson(c.client, imp, nil, "/courses/%d/rubrics/upload/%d", c.ID, id)
+	})
+	if ctx.Err() != nil {
+		return imp, ctx.Err()
+	} else if err != nil {
+		return nil, err
+	}
+	return imp, imp.Err()
+}
+
+// AttachRubric will use one of the course's rubrics for the assignment,
+// replacing the rubric the assignment had before. The rubric's scores
+// are used for the assignment's grades when useForGrading is true and
+// hidePoints hides the points of the rubric from students.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_associations.create
+func (a *Assignment) AttachRubric(rubricID int, useForGrading, hidePoints bool) (*RubricAssociation, error) {
+	q := params{
+		"rubric_association[rubric_id]":        {strconv.Itoa(rubricID)},
+		"rubric_association[association_id]":   {strconv.Itoa(a.ID)},
+		"rubric_association[association_type]": {"Assignment"},
+		"rubric_association[purpose]":          {"grading"},
+		"rubric_association[use_for_grading]":  {strconv.FormatBool(useForGrading)},
+		"rubric_association[hide_points]":      {strconv.FormatBool(hidePoints)},
+	}
+	resp, err := post(a.client, fmt.Sprintf("/courses/%d/rubric_associations", a.CourseID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res struct {
+		Association *RubricAssociation `json:"rubric_association"`
+	}
+	if err = decodeJSON(resp.Body, &res); err != nil {
+		return nil, err
+	}
+	if res.Association == nil {
+		return nil, errors.New("no rubric association returned")
+	}
+	a.UseRubricForGrading = useForGrading
+	return res.Association, nil
+}
+
+// DetachRubric will remove the rubric from the assignment. The rubric is
+// not deleted and stays in the course. It does nothing if the assignment
+// has no rubric.
+//
+// https://canvas.instructure.com/doc/api/rubrics.html#method.rubric_associations.destroy
+func (a *Assignment) DetachRubric() error {
+	rubricID, err := a.rubricID()
+	if err != nil || rubricID == 0 {
+		return err
+	}
+	rubric := &Rubric{}
+	err = getjson(a.client, rubric, optEnc{IncludeOpt("assignment_associations")},
+		"/courses/%d/rubrics/%d", a.CourseID, rubricID)
+	if err != nil {
+		return err
+	}
+	for _, ra := range rubric.Associations {
+		if ra.AssociationType != "Assignment" || ra.AssociationID != a.ID {
+			continue
+		}
+		resp, err := delete(a.client, fmt.Sprintf("/courses/%d/rubric_associations/%d", a.CourseID, ra.ID), nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+	}
+	a.UseRubricForGrading = false
+	a.RubricSettings, a.Rubric = nil, nil
+	return nil
+}
+
+// rubricID returns the id of the assignment's rubric from its rubric
+// settings. The assignment is fetched again if the settings are missing.
+func (a *Assignment) rubricID() (int, error) {
+	id := func(settings interface{}) int {
+		m, ok := settings.(map[string]interface{})
+		if !ok {
+			return 0
+		}
+		n, _ := m["id"].(float64)
+		return int(n)
+	}
+	if n := id(a.RubricSettings); n != 0 {
+		return n, nil
+	}
+	var latest struct {
+		RubricSettings interface{} `json:"rubric_settings"`
+	}
+	if err := getjson(a.client, &latest, nil, a.path("")); err != nil {
+		return 0, err
+	}
+	return id(latest.RubricSettings), nil
+}
+
+// ExportRubricCSV will write all of the course's rubrics
+// as a csv file that can be imported with ImportRubricCSV.
+func (c *Course) ExportRubricCSV(w io.Writer, opts ...Option) error {
+	rubrics, err := c.Rubrics(opts...)
+	if err != nil {
+		return err
+	}
+	return WriteRubricCSV(w, rubrics)
+}
+
+var rubricCSVHeader = []string{
+	"Rubric Name",
+	"Criteria Name",
+	"Criteria Description",
+	"Criteria Enable Range",
+}
+
+var rubricRatingHeader = []string{
+	"Rating Name",
+	"Rating Description",
+	"Rating Points",
+}
+
+// WriteRubricCSV will write rubrics in the csv format that canvas
+// uses for rubric imports. Each row is one criterion followed by a
+// name, description, and points column for each of its ratings.
+func WriteRubricCSV(w io.Writer, rubrics []*Rubric) error {
+	var ratings int
+	for _, rb := range rubrics {
+		for _, c := range rb.Data {
+			if len(c.Ratings) > ratings {
+				ratings = len(c.Ratings)
+			}
+		}
+	}
+	header := append([]string{}, rubricCSVHeader...)
+	for i := 0; i < ratings; i++ {
+		header = append(header, rubricRatingHeader...)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, rb := range rubrics {
+		for _, c := range rb.Data {
+			row := []string{
+				rb.Title,
+				c.Description,
+				c.LongDescription,
+				strconv.FormatBool(c.CriterionUseRange),
+			}
+			for _, r := range c.Ratings {
+				row = append(row,
+					r.Description,
+					r.LongDescription,
+					strconv.FormatFloat(r.Points, 'f', -1, 64),
+				)
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadRubricCSV will read rubrics in the format written by
+// WriteRubricCSV. Rows with the same rubric name are combined
+// into one rubric.
+func ReadRubricCSV(r io.Reader) ([]*Rubric, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(header) < len(rubricCSVHeader) {
+		return nil, errors.New("rubric csv has too few columns")
+	}
+	var (
+		rubrics []*Rubric
+		byName  = make(map[string]*Rubric)
+	)
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return rubrics, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(row) < len(rubricCSVHeader) {
+			return nil, fmt.Errorf("rubric csv: row has %d columns", len(row))
+		}
+		rb, ok := byName[row[0]]
+		if !ok {
+			rb = &Rubric{Title: row[0]}
+			byName[row[0]] = rb
+			rubrics = append(rubrics, rb)
+		}
+		c := RubricCriteria{
+			Description:     row[1],
+			LongDescription: row[2],
+		}
+		c.CriterionUseRange, _ = strconv.ParseBool(row[3])
+		for i := len(rubricCSVHeader); i+2 < len(row); i += 3 {
+			if row[i] == "" && row[i+2] == "" {
+				continue
+			}
+			points, err := strconv.ParseFloat(row[i+2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("rubric csv: bad rating points: %w", err)
+			}
+			c.Ratings = append(c.Ratings, RubricRating{
+				Description:     row[i],
+				LongDescription: row[i+1],
+				Points:          points,
+			})
+			if points > c.Points {
+				c.Points = points
+			}
+		}
+		rb.PointsPossible += c.Points
+		rb.Data = append(rb.Data, c)
+	}
+}
@@ -0,0 +1,125 @@
+package canvas
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRubricCSV(t *testing.T) {
+	is := is.New(t)
+	rubrics := []*Rubric{{Title: "Essay", Data: []RubricCriteria{
+		{Description: "Thesis", LongDescription: "Has a clear thesis", Ratings: []RubricRating{
+			{Description: "Full", Points: 5},
+			{Description: "None", Points: 0},
+		}},
+		{Description: "Grammar", Ratings: []RubricRating{{Description: "Good", Points: 2.5}}},
+	}}}
+	var buf bytes.Buffer
+	is.NoErr(WriteRubricCSV(&buf, rubrics))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	is.Equal(len(lines), 3)
+	is.True(strings.HasPrefix(lines[0], "Rubric Name,Criteria Name,Criteria Description,Criteria Enable Range,Rating Name"))
+	is.Equal(lines[2], "Essay,Grammar,,false,Good,,2.5")
+
+	read, err := ReadRubricCSV(&buf)
+	is.NoErr(err)
+	is.Equal(len(read), 1)
+	is.Equal(read[0].Title, "Essay")
+	is.Equal(read[0].PointsPossible, 7.5)
+	is.Equal(read[0].Data[0].Ratings, rubrics[0].Data[0].Ratings)
+	is.Equal(read[0].Data[1].Ratings, rubrics[0].Data[1].Ratings)
+}
+
+func TestImportRubricCSV(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	defer func(d time.Duration) { progressPollInterval = d }(progressPollInterval)
+	progressPollInterval = time.Millisecond
+
+	mux.HandleFunc("/api/v1/courses/1/rubrics/upload", func(w http.ResponseWriter, r *http.Request) {
+		f, _, err := r.FormFile("attachment")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b, _ := ioutil.ReadAll(f)
+		if !strings.HasPrefix(string(b), "Rubric Name") {
+			t.Error("wrong file contents")
+		}
+		fmt.Fprint(w, `{"id":5,"workflow_state":"created"}`)
+	})
+	var polls int32
+	mux.HandleFunc("/api/v1/courses/1/rubrics/upload/5", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) < 3 {
+			fmt.Fprint(w, `{"id":5,"workflow_state":"importing","progress":50}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":5,"workflow_state":"succeeded_with_errors","error_count":1,"error_data":[{"message":"missing points"}]}`)
+	})
+	c := &Course{ID: 1, client: client}
+	imp, err := c.ImportRubricCSV(strings.NewReader("Rubric Name,Criteria Name,Criteria Description,Criteria Enable Range\n"))
+	is.NoErr(err)
+	is.Equal(imp.ID, 5)
+	is.True(!imp.Done())
+	imp, err = c.WaitRubricImport(context.Background(), imp.ID)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "missing points"))
+	is.True(imp.Done())
+	is.Equal(atomic.LoadInt32(&polls), int32(3))
+}
+
+func TestAttachRubric(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/rubric_associations", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("rubric_association[rubric_id]") != "5" ||
+			q.Get("rubric_association[association_id]") != "2" ||
+			q.Get("rubric_association[association_type]") != "Assignment" ||
+			q.Get("rubric_association[use_for_grading]") != "true" ||
+			q.Get("rubric_association[hide_points]") != "false" {
+			t.Errorf("wrong query parameters: %v", q)
+		}
+		fmt.Fprint(w, `{"rubric":{"id":5},"rubric_association":{"id":8,"rubric_id":5,"association_id":2,"association_type":"Assignment","use_for_grading":true}}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"course_id":1,"rubric_settings":{"id":5,"title":"Essay"}}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/rubrics/5", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include[]") != "assignment_associations" {
+			t.Error("associations not included")
+		}
+		fmt.Fprint(w, `{"id":5,"associations":[
+			{"id":7,"association_id":3,"association_type":"Assignment"},
+			{"id":8,"association_id":2,"association_type":"Assignment"}]}`)
+	})
+	var deleted []string
+	mux.HandleFunc("/api/v1/courses/1/rubric_associations/", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "DELETE")
+		deleted = append(deleted, r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	})
+
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	ra, err := a.AttachRubric(5, true, false)
+	is.NoErr(err)
+	is.Equal(ra.ID, 8)
+	is.True(ra.UseForGrading)
+	is.True(a.UseRubricForGrading)
+
+	is.NoErr(a.DetachRubric())
+	is.Equal(deleted, []string{"/api/v1/courses/1/rubric_associations/8"})
+	is.True(!a.UseRubricForGrading)
+}
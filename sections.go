@@ -0,0 +1,208 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// Section is a course section.
+type Section struct {
+	ID                                int       `json:"id" url:"-"`
+	Name                              string    `json:"name" url:"name,omitempty"`
+	SisSectionID                      string    `json:"sis_section_id" url:"sis_section_id,omitempty"`
+	SisCourseID                       string    `json:"sis_course_id" url:"-"`
+	IntegrationID                     string    `json:"integration_id" url:"integration_id,omitempty"`
+	SisImportID                       int       `json:"sis_import_id" url:"-"`
+	CourseID                          int       `json:"course_id" url:"-"`
+	NonxlistCourseID                  int       `json:"nonxlist_course_id" url:"-"`
+	StartAt                           time.Time `json:"start_at" url:"start_at,omitempty"`
+	EndAt                             time.Time `json:"end_at" url:"end_at,omitempty"`
+	RestrictEnrollmentsToSectionDates bool      `json:"restrict_enrollments_to_section_dates" url:"restrict_enrollments_to_section_dates,omitempty"`
+	TotalStudents                     int       `json:"total_students" url:"-"`
+
+	client doer
+}
+
+type sectionOptions struct {
+	Section `url:"course_section"`
+}
+
+// Sections will get the course's sections. Use IncludeOpt("students")
+// or IncludeOpt("total_students") to get more about each section.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.index
+func (c *Course) Sections(opts ...Option) (sections []*Section, err error) {
+	ch := make(chan *Section)
+	errs := newPaginatedList(c.client, c.id("/courses/%d/sections"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			s := &Section{client: c.client}
+			if err := dec.Decode(s); err != nil {
+				return err
+			}
+			ch <- s
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case s := <-ch:
+			sections = append(sections, s)
+		case err, ok := <-errs:
+			if !ok {
+				return sections, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// Section will get one of the course's sections.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.show
+func (c *Course) Section(id int, opts ...Option) (*Section, error) {
+	s := &Section{client: c.client}
+	return s, getjson(c.client, s, optEnc(opts), "/courses/%d/sections/%d", c.ID, id)
+}
+
+// CreateSection will create a section in the course.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.create
+func (c *Course) CreateSection(s Section, opts ...Option) (*Section, error) {
+	return sendSection(c.client, "POST", c.id("/courses/%d/sections"), &s, opts)
+}
+
+// EditSection will update a section.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.update
+func (c *Course) EditSection(s *Section, opts ...Option) (*Section, error) {
+	return sendSection(c.client, "PUT", fmt.Sprintf("/sections/%d", s.ID), s, opts)
+}
+
+// DeleteSection will delete a section. Sections
+// with enrollments cannot be deleted.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.destroy
+func (c *Course) DeleteSection(s *Section) (*Section, error) {
+	return c.DeleteSectionByID(s.ID)
+}
+
+// DeleteSectionByID will delete a section given its id.
+func (c *Course) DeleteSectionByID(id int) (*Section, error) {
+	resp, err := delete(c.client, fmt.Sprintf("/sections/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	s := &Section{client: c.client}
+	return s, decodeJSON(resp.Body, s)
+}
+
+// CrossListSection will move a section into another course. The
+// section's NonxlistCourseID is set to the course it came from.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.crosslist
+func (c *Course) CrossListSection(s *Section, courseID int) (*Section, error) {
+	return sendSection(c.client, "POST", fmt.Sprintf("/sections/%d/crosslist/%d", s.ID, courseID), nil, nil)
+}
+
+// UncrossListSection will move a cross-listed section
+// back into the course it came from.
+//
+// https://canvas.instructure.com/doc/api/sections.html#method.sections.uncrosslist
+func (c *Course) UncrossListSection(s *Section) (*Section, error) {
+	return sendSection(c.client, "DELETE", fmt.Sprintf("/sections/%d/crosslist", s.ID), nil, nil)
+}
+
+// Enrollments will get the enrollments in the section. Use
+// EnrollmentTypeFilter and EnrollmentStateFilter to narrow them down.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.index
+func (s *Section) Enrollments(opts ...Option) ([]*Enrollment, error) {
+	return collectEnrollments(s.client, fmt.Sprintf("/sections/%d/enrollments", s.ID), opts)
+}
+
+func sendSection(d doer, method, path string, s *Section, opts []Option) (*Section, error) {
+	q := params{}
+	if s != nil {
+		vals, err := query.Values(&sectionOptions{*s})
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range vals {
+			q[k] = v
+		}
+	}
+	q.Add(opts)
+	resp, err := do(d, newreq(method, path, q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	sec := &Section{client: d}
+	return sec, decodeJSON(resp.Body, sec)
+}
+
+// Assignments will get the course assignments that are visible to
+// the section. Canvas has no section assignments endpoint so the
+// course assignments are listed with their overrides and assignments
+// that are only assigned to other sections or students are left out.
+//
+// Use EffectiveDueAt with the section's ID to find an assignment's due
+// date for the section.
+func (s *Section) Assignments(opts ...Option) ([]*Assignment, error) {
+	return s.assignments(context.Background(), opts)
+}
+
+func (s *Section) assignments(ctx context.Context, opts []Option) ([]*Assignment, error) {
+	c := &Course{ID: s.CourseID, client: s.client}
+	asses, err := c.listAssignments(ctx, append([]Option{AssignmentIncludes(IncludeOverrides)}, opts...))
+	if err != nil {
+		return nil, err
+	}
+	visible := asses[:0]
+	for _, a := range asses {
+		if a.assignedToSection(s.ID) {
+			visible = append(visible, a)
+		}
+	}
+	return visible, nil
+}
+
+func (a *Assignment) assignedToSection(id int) bool {
+	if !a.OnlyVisibleToOverrides {
+		return true
+	}
+	for _, o := range a.Overrides {
+		if o.CourseSectionID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Submissions will stream the submissions of every student in the
+// section for every assignment visible to the section. It works just
+// like Course.AllSubmissions except that only the section's students
+// are listed. Errors are passed to the ConcurrentErrorHandler.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.for_students
+func (s *Section) Submissions(ctx context.Context, opts ...Option) <-chan *StudentSubmission {
+	return streamSubmissions(
+		ctx, s.client, fmt.Sprintf("/sections/%d/students/submissions", s.ID),
+		func(ctx context.Context) ([]*Assignment, error) { return s.assignments(ctx, nil) },
+		ConcurrentErrorHandler, opts,
+	)
+}
+
+// AssignmentSubmissions will get the submissions for one assignment
+// from the students in the section.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.index
+func (s *Section) AssignmentSubmissions(assignmentID int, opts ...Option) ([]*Submission, error) {
+	return collectSubmissions(s.client, fmt.Sprintf("/sections/%d/assignments/%d/submissions", s.ID, assignmentID), opts)
+}
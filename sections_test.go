@@ -0,0 +1,133 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSectionSubmissions(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/sections/4", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":4,"name":"Lab A","course_id":1}`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include[]") != "overrides" {
+			t.Error("overrides should be included")
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[
+			{"id":1,"name":"everyone"},
+			{"id":2,"name":"lab a","only_visible_to_overrides":true,"overrides":[{"course_section_id":4}]},
+			{"id":3,"name":"lab b","only_visible_to_overrides":true,"overrides":[{"course_section_id":5}]}
+		]`)
+	})
+	mux.HandleFunc("/api/v1/sections/4/students/submissions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("grouped") != "true" || q.Get("student_ids[]") != "all" {
+			t.Error("wrong query parameters")
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/sections/4/students/submissions?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"user_id":7,"section_id":4,"submissions":[
+			{"assignment_id":1,"user_id":7,"score":1},
+			{"assignment_id":2,"user_id":7,"score":2}
+		]}]`)
+	})
+	mux.HandleFunc("/api/v1/sections/4/assignments/2/submissions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/sections/4/assignments/2/submissions?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"assignment_id":2,"user_id":7,"score":2}]`)
+	})
+	course := &Course{ID: 1, client: client}
+	section, err := course.Section(4)
+	is.NoErr(err)
+	is.Equal(section.Name, "Lab A")
+
+	asses, err := section.Assignments()
+	is.NoErr(err)
+	is.Equal(len(asses), 2)
+	is.Equal(asses[1].Name, "lab a")
+
+	count := 0
+	for s := range section.Submissions(context.Background()) {
+		is.Equal(s.SectionID, 4)
+		is.Equal(s.Assignment.ID, s.Submission.AssignmentID)
+		is.True(s.Assignment.Name != "")
+		count++
+	}
+	is.Equal(count, 2)
+
+	subs, err := section.AssignmentSubmissions(2)
+	is.NoErr(err)
+	is.Equal(len(subs), 1)
+}
+
+func TestSections(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/sections", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `[{"id":4,"name":"Lab A","course_id":1},{"id":5,"name":"Lab B","course_id":1}]`)
+		case "POST":
+			q := r.URL.Query()
+			if q.Get("course_section[name]") != "Lab C" || q.Get("enable_sis_reactivation") != "true" {
+				t.Error("wrong query parameters")
+			}
+			if _, ok := q["course_section[sis_section_id]"]; ok {
+				t.Error("empty fields should not be sent")
+			}
+			fmt.Fprint(w, `{"id":6,"name":"Lab C","course_id":1}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/sections/6", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			if r.URL.Query().Get("course_section[name]") != "Lab D" {
+				t.Error("name not updated")
+			}
+			fmt.Fprint(w, `{"id":6,"name":"Lab D","course_id":1}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"id":6,"name":"Lab D","course_id":1}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/sections/4/crosslist/2", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":4,"course_id":2,"nonxlist_course_id":1}`)
+	})
+	mux.HandleFunc("/api/v1/sections/4/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[{"id":10,"course_section_id":4,"type":"StudentEnrollment"}]`)
+	})
+	c := &Course{ID: 1, client: client}
+	sections, err := c.Sections()
+	is.NoErr(err)
+	is.Equal(len(sections), 2)
+	is.Equal(sections[1].Name, "Lab B")
+
+	s, err := c.CreateSection(Section{Name: "Lab C"}, Opt("enable_sis_reactivation", true))
+	is.NoErr(err)
+	is.Equal(s.ID, 6)
+	s.Name = "Lab D"
+	s, err = c.EditSection(s)
+	is.NoErr(err)
+	is.Equal(s.Name, "Lab D")
+	_, err = c.DeleteSection(s)
+	is.NoErr(err)
+
+	moved, err := c.CrossListSection(sections[0], 2)
+	is.NoErr(err)
+	is.Equal(moved.CourseID, 2)
+	is.Equal(moved.NonxlistCourseID, 1)
+
+	enrollments, err := sections[0].Enrollments()
+	is.NoErr(err)
+	is.Equal(len(enrollments), 1)
+	is.Equal(enrollments[0].CourseSectionID, 4)
+}
@@ -0,0 +1,149 @@
+package canvas
+
+import (
+	"fmt"
+)
+
+// CurrentUserHandle is the user that the api token belongs to. It has
+// all of the User methods along with the endpoints that canvas only
+// provides for the current user. Those endpoints are requested using
+// the "self" user path, the embedded User methods still use the user's
+// id which is filled in when Self fetches the user.
+type CurrentUserHandle struct {
+	*User
+}
+
+// Self will get a handle on the current user.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.api_show
+func (c *Canvas) Self(opts ...Option) (*CurrentUserHandle, error) {
+	u, err := getUser(c.client, "self", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &CurrentUserHandle{User: u}, nil
+}
+
+// Self will get a handle on the current user.
+func Self(opts ...Option) (*CurrentUserHandle, error) { return ca.Self(opts...) }
+
+// Todos will get the current user's to-do list.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.todo_items
+func (cu *CurrentUserHandle) Todos(opts ...Option) ([]TODO, error) {
+	todos := make([]TODO, 0)
+	q := params{"per_page": {"100"}}
+	q.Add(opts)
+	return todos, getjson(cu.client, &todos, q, "/users/self/todo")
+}
+
+// TodoCount returns the number of assignments that the current user
+// needs to grade and the number that they still need to submit.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.todo_item_count
+func (cu *CurrentUserHandle) TodoCount() (needsGrading, needsSubmitting int, err error) {
+	var res struct {
+		NeedsGrading              int `json:"needs_grading_count"`
+		AssignmentsNeedSubmitting int `json:"assignments_needing_submitting"`
+	}
+	err = getjson(cu.client, &res, nil, "/users/self/todo_item_count")
+	return res.NeedsGrading, res.AssignmentsNeedSubmitting, err
+}
+
+// UpdateSettings will change the current user's settings. Settings are
+// passed as options like Opt("collapse_global_nav", true). The updated
+// settings are returned.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.settings
+func (cu *CurrentUserHandle) UpdateSettings(opts ...Option) (settings map[string]interface{}, err error) {
+	resp, err := put(cu.client, "/users/self/settings", optEnc(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return settings, decodeJSON(resp.Body, &settings)
+}
+
+// AddFavoriteCourse will add a course to the current
+// user's favorites so that it shows on their dashboard.
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.add_favorite_course
+func (cu *CurrentUserHandle) AddFavoriteCourse(id interface{}) error {
+	resp, err := post(cu.client, favoriteCoursePath(id), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// RemoveFavoriteCourse will remove a course from
+// the current user's favorites.
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.remove_favorite_course
+func (cu *CurrentUserHandle) RemoveFavoriteCourse(id interface{}) error {
+	resp, err := delete(cu.client, favoriteCoursePath(id), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// ResetFavoriteCourses will remove all of the current user's favorite
+// courses so that the dashboard goes back to showing every course.
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.reset_course_favorites
+func (cu *CurrentUserHandle) ResetFavoriteCourses() error {
+	resp, err := delete(cu.client, "/users/self/favorites/courses", nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// favoriteCoursePath takes a course id or an sis
+// id like "sis_course_id:MATH101".
+func favoriteCoursePath(id interface{}) string {
+	return fmt.Sprintf("/users/self/favorites/courses/%v", id)
+}
+
+// FavoriteGroups will list the current user's favorite groups.
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.list_favorite_groups
+func (cu *CurrentUserHandle) FavoriteGroups(opts ...Option) ([]*Group, error) {
+	return listGroups(cu.client, "/users/self/favorites/groups", opts)
+}
+
+// AddFavoriteGroup will add a group to the current user's favorites.
+// The id can be a group id or an sis id like "sis_group_id:G1".
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.add_favorite_groups
+func (cu *CurrentUserHandle) AddFavoriteGroup(id interface{}) error {
+	resp, err := post(cu.client, fmt.Sprintf("/users/self/favorites/groups/%v", id), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// RemoveFavoriteGroup will remove a group from
+// the current user's favorites.
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.remove_favorite_groups
+func (cu *CurrentUserHandle) RemoveFavoriteGroup(id interface{}) error {
+	resp, err := delete(cu.client, fmt.Sprintf("/users/self/favorites/groups/%v", id), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// ResetFavoriteGroups will remove all of the current user's favorite groups.
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.reset_groups_favorites
+func (cu *CurrentUserHandle) ResetFavoriteGroups() error {
+	resp, err := delete(cu.client, "/users/self/favorites/groups", nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
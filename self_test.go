@@ -0,0 +1,117 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSelf(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/self", func(w http.ResponseWriter, r *http.Request) {
+		writeTestFile(t, "user.json", w)
+	})
+	mux.HandleFunc("/api/v1/users/self/todo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"type":"grading","needs_grading_count":3,"course_id":1}]`)
+	})
+	mux.HandleFunc("/api/v1/users/self/todo_item_count", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"needs_grading_count":3,"assignments_needing_submitting":1}`)
+	})
+	mux.HandleFunc("/api/v1/users/self/settings", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("collapse_global_nav") != "true" {
+			t.Error("wrong settings update")
+		}
+		fmt.Fprint(w, `{"manual_mark_as_read":false,"collapse_global_nav":true}`)
+	})
+	var favorites []string
+	mux.HandleFunc("/api/v1/users/self/favorites/courses/", func(w http.ResponseWriter, r *http.Request) {
+		favorites = append(favorites, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"context_id":1,"context_type":"course"}`)
+	})
+	self, err := (&Canvas{client: client}).Self()
+	is.NoErr(err)
+	is.Equal(self.ID, 2)
+	todos, err := self.Todos()
+	is.NoErr(err)
+	is.Equal(len(todos), 1)
+	is.Equal(todos[0].NeedsGradingCount, 3)
+	grading, submitting, err := self.TodoCount()
+	is.NoErr(err)
+	is.Equal(grading, 3)
+	is.Equal(submitting, 1)
+	settings, err := self.UpdateSettings(Opt("collapse_global_nav", true))
+	is.NoErr(err)
+	is.Equal(settings["collapse_global_nav"], true)
+	is.NoErr(self.AddFavoriteCourse(1))
+	is.NoErr(self.RemoveFavoriteCourse("sis_course_id:MATH101"))
+	is.Equal(favorites, []string{
+		"POST /api/v1/users/self/favorites/courses/1",
+		"DELETE /api/v1/users/self/favorites/courses/sis_course_id:MATH101",
+	})
+}
+
+func TestFavorites(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	var requests []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	}
+	mux.HandleFunc("/api/v1/users/self/favorites/courses", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			record(w, r)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/users/self/favorites/courses?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":1,"name":"CS 101"}]`)
+	})
+	mux.HandleFunc("/api/v1/users/self/favorites/groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			record(w, r)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/users/self/favorites/groups?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":3,"name":"study group"}]`)
+	})
+	mux.HandleFunc("/api/v1/users/self/favorites/groups/", record)
+	mux.HandleFunc("/api/v1/users/2/dashboard_positions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"dashboard_positions":{"course_1":0,"group_3":1}}`)
+		case "PUT":
+			q := r.URL.Query()
+			is.Equal(q.Get("dashboard_positions[course_1]"), "1")
+			is.Equal(q.Get("dashboard_positions[group_3]"), "0")
+			fmt.Fprint(w, `{"dashboard_positions":{"course_1":1,"group_3":0}}`)
+		}
+	})
+	self := &CurrentUserHandle{User: &User{ID: 2, client: client}}
+
+	courses, err := self.FavoriteCourses()
+	is.NoErr(err)
+	is.Equal(courses[0].ID, 1)
+	groups, err := self.FavoriteGroups()
+	is.NoErr(err)
+	is.Equal(groups[0].ID, 3)
+	is.NoErr(self.AddFavoriteGroup(3))
+	is.NoErr(self.RemoveFavoriteGroup("sis_group_id:G1"))
+	is.NoErr(self.ResetFavoriteGroups())
+	is.NoErr(self.ResetFavoriteCourses())
+	is.Equal(requests, []string{
+		"POST /api/v1/users/self/favorites/groups/3",
+		"DELETE /api/v1/users/self/favorites/groups/sis_group_id:G1",
+		"DELETE /api/v1/users/self/favorites/groups",
+		"DELETE /api/v1/users/self/favorites/courses",
+	})
+
+	positions, err := self.DashboardPositions()
+	is.NoErr(err)
+	is.Equal(positions["group_3"], 1)
+	is.NoErr(self.SetDashboardPositions(map[string]int{"course_1": 1, "group_3": 0}))
+}
@@ -0,0 +1,126 @@
+//go:build go1.23
+
+package canvas
+
+import (
+	"fmt"
+	"iter"
+)
+
+// All returns the iterator's items as a sequence for range-over-func
+// loops. An error stops the sequence and is given with a zero value as
+// the last pair. The iterator is closed when the loop ends, even if it
+// ends early.
+//
+//	for a, err := range canvas.Paginate[*canvas.Assignment](c, "/courses/1/assignments").All() {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(a.Name)
+//	}
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// CoursesSeq returns a sequence of the current user's courses.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.index
+func (c *Canvas) CoursesSeq(opts ...Option) iter.Seq2[*Course, error] {
+	return newIterator[*Course](c.client, "/courses", opts).All()
+}
+
+// FilesSeq returns a sequence of the current user's files.
+func (c *Canvas) FilesSeq(opts ...Option) iter.Seq2[*File, error] {
+	return newIterator[*File](c.client, "/users/self/files", opts).All()
+}
+
+// FoldersSeq returns a sequence of the current user's folders.
+func (c *Canvas) FoldersSeq(opts ...Option) iter.Seq2[*Folder, error] {
+	return newIterator[*Folder](c.client, "/users/self/folders", opts).All()
+}
+
+// AssignmentsSeq returns a sequence of the course's assignments.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignments_api.index
+func (c *Course) AssignmentsSeq(opts ...Option) iter.Seq2[*Assignment, error] {
+	return newPreparedIterator(c.client, c.id("/courses/%d/assignments"), opts, func(a *Assignment) {
+		a.courseCode = c.CourseCode
+	}).All()
+}
+
+// FilesSeq returns a sequence of the course's files.
+func (c *Course) FilesSeq(opts ...Option) iter.Seq2[*File, error] {
+	return newIterator[*File](c.client, c.id("/courses/%d/files"), opts).All()
+}
+
+// FoldersSeq returns a sequence of the course's folders.
+func (c *Course) FoldersSeq(opts ...Option) iter.Seq2[*Folder, error] {
+	return newIterator[*Folder](c.client, c.id("/courses/%d/folders"), opts).All()
+}
+
+// UsersSeq returns a sequence of the course's users.
+func (c *Course) UsersSeq(opts ...Option) iter.Seq2[*User, error] {
+	return newIterator[*User](c.client, c.id("/courses/%d/users"), opts).All()
+}
+
+// QuizzesSeq returns a sequence of the course's quizzes.
+func (c *Course) QuizzesSeq(opts ...Option) iter.Seq2[*Quiz, error] {
+	return newPreparedIterator(c.client, c.id("/courses/%d/quizzes"), opts, func(q *Quiz) {
+		q.courseID, q.client = c.ID, c.client
+	}).All()
+}
+
+// ModulesSeq returns a sequence of the course's modules.
+func (c *Course) ModulesSeq(opts ...Option) iter.Seq2[*Module, error] {
+	return newPreparedIterator(c.client, c.id("/courses/%d/modules"), opts, func(m *Module) {
+		m.courseID = c.ID
+		m.setclient(c.client)
+	}).All()
+}
+
+// ItemsSeq returns a sequence of the module's items.
+func (m *Module) ItemsSeq(opts ...Option) iter.Seq2[*ModuleItem, error] {
+	return newPreparedIterator(m.client, m.path("/items"), opts, func(item *ModuleItem) {
+		item.courseID, item.client = m.courseID, m.client
+	}).All()
+}
+
+// FilesSeq returns a sequence of the files in the folder.
+func (f *Folder) FilesSeq(opts ...Option) iter.Seq2[*File, error] {
+	return newPreparedIterator(f.client, fmt.Sprintf("folders/%d/files", f.ID), opts, func(file *File) {
+		file.folder = f
+	}).All()
+}
+
+// FoldersSeq returns a sequence of the folder's sub-folders.
+func (f *Folder) FoldersSeq(opts ...Option) iter.Seq2[*Folder, error] {
+	return newPreparedIterator(f.client, fmt.Sprintf("folders/%d/folders", f.ID), opts, func(sub *Folder) {
+		sub.parent = f
+	}).All()
+}
+
+// FilesSeq returns a sequence of the group's files.
+func (g *Group) FilesSeq(opts ...Option) iter.Seq2[*File, error] {
+	return newIterator[*File](g.client, g.path("/files"), opts).All()
+}
+
+// FilesSeq returns a sequence of the user's files.
+func (u *User) FilesSeq(opts ...Option) iter.Seq2[*File, error] {
+	return newIterator[*File](u.client, u.id("/users/%d/files"), opts).All()
+}
+
+// FoldersSeq returns a sequence of the user's folders.
+func (u *User) FoldersSeq(opts ...Option) iter.Seq2[*Folder, error] {
+	return newIterator[*Folder](u.client, u.id("/users/%d/folders"), opts).All()
+}
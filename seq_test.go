@@ -0,0 +1,56 @@
+//go:build go1.23
+
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSeq(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(
+				`<https://%s/api/v1/courses/1/assignments?page=2>; rel="next"`, DefaultHost))
+			fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":3}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/modules", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"errors":[{"message":"oops"}]}`)
+	})
+	c := &Course{ID: 1, CourseCode: "CS101", client: client}
+
+	var ids []int
+	for a, err := range c.AssignmentsSeq() {
+		is.NoErr(err)
+		is.Equal(a.courseCode, "CS101")
+		is.True(a.client != nil)
+		ids = append(ids, a.ID)
+	}
+	is.Equal(ids, []int{1, 2, 3})
+
+	ids = nil
+	for a, err := range c.AssignmentsSeq() {
+		is.NoErr(err)
+		ids = append(ids, a.ID)
+		break // stopping early should not fetch more pages
+	}
+	is.Equal(ids, []int{1})
+
+	var errs int
+	for m, err := range c.ModulesSeq() {
+		is.True(m == nil)
+		is.True(err != nil)
+		errs++
+	}
+	is.Equal(errs, 1)
+}
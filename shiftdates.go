@@ -0,0 +1,280 @@
+package canvas
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Date shift item types.
+const (
+	ShiftAssignment         = "assignment"
+	ShiftAssignmentOverride = "assignment_override"
+	ShiftQuiz               = "quiz"
+	ShiftCalendarEvent      = "calendar_event"
+)
+
+// shiftWorkers is the number of items updated at once by ApplyDateShift.
+const shiftWorkers = 8
+
+// DateShift is a planned change to the dates of one item in a course.
+type DateShift struct {
+	Type string
+	ID   int
+	// AssignmentID is the assignment that an
+	// assignment override belongs to.
+	AssignmentID int
+	Name         string
+	Dates        []ShiftedDate
+}
+
+// ShiftedDate is one date field of an item and the value it will be moved to.
+type ShiftedDate struct {
+	// Field is the name canvas uses for the date, like "due_at".
+	Field string
+	From  time.Time
+	To    time.Time
+}
+
+// ShiftFilter decides which items have their dates moved.
+// Items are left alone when it returns false.
+type ShiftFilter func(*DateShift) bool
+
+// ShiftDates will move the due, lock, and unlock dates of the course's
+// assignments, assignment overrides, and quizzes along with the start and
+// end of its calendar events by delta. A nil filter moves everything.
+// Dates that are not set are never changed. The changes that were made
+// are returned.
+//
+// Whole days in delta are added as calendar days in the course's time
+// zone so that local times stay the same across daylight saving time
+// changes. UTC is used if the course has no time zone.
+//
+// Use PlanDateShift to see the changes without making them.
+//
+//	// move everything one week later
+//	_, err := course.ShiftDates(7*24*time.Hour, nil)
+func (c *Course) ShiftDates(delta time.Duration, filter ShiftFilter) ([]*DateShift, error) {
+	plan, err := c.PlanDateShift(delta, filter)
+	if err != nil {
+		return nil, err
+	}
+	return plan, c.ApplyDateShift(plan)
+}
+
+// PlanDateShift returns the changes that ShiftDates would make without
+// changing anything on canvas. The plan can be edited and then given
+// to ApplyDateShift.
+//
+// Quizzes that are graded share their dates with an assignment, so they
+// are only planned as a quiz.
+func (c *Course) PlanDateShift(delta time.Duration, filter ShiftFilter) ([]*DateShift, error) {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errl []error
+		plan []*DateShift
+	)
+	shift, err := newDateShifter(delta, c.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	collect := func(list func(*Course, *dateShifter) ([]*DateShift, error)) {
+		defer wg.Done()
+		l, err := list(c, shift)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errl = append(errl, err)
+			return
+		}
+		for _, s := range l {
+			if len(s.Dates) == 0 || (filter != nil && !filter(s)) {
+				continue
+			}
+			plan = append(plan, s)
+		}
+	}
+	wg.Add(3)
+	go collect(assignmentShifts)
+	go collect(quizShifts)
+	go collect(calendarEventShifts)
+	wg.Wait()
+	if err := joinErrs(errl); err != nil {
+		return nil, err
+	}
+	sort.Slice(plan, func(i, j int) bool {
+		if plan[i].Type != plan[j].Type {
+			return plan[i].Type < plan[j].Type
+		}
+		return plan[i].ID < plan[j].ID
+	})
+	return plan, nil
+}
+
+// ApplyDateShift will concurrently update the dates of every item in
+// the plan. Items that fail to update do not stop the others.
+func (c *Course) ApplyDateShift(plan []*DateShift) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errl []error
+		sem  = make(chan struct{}, shiftWorkers)
+	)
+	for _, s := range plan {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(s *DateShift) {
+			defer func() { <-sem; wg.Done() }()
+			if err := c.applyShift(s); err != nil {
+				mu.Lock()
+				errl = append(errl, fmt.Errorf("%s %d: %w", s.Type, s.ID, err))
+				mu.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+	return joinErrs(errl)
+}
+
+func (c *Course) applyShift(s *DateShift) error {
+	var path, key string
+	switch s.Type {
+	case ShiftAssignment:
+		path, key = fmt.Sprintf("/courses/%d/assignments/%d", c.ID, s.ID), "assignment"
+	case ShiftAssignmentOverride:
+		path = fmt.Sprintf("/courses/%d/assignments/%d/overrides/%d", c.ID, s.AssignmentID, s.ID)
+		key = "assignment_override"
+	case ShiftQuiz:
+		path, key = fmt.Sprintf("/courses/%d/quizzes/%d", c.ID, s.ID), "quiz"
+	case ShiftCalendarEvent:
+		path, key = fmt.Sprintf("/calendar_events/%d", s.ID), "calendar_event"
+	default:
+		return fmt.Errorf("unknown item type %q", s.Type)
+	}
+	q := params{}
+	for _, d := range s.Dates {
+		q.Set(fmt.Sprintf("%s[%s]", key, d.Field), d.To.Format(time.RFC3339))
+	}
+	resp, err := put(c.client, path, q)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+var (
+	itemDateFields  = []string{"due_at", "lock_at", "unlock_at"}
+	eventDateFields = []string{"start_at", "end_at"}
+)
+
+// dateShifter moves dates by a number of calendar days
+// in a time zone followed by the rest of a duration.
+type dateShifter struct {
+	days int
+	rest time.Duration
+	loc  *time.Location
+}
+
+func newDateShifter(delta time.Duration, timezone string) (*dateShifter, error) {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, err
+		}
+	}
+	days := int(delta / (24 * time.Hour))
+	return &dateShifter{
+		days: days,
+		rest: delta - time.Duration(days)*24*time.Hour,
+		loc:  loc,
+	}, nil
+}
+
+func (ds *dateShifter) shift(t time.Time) time.Time {
+	return t.In(ds.loc).AddDate(0, 0, ds.days).Add(ds.rest).In(t.Location())
+}
+
+// dates moves each date that is set.
+func (ds *dateShifter) dates(fields []string, times ...time.Time) []ShiftedDate {
+	var dates []ShiftedDate
+	for i, t := range times {
+		if t.IsZero() {
+			continue
+		}
+		dates = append(dates, ShiftedDate{Field: fields[i], From: t, To: ds.shift(t)})
+	}
+	return dates
+}
+
+func assignmentShifts(c *Course, shift *dateShifter) ([]*DateShift, error) {
+	asses, err := c.ListAssignments(AssignmentIncludes(IncludeOverrides))
+	if err != nil {
+		return nil, err
+	}
+	shifts := make([]*DateShift, 0, len(asses))
+	for _, a := range asses {
+		// overrides belong to the assignment even
+		// when the assignment is a graded quiz
+		for _, o := range a.Overrides {
+			shifts = append(shifts, &DateShift{
+				Type:         ShiftAssignmentOverride,
+				ID:           o.ID,
+				AssignmentID: a.ID,
+				Name:         fmt.Sprintf("%s (%s)", a.Name, o.Title),
+				Dates:        shift.dates(itemDateFields, o.DueAt, o.LockAt, o.UnlockAt),
+			})
+		}
+		if a.QuizID != 0 {
+			// moved along with the quiz
+			continue
+		}
+		shifts = append(shifts, &DateShift{
+			Type:  ShiftAssignment,
+			ID:    a.ID,
+			Name:  a.Name,
+			Dates: shift.dates(itemDateFields, a.DueAt, a.LockAt, a.UnlockAt),
+		})
+	}
+	return shifts, nil
+}
+
+func quizShifts(c *Course, shift *dateShifter) ([]*DateShift, error) {
+	// every page is needed since the assignments of graded
+	// quizzes are skipped and only moved along with their quiz
+	it := newIterator[*Quiz](c.client, c.id("/courses/%d/quizzes"), nil)
+	defer it.Close()
+	var shifts []*DateShift
+	for it.Next() {
+		q := it.Value()
+		shifts = append(shifts, &DateShift{
+			Type:  ShiftQuiz,
+			ID:    q.ID,
+			Name:  q.Title,
+			Dates: shift.dates(itemDateFields, q.DueAt, q.LockAt, q.UnlockAt),
+		})
+	}
+	return shifts, it.Err()
+}
+
+func calendarEventShifts(c *Course, shift *dateShifter) ([]*DateShift, error) {
+	events, err := (&Canvas{client: c.client}).CalendarEvents(
+		ArrayOpt("context_codes", fmt.Sprintf("course_%d", c.ID)),
+		Opt("all_events", true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	shifts := make([]*DateShift, len(events))
+	for i, e := range events {
+		shifts[i] = &DateShift{
+			Type:  ShiftCalendarEvent,
+			ID:    e.ID,
+			Name:  e.Title,
+			Dates: shift.dates(eventDateFields, e.StartAt, e.EndAt),
+		}
+	}
+	return shifts, nil
+}
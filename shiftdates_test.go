@@ -0,0 +1,121 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestShiftDates(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	c := &Course{ID: 1, client: client}
+	link := func(w http.ResponseWriter, path string) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1%s?page=1>; rel="last"`, DefaultHost, path))
+	}
+	var (
+		mu      sync.Mutex
+		updates = map[string]string{}
+	)
+	update := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("expected PUT; got %s", r.Method)
+		}
+		mu.Lock()
+		for k, v := range r.URL.Query() {
+			updates[r.URL.Path+" "+k] = v[0]
+		}
+		mu.Unlock()
+		fmt.Fprint(w, `{}`)
+	}
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include[]") != "overrides" {
+			t.Error("should include overrides")
+		}
+		link(w, "/courses/1/assignments")
+		fmt.Fprint(w, `[
+			{"id":1,"name":"hw1","due_at":"2020-09-01T23:59:00Z","overrides":[
+				{"id":7,"assignment_id":1,"title":"Section 2","due_at":"2020-09-04T23:59:00Z","lock_at":null}
+			]},
+			{"id":2,"name":"quiz1","due_at":"2020-09-02T23:59:00Z","quiz_id":5,"overrides":[
+				{"id":8,"assignment_id":2,"title":"Sheldon","unlock_at":"2020-09-01T12:00:00Z"}
+			]},
+			{"id":3,"name":"undated"}
+		]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/quizzes", func(w http.ResponseWriter, r *http.Request) {
+		// the quiz is on the second page
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/quizzes?page=2>; rel="next"`, DefaultHost))
+			fmt.Fprint(w, `[{"id":6,"title":"practice"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":5,"title":"quiz1","due_at":"2020-09-02T23:59:00Z","unlock_at":"2020-09-01T00:00:00Z"}]`)
+	})
+	mux.HandleFunc("/api/v1/calendar_events", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("context_codes[]") != "course_1" {
+			t.Error("wrong context code")
+		}
+		link(w, "/calendar_events")
+		fmt.Fprint(w, `[{"id":9,"title":"lecture","start_at":"2020-09-03T10:00:00Z","end_at":"2020-09-03T11:00:00Z"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments/1", update)
+	mux.HandleFunc("/api/v1/courses/1/assignments/1/overrides/7", update)
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/overrides/8", update)
+	mux.HandleFunc("/api/v1/courses/1/quizzes/5", update)
+	mux.HandleFunc("/api/v1/calendar_events/9", update)
+
+	week := 7 * 24 * time.Hour
+	plan, err := c.PlanDateShift(week, nil)
+	is.NoErr(err)
+	is.Equal(len(plan), 5)
+	is.Equal(len(updates), 0) // planning should not change anything
+	is.Equal(plan[0].Type, ShiftAssignment)
+	is.Equal(plan[0].Dates[0].To, time.Date(2020, 9, 8, 23, 59, 0, 0, time.UTC))
+	is.Equal(plan[1].Type, ShiftAssignmentOverride)
+	is.Equal(plan[1].AssignmentID, 1)
+	is.Equal(len(plan[1].Dates), 1)
+	is.Equal(plan[2].Type, ShiftAssignmentOverride)
+	is.Equal(plan[2].AssignmentID, 2)
+	is.Equal(plan[3].Type, ShiftCalendarEvent)
+	is.Equal(len(plan[3].Dates), 2)
+	is.Equal(plan[4].Type, ShiftQuiz)
+
+	plan, err = c.ShiftDates(week, func(s *DateShift) bool {
+		return s.Type != ShiftCalendarEvent
+	})
+	is.NoErr(err)
+	is.Equal(len(plan), 4)
+	is.Equal(updates, map[string]string{
+		"/api/v1/courses/1/assignments/1 assignment[due_at]":                         "2020-09-08T23:59:00Z",
+		"/api/v1/courses/1/assignments/1/overrides/7 assignment_override[due_at]":    "2020-09-11T23:59:00Z",
+		"/api/v1/courses/1/assignments/2/overrides/8 assignment_override[unlock_at]": "2020-09-08T12:00:00Z",
+		"/api/v1/courses/1/quizzes/5 quiz[due_at]":                                   "2020-09-09T23:59:00Z",
+		"/api/v1/courses/1/quizzes/5 quiz[unlock_at]":                                "2020-09-08T00:00:00Z",
+	})
+}
+
+func TestDateShifterDST(t *testing.T) {
+	is := is.New(t)
+	shift, err := newDateShifter(7*24*time.Hour+time.Hour, "America/Denver")
+	is.NoErr(err)
+	loc, err := time.LoadLocation("America/Denver")
+	is.NoErr(err)
+	// daylight saving time ends on Nov 1 2020
+	due := time.Date(2020, 10, 30, 23, 0, 0, 0, loc).UTC()
+	moved := shift.shift(due)
+	is.Equal(moved.Location(), time.UTC)
+	is.Equal(moved.In(loc), time.Date(2020, 11, 7, 0, 0, 0, 0, loc))
+
+	shift, err = newDateShifter(-2*24*time.Hour, "America/Denver")
+	is.NoErr(err)
+	is.Equal(shift.shift(time.Date(2020, 11, 2, 9, 0, 0, 0, loc)), time.Date(2020, 10, 31, 9, 0, 0, 0, loc))
+
+	_, err = newDateShifter(time.Hour, "Not/AZone")
+	is.True(err != nil)
+}
@@ -0,0 +1,337 @@
+package canvas
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// GradeRecord is a single grade that is ready to be sent
+// to a student information system (SIS).
+//
+// Records with an AssignmentID of zero hold the student's
+// total score for the course.
+type GradeRecord struct {
+	CourseID    int
+	SisCourseID string
+	CourseCode  string
+
+	UserID    int
+	SisUserID string
+	LoginID   string
+
+	AssignmentID   int
+	AssignmentName string
+	PointsPossible float64
+	DueAt          time.Time
+
+	// Score is only meaningful when Graded is true
+	// and the record is not excused.
+	Score    float64
+	Grade    string
+	GradedAt time.Time
+	Excused  bool
+	Missing  bool
+	Late     bool
+	Graded   bool
+}
+
+// IsCourseTotal returns true if the record holds a course total and
+// not a grade for a single assignment.
+func (gr *GradeRecord) IsCourseTotal() bool {
+	return gr.AssignmentID == 0
+}
+
+// SISAdapter is an interface for anything that can push canvas grade
+// data into a student information system.
+type SISAdapter interface {
+	WriteGrades([]*GradeRecord) error
+}
+
+// PassbackGrades will collect all the assignment scores and course totals for
+// the students in the course and send them to a SIS adapter.
+//
+// The options given are used when listing the course students.
+func (c *Course) PassbackGrades(adapter SISAdapter, opts ...Option) error {
+	records, err := c.GradeRecords(opts...)
+	if err != nil {
+		return err
+	}
+	return adapter.WriteGrades(records)
+}
+
+// GradeRecords will build a list of grade records for every student in
+// the course using the submissions and enrollments apis.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.for_students
+func (c *Course) GradeRecords(opts ...Option) ([]*GradeRecord, error) {
+	opts = append(append([]Option{}, opts...), OptStudent, IncludeOpt("enrollments"))
+	students, err := c.Users(opts...)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := c.ListAssignments()
+	if err != nil {
+		return nil, err
+	}
+	subs, err := collectSubmissions(
+		c.client, c.id("/courses/%d/students/submissions"),
+		[]Option{ArrayOpt("student_ids", "all")},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return buildGradeRecords(c, students, assignments, subs), nil
+}
+
+func buildGradeRecords(
+	c *Course,
+	students []*User,
+	assignments []*Assignment,
+	subs []*Submission,
+) []*GradeRecord {
+	users := make(map[int]*User, len(students))
+	for _, u := range students {
+		users[u.ID] = u
+	}
+	asses := make(map[int]*Assignment, len(assignments))
+	for _, a := range assignments {
+		asses[a.ID] = a
+	}
+
+	records := make([]*GradeRecord, 0, len(subs)+len(students))
+	for _, s := range subs {
+		u, ok := users[s.UserID]
+		if !ok {
+			continue
+		}
+		r := newGradeRecord(c, u)
+		r.AssignmentID = s.AssignmentID
+		if a, ok := asses[s.AssignmentID]; ok {
+			r.AssignmentName = a.Name
+			r.PointsPossible = a.PointsPossible
+			r.DueAt = a.DueAt
+		}
+		r.Score = s.Score
+		r.Grade = s.Grade
+		r.GradedAt = s.GradedAt
+		r.Excused = s.Excused
+		r.Missing = s.Missing
+		r.Late = s.Late
+		r.Graded = s.WorkflowState == "graded"
+		records = append(records, r)
+	}
+
+	for _, u := range students {
+		for _, e := range u.Enrollments {
+			if e.CourseID != c.ID || e.Type != string(StudentEnrollment) {
+				continue
+			}
+			r := newGradeRecord(c, u)
+			r.AssignmentName = "Course Total"
+			r.Score = e.Grades.FinalScore
+			r.Grade = e.Grades.FinalGrade
+			r.Graded = true
+			records = append(records, r)
+			break
+		}
+	}
+	return records
+}
+
+func newGradeRecord(c *Course, u *User) *GradeRecord {
+	r := &GradeRecord{
+		CourseID:   c.ID,
+		CourseCode: c.CourseCode,
+		UserID:     u.ID,
+		SisUserID:  u.SisUserID,
+		LoginID:    u.LoginID,
+	}
+	if c.SisCourseID != 0 {
+		r.SisCourseID = strconv.Itoa(c.SisCourseID)
+	}
+	return r
+}
+
+// NewOneRosterCSV creates a SISAdapter that writes grades in the
+// format of a OneRoster v1.1 results.csv file.
+//
+// https://www.imsglobal.org/oneroster-v11-final-csv-tables
+func NewOneRosterCSV(w io.Writer) SISAdapter {
+	return &oneRosterCSV{w: csv.NewWriter(w)}
+}
+
+type oneRosterCSV struct {
+	w *csv.Writer
+}
+
+var oneRosterHeader = []string{
+	"sourcedId",
+	"status",
+	"dateLastModified",
+	"lineItemSourcedId",
+	"studentSourcedId",
+	"scoreStatus",
+	"score",
+	"scoreDate",
+	"comment",
+}
+
+func (or *oneRosterCSV) WriteGrades(records []*GradeRecord) error {
+	if err := or.w.Write(oneRosterHeader); err != nil {
+		return err
+	}
+	now := time.Now().UTC().Format(dateFormat)
+	for _, r := range records {
+		student := r.SisUserID
+		if student == "" {
+			student = strconv.Itoa(r.UserID)
+		}
+		lineItem := fmt.Sprintf("canvas-assignment-%d", r.AssignmentID)
+		if r.IsCourseTotal() {
+			lineItem = fmt.Sprintf("canvas-course-%d", r.CourseID)
+		}
+		err := or.w.Write([]string{
+			fmt.Sprintf("%s-%s", lineItem, student),
+			"active",
+			now,
+			lineItem,
+			student,
+			oneRosterScoreStatus(r),
+			recordScore(r),
+			formatDate(r.GradedAt, "2006-01-02"),
+			"",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	or.w.Flush()
+	return or.w.Error()
+}
+
+func oneRosterScoreStatus(r *GradeRecord) string {
+	switch {
+	case r.Excused:
+		return "exempt"
+	case r.Graded:
+		return "fully graded"
+	case r.Missing:
+		return "not submitted"
+	default:
+		return "submitted"
+	}
+}
+
+// NewPowerSchoolCSV creates a SISAdapter that writes grades as a csv
+// file that can be imported into PowerSchool style gradebooks.
+func NewPowerSchoolCSV(w io.Writer) SISAdapter {
+	return &powerSchoolCSV{w: csv.NewWriter(w)}
+}
+
+type powerSchoolCSV struct {
+	w *csv.Writer
+}
+
+var powerSchoolHeader = []string{
+	"Student_Number",
+	"Course_Number",
+	"Assignment_Name",
+	"Due_Date",
+	"Score",
+	"Points_Possible",
+	"Grade",
+	"Exempt",
+	"Late",
+	"Missing",
+}
+
+func (ps *powerSchoolCSV) WriteGrades(records []*GradeRecord) error {
+	if err := ps.w.Write(powerSchoolHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		student := r.SisUserID
+		if student == "" {
+			student = r.LoginID
+		}
+		course := r.SisCourseID
+		if course == "" {
+			course = r.CourseCode
+		}
+		err := ps.w.Write([]string{
+			student,
+			course,
+			r.AssignmentName,
+			formatDate(r.DueAt, "01/02/2006"),
+			recordScore(r),
+			formatScore(r.PointsPossible),
+			r.Grade,
+			strconv.FormatBool(r.Excused),
+			strconv.FormatBool(r.Late),
+			strconv.FormatBool(r.Missing),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	ps.w.Flush()
+	return ps.w.Error()
+}
+
+// recordScore leaves the score blank for records that have no grade so
+// that a SIS does not record them as zeros.
+func recordScore(r *GradeRecord) string {
+	if !r.Graded || r.Excused {
+		return ""
+	}
+	return formatScore(r.Score)
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+func formatDate(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+func collectSubmissions(d doer, path string, opts []Option) (subs []*Submission, err error) {
+	ch := make(chan *Submission)
+	errs := newPaginatedList(d, path, sendSubmissionFunc(ch), opts).start()
+	var errl []error
+	for {
+		select {
+		case s := <-ch:
+			subs = append(subs, s)
+		case err, ok := <-errs:
+			if !ok {
+				return subs, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+func sendSubmissionFunc(ch chan *Submission) sendFunc {
+	return func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			s := &Submission{}
+			if err := dec.Decode(s); err != nil {
+				return err
+			}
+			ch <- s
+			return nil
+		})
+	}
+}
+
+var (
+	_ SISAdapter = (*oneRosterCSV)(nil)
+	_ SISAdapter = (*powerSchoolCSV)(nil)
+)
@@ -0,0 +1,107 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGradeRecords(t *testing.T) {
+	is := is.New(t)
+	course := &Course{ID: 1, CourseCode: "CS101"}
+	students := []*User{
+		{ID: 2, SisUserID: "SHEL93921", Enrollments: []Enrollment{
+			{CourseID: 1, Type: string(StudentEnrollment)},
+		}},
+		{ID: 3, LoginID: "leonard"},
+	}
+	students[0].Enrollments[0].Grades.FinalScore = 91.5
+	students[0].Enrollments[0].Grades.FinalGrade = "A-"
+	assignments := []*Assignment{{ID: 10, Name: "Homework 1", PointsPossible: 10}}
+	subs := []*Submission{
+		{AssignmentID: 10, UserID: 2, Score: 9, WorkflowState: "graded"},
+		{AssignmentID: 10, UserID: 3, Missing: true},
+		{AssignmentID: 10, UserID: 4}, // not a student
+	}
+	records := buildGradeRecords(course, students, assignments, subs)
+	is.Equal(len(records), 3)
+	is.Equal(records[0].AssignmentName, "Homework 1")
+	is.True(records[0].Graded)
+	is.True(records[1].Missing)
+	is.True(records[2].IsCourseTotal())
+	is.Equal(records[2].Grade, "A-")
+
+	var buf bytes.Buffer
+	is.NoErr(NewOneRosterCSV(&buf).WriteGrades(records))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	is.NoErr(err)
+	is.Equal(len(rows), 4)
+	is.Equal(rows[0], oneRosterHeader)
+	is.Equal(rows[1][3], "canvas-assignment-10")
+	is.Equal(rows[1][4], "SHEL93921")
+	is.Equal(rows[1][5], "fully graded")
+	is.Equal(rows[2][4], "3")
+	is.Equal(rows[2][5], "not submitted")
+	is.Equal(rows[2][6], "") // ungraded scores are not zeros
+	is.Equal(rows[3][3], "canvas-course-1")
+	is.Equal(rows[3][6], "91.5")
+
+	buf.Reset()
+	is.NoErr(NewPowerSchoolCSV(&buf).WriteGrades(records))
+	rows, err = csv.NewReader(&buf).ReadAll()
+	is.NoErr(err)
+	is.Equal(len(rows), 4)
+	is.Equal(rows[0], powerSchoolHeader)
+	is.Equal(rows[1][:6], []string{"SHEL93921", "CS101", "Homework 1", "", "9", "10"})
+	is.Equal(rows[2][0], "leonard")
+	is.Equal(rows[2][4], "")
+}
+
+func TestGradeRecordsOptions(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	for _, path := range []string{"users", "assignments", "students/submissions"} {
+		path := path
+		mux.HandleFunc("/api/v1/courses/1/"+path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/%s?page=1>; rel="last"`, DefaultHost, path))
+			fmt.Fprint(w, `[]`)
+		})
+	}
+	course := &Course{ID: 1, client: client}
+	// the caller's slice has room for the options added by GradeRecords
+	opts := make([]Option, 1, 4)
+	opts[0] = Opt("search_term", "leonard")
+	_, err := course.GradeRecords(opts...)
+	is.NoErr(err)
+	is.Equal(opts[:cap(opts)][1], nil) // the caller's backing array is not changed
+}
+
+func TestGradeRecordsUngraded(t *testing.T) {
+	is := is.New(t)
+	records := []*GradeRecord{
+		{AssignmentID: 1, UserID: 1, Score: 0, Graded: false},
+		{AssignmentID: 1, UserID: 2, Score: 0, Graded: true, Excused: true},
+		{AssignmentID: 1, UserID: 3, Score: 0, Graded: true},
+	}
+	var buf bytes.Buffer
+	is.NoErr(NewOneRosterCSV(&buf).WriteGrades(records))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	is.NoErr(err)
+	is.Equal(rows[1][6], "")
+	is.Equal(rows[2][5], "exempt")
+	is.Equal(rows[2][6], "")
+	is.Equal(rows[3][6], "0")
+
+	buf.Reset()
+	is.NoErr(NewPowerSchoolCSV(&buf).WriteGrades(records))
+	rows, err = csv.NewReader(&buf).ReadAll()
+	is.NoErr(err)
+	is.Equal(rows[1][4], "")
+	is.Equal(rows[2][4], "")
+	is.Equal(rows[3][4], "0")
+}
@@ -0,0 +1,247 @@
+package canvas
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harrybrwn/errs"
+	"github.com/harrybrwn/go-canvas/store"
+)
+
+// Snapshot item types.
+const (
+	SnapshotAssignment   = "assignment"
+	SnapshotFile         = "file"
+	SnapshotAnnouncement = "announcement"
+)
+
+// CourseSnapshot is the state of a course's assignments, files, and
+// announcements at some point in time. Snapshots can be saved as json
+// and compared later with Course.ChangesSince.
+type CourseSnapshot struct {
+	CourseID int            `json:"course_id"`
+	TakenAt  time.Time      `json:"taken_at"`
+	Items    []SnapshotItem `json:"items"`
+}
+
+// SnapshotItem is the metadata of one item in a course snapshot.
+type SnapshotItem struct {
+	Type      string    `json:"type"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Checksum is a hash of the fields that are
+	// used to tell if the item has been modified.
+	Checksum string `json:"checksum"`
+}
+
+func (si *SnapshotItem) key() string {
+	return fmt.Sprintf("%s:%d", si.Type, si.ID)
+}
+
+// CourseChanges are the differences between a snapshot and
+// the current state of the course.
+type CourseChanges struct {
+	// Snapshot is the current state of the course and can be
+	// used to find the next set of changes.
+	Snapshot *CourseSnapshot
+
+	Added    []SnapshotItem
+	Removed  []SnapshotItem
+	Modified []SnapshotItem
+}
+
+// Empty returns true if nothing has changed.
+func (cc *CourseChanges) Empty() bool {
+	return len(cc.Added) == 0 &&
+		len(cc.Removed) == 0 &&
+		len(cc.Modified) == 0
+}
+
+// Snapshot will concurrently fetch the course's assignments, files, and
+// announcements and save their metadata in a snapshot.
+func (c *Course) Snapshot() (*CourseSnapshot, error) {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		errl  []error
+		items []SnapshotItem
+	)
+	collect := func(list func(*Course) ([]SnapshotItem, error)) {
+		defer wg.Done()
+		l, err := list(c)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errl = append(errl, err)
+			return
+		}
+		items = append(items, l...)
+	}
+	wg.Add(3)
+	go collect(assignmentSnapshot)
+	go collect(fileSnapshot)
+	go collect(announcementSnapshot)
+	wg.Wait()
+	if err := errs.Chain(errl...); err != nil {
+		return nil, err
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].ID < items[j].ID
+	})
+	return &CourseSnapshot{
+		CourseID: c.ID,
+		TakenAt:  time.Now().UTC(),
+		Items:    items,
+	}, nil
+}
+
+// ChangesSince will take a new snapshot of the course and return
+// everything that has been added, removed, or modified since the
+// snapshot given.
+func (c *Course) ChangesSince(snap *CourseSnapshot) (*CourseChanges, error) {
+	current, err := c.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snapshotChanges(snap, current), nil
+}
+
+// ChangesSinceSaved is like ChangesSince but it compares the course with
+// the last snapshot saved in the store and then saves the new snapshot.
+// Every item is reported as added if no snapshot has been saved yet.
+func (c *Course) ChangesSinceSaved(s store.Store) (*CourseChanges, error) {
+	prev, err := LoadSnapshot(s, c.ID)
+	if errors.Is(err, store.ErrNotFound) {
+		prev = &CourseSnapshot{CourseID: c.ID}
+	} else if err != nil {
+		return nil, err
+	}
+	changes, err := c.ChangesSince(prev)
+	if err != nil {
+		return nil, err
+	}
+	return changes, SaveSnapshot(s, changes.Snapshot)
+}
+
+// SaveSnapshot will save a snapshot in the store's
+// snapshots bucket using the course id as the key.
+func SaveSnapshot(s store.Store, snap *CourseSnapshot) error {
+	return store.PutJSON(s, store.Snapshots, strconv.Itoa(snap.CourseID), snap)
+}
+
+// LoadSnapshot will get the last snapshot saved for a course. It
+// returns store.ErrNotFound if there is no snapshot for the course.
+func LoadSnapshot(s store.Store, courseID int) (*CourseSnapshot, error) {
+	snap := &CourseSnapshot{}
+	if err := store.GetJSON(s, store.Snapshots, strconv.Itoa(courseID), snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func snapshotChanges(prev, current *CourseSnapshot) *CourseChanges {
+	changes := &CourseChanges{Snapshot: current}
+	old := make(map[string]SnapshotItem, len(prev.Items))
+	for _, item := range prev.Items {
+		old[item.key()] = item
+	}
+	seen := make(map[string]bool, len(current.Items))
+	for _, item := range current.Items {
+		seen[item.key()] = true
+		o, ok := old[item.key()]
+		if !ok {
+			changes.Added = append(changes.Added, item)
+			continue
+		}
+		if o.Checksum != item.Checksum || !o.UpdatedAt.Equal(item.UpdatedAt) {
+			changes.Modified = append(changes.Modified, item)
+		}
+	}
+	for _, item := range prev.Items {
+		if !seen[item.key()] {
+			changes.Removed = append(changes.Removed, item)
+		}
+	}
+	return changes
+}
+
+func checksum(fields ...interface{}) string {
+	h := sha1.New()
+	for _, f := range fields {
+		if t, ok := f.(time.Time); ok {
+			f = t.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(h, "%v\x00", f)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func assignmentSnapshot(c *Course) ([]SnapshotItem, error) {
+	asses, err := c.ListAssignments()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]SnapshotItem, len(asses))
+	for i, a := range asses {
+		items[i] = SnapshotItem{
+			Type:      SnapshotAssignment,
+			ID:        a.ID,
+			Name:      a.Name,
+			UpdatedAt: a.UpdatedAt,
+			Checksum: checksum(
+				a.Name, a.Description, a.DueAt, a.LockAt, a.UnlockAt,
+				a.PointsPossible, a.Published,
+			),
+		}
+	}
+	return items, nil
+}
+
+func fileSnapshot(c *Course) ([]SnapshotItem, error) {
+	files, err := c.ListFiles()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]SnapshotItem, len(files))
+	for i, f := range files {
+		items[i] = SnapshotItem{
+			Type:      SnapshotFile,
+			ID:        f.ID,
+			Name:      f.DisplayName,
+			UpdatedAt: f.UpdatedAt,
+			Checksum: checksum(
+				f.DisplayName, f.Size, f.ContentType, f.ModifiedAt,
+				f.FolderID, f.Hidden, f.Locked,
+			),
+		}
+	}
+	return items, nil
+}
+
+func announcementSnapshot(c *Course) ([]SnapshotItem, error) {
+	topics, err := c.DiscussionTopics(Opt("only_announcements", true))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]SnapshotItem, len(topics))
+	for i, t := range topics {
+		items[i] = SnapshotItem{
+			Type:      SnapshotAnnouncement,
+			ID:        t.ID,
+			Name:      t.Title,
+			UpdatedAt: t.PostedAt,
+			Checksum:  checksum(t.Title, t.Message, t.Published, t.LastReplyAt),
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,60 @@
+package canvas
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harrybrwn/go-canvas/store"
+	"github.com/matryer/is"
+)
+
+func TestSnapshotChanges(t *testing.T) {
+	is := is.New(t)
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	prev := &CourseSnapshot{CourseID: 1, TakenAt: now, Items: []SnapshotItem{
+		{Type: SnapshotAssignment, ID: 1, Name: "hw1", UpdatedAt: now, Checksum: checksum("hw1", 10)},
+		{Type: SnapshotAssignment, ID: 2, Name: "hw2", UpdatedAt: now, Checksum: checksum("hw2", 10)},
+		{Type: SnapshotFile, ID: 1, Name: "syllabus.pdf", UpdatedAt: now, Checksum: checksum("syllabus.pdf")},
+	}}
+	// make sure snapshots survive being saved
+	b, err := json.Marshal(prev)
+	is.NoErr(err)
+	prev = &CourseSnapshot{}
+	is.NoErr(json.Unmarshal(b, prev))
+
+	current := &CourseSnapshot{CourseID: 1, TakenAt: now.Add(time.Hour), Items: []SnapshotItem{
+		{Type: SnapshotAssignment, ID: 1, Name: "hw1", UpdatedAt: now, Checksum: checksum("hw1", 10)},
+		{Type: SnapshotAssignment, ID: 2, Name: "hw2", UpdatedAt: now, Checksum: checksum("hw2", 20)},
+		{Type: SnapshotAnnouncement, ID: 1, Name: "welcome", UpdatedAt: now},
+	}}
+	changes := snapshotChanges(prev, current)
+	is.True(!changes.Empty())
+	is.Equal(len(changes.Added), 1)
+	is.Equal(changes.Added[0].Type, SnapshotAnnouncement)
+	is.Equal(len(changes.Removed), 1)
+	is.Equal(changes.Removed[0].Type, SnapshotFile)
+	is.Equal(len(changes.Modified), 1)
+	is.Equal(changes.Modified[0].ID, 2)
+	is.True(snapshotChanges(current, current).Empty())
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	is := is.New(t)
+	s := store.NewMemory()
+	_, err := LoadSnapshot(s, 1)
+	is.Equal(err, store.ErrNotFound)
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	snap := &CourseSnapshot{CourseID: 1, TakenAt: now, Items: []SnapshotItem{
+		{Type: SnapshotFile, ID: 1, Name: "syllabus.pdf", UpdatedAt: now, Checksum: checksum("syllabus.pdf")},
+	}}
+	is.NoErr(SaveSnapshot(s, snap))
+	loaded, err := LoadSnapshot(s, 1)
+	is.NoErr(err)
+	is.Equal(loaded.CourseID, 1)
+	is.Equal(len(loaded.Items), 1)
+	is.True(loaded.TakenAt.Equal(now))
+	keys, err := s.Keys(store.Snapshots)
+	is.NoErr(err)
+	is.Equal(keys, []string{"1"})
+}
@@ -0,0 +1,69 @@
+package canvas
+
+import "fmt"
+
+// ScoreStatistics are the statistics for the
+// scores of an assignment's submissions.
+type ScoreStatistics struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	LowerQ float64 `json:"lower_q"`
+	Median float64 `json:"median"`
+	UpperQ float64 `json:"upper_q"`
+}
+
+// ScoreStatistics returns the distribution of scores for the assignment.
+// If the assignment was not fetched with IncludeScoreStatistics it is
+// fetched again with them. Canvas only includes them for students so
+// the course analytics are used when they are still missing, which do
+// not have a mean.
+//
+// https://canvas.instructure.com/doc/api/analytics.html#method.analytics_api.course_assignments
+func (a *Assignment) ScoreStatistics() (*ScoreStatistics, error) {
+	if a.Statistics != nil {
+		return a.Statistics, nil
+	}
+	as := &Assignment{}
+	err := getjson(a.client, as, optEnc{AssignmentIncludes(IncludeScoreStatistics)},
+		"/courses/%d/assignments/%d", a.CourseID, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if as.Statistics != nil {
+		a.Statistics = as.Statistics
+		return a.Statistics, nil
+	}
+	stats, err := analyticsScoreStatistics(a.client, a.CourseID, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	a.Statistics = stats
+	return stats, nil
+}
+
+func analyticsScoreStatistics(d doer, courseID, assignmentID int) (*ScoreStatistics, error) {
+	assignments, err := assignmentAnalytics(d, courseID, nil)
+	if err != nil {
+		return nil, err
+	}
+	val := func(f *float64) float64 {
+		if f == nil {
+			return 0
+		}
+		return *f
+	}
+	for _, as := range assignments {
+		if as.AssignmentID != assignmentID {
+			continue
+		}
+		return &ScoreStatistics{
+			Min:    val(as.MinScore),
+			Max:    val(as.MaxScore),
+			LowerQ: val(as.FirstQuartile),
+			Median: val(as.Median),
+			UpperQ: val(as.ThirdQuartile),
+		}, nil
+	}
+	return nil, fmt.Errorf("no statistics found for assignment %d", assignmentID)
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// OpenBolt creates a Store that keeps everything in a single bbolt
+// database file, which is created if it does not exist. Only one
+// process can have the database open at a time, OpenBolt gives up
+// waiting for another process after a second.
+func OpenBolt(path string) (Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+type boltStore struct {
+	db *bolt.DB
+}
+
+func (bs *boltStore) Get(bucket, key string) (value []byte, err error) {
+	err = bs.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return ErrNotFound
+		}
+		v := b.Get([]byte(key))
+		if v == nil {
+			return ErrNotFound
+		}
+		// values are only valid during the transaction
+		value = append([]byte{}, v...)
+		return nil
+	})
+	return value, err
+}
+
+func (bs *boltStore) Put(bucket, key string, value []byte) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), value)
+	})
+}
+
+func (bs *boltStore) Delete(bucket, key string) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(key))
+	})
+}
+
+func (bs *boltStore) Keys(bucket string) ([]string, error) {
+	keys := []string{}
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, _ []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	})
+	// bolt keeps keys in byte order so they are already sorted
+	return keys, err
+}
+
+func (bs *boltStore) Close() error { return bs.db.Close() }
+
+var _ Store = (*boltStore)(nil)
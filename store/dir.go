@@ -0,0 +1,198 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Open creates a Store that keeps each bucket in a sub-directory
+// of dir and each key in its own file. Writes are atomic so the
+// store is never left with half written values.
+//
+// Keys that are too long to be file names are stored in a file named
+// after the key's hash, and the key is saved at the top of the file.
+func Open(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &dirStore{root: dir}, nil
+}
+
+type dirStore struct {
+	mu   sync.RWMutex
+	root string
+}
+
+// maxNameLen is well under the 255 byte file name
+// limit of most file systems.
+const maxNameLen = 200
+
+// hashPrefix starts the names of files with hashed keys, it
+// is always escaped by escape so it cannot collide.
+const hashPrefix = "#"
+
+func (ds *dirStore) path(bucket, key string) (path string, hashed bool) {
+	name := escape(key)
+	if len(name) > maxNameLen {
+		sum := sha256.Sum256([]byte(key))
+		name = hashPrefix + hex.EncodeToString(sum[:])
+		hashed = true
+	}
+	return filepath.Join(ds.root, escape(bucket), name), hashed
+}
+
+// escape turns a key into a file name. Only lower case letters, digits,
+// '-' and '_' are left as they are, everything else is percent encoded so
+// names are safe on every file system, including ones that don't allow
+// ':' or ignore case, and never start with a dot so they can't be
+// confused with temporary files or "." and "..".
+func escape(key string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
+func (ds *dirStore) Get(bucket, key string) ([]byte, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	path, hashed := ds.path(bucket, key)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if hashed {
+		stored, value := splitHashed(b)
+		if stored != key {
+			return nil, ErrNotFound
+		}
+		return value, nil
+	}
+	return b, nil
+}
+
+// splitHashed splits the contents of a file with a hashed
+// name into its key and value.
+func splitHashed(b []byte) (key string, value []byte) {
+	i := bytes.IndexByte(b, '\n')
+	if i < 0 {
+		return "", nil
+	}
+	key, err := url.PathUnescape(string(b[:i]))
+	if err != nil {
+		return "", nil
+	}
+	return key, b[i+1:]
+}
+
+func (ds *dirStore) Put(bucket, key string, value []byte) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	dir := filepath.Join(ds.root, escape(bucket))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	path, hashed := ds.path(bucket, key)
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if hashed {
+		_, err = tmp.WriteString(escape(key) + "\n")
+	}
+	if err == nil {
+		_, err = tmp.Write(value)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (ds *dirStore) Delete(bucket, key string) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	path, _ := ds.path(bucket, key)
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (ds *dirStore) Keys(bucket string) ([]string, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	infos, err := ioutil.ReadDir(filepath.Join(ds.root, escape(bucket)))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		if strings.HasPrefix(info.Name(), hashPrefix) {
+			key, err := readHashedKey(filepath.Join(ds.root, escape(bucket), info.Name()))
+			if err == nil {
+				keys = append(keys, key)
+			}
+			continue
+		}
+		key, err := url.PathUnescape(info.Name())
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// readHashedKey reads only the key from the
+// top of a file with a hashed name.
+func readHashedKey(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return url.PathUnescape(strings.TrimSuffix(line, "\n"))
+}
+
+func (ds *dirStore) Close() error { return nil }
+
+var _ Store = (*dirStore)(nil)
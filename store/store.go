@@ -0,0 +1,119 @@
+// Package store holds the state that needs to outlive a single run of a
+// program using go-canvas, things like file sync manifests and course
+// snapshots.
+//
+// Everything is stored as raw bytes in named buckets. The buckets used by
+// this module are
+//
+//	etags      key: token hash and url       value: json cached response (canvas.WithCache)
+//	manifests  key: absolute sync directory  value: json sync manifest (canvas.SyncManifest)
+//	snapshots  key: course id                value: json canvas.CourseSnapshot (canvas.SaveSnapshot)
+//
+// Three backends are included, an in memory store, a directory store
+// that keeps one file per key, and a bbolt database (OpenBolt). Any other
+// database (SQLite, redis) can be used by implementing the Store interface.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Bucket names used by go-canvas.
+const (
+	ETags     = "etags"
+	Manifests = "manifests"
+	Snapshots = "snapshots"
+)
+
+// ErrNotFound is returned when a key does not exist.
+var ErrNotFound = errors.New("store: key not found")
+
+// Store is a key value store grouped into buckets. Implementations
+// must be safe to use from multiple goroutines.
+type Store interface {
+	// Get returns the value for a key or ErrNotFound.
+	Get(bucket, key string) ([]byte, error)
+	// Put will create or overwrite a key.
+	Put(bucket, key string, value []byte) error
+	// Delete will remove a key, deleting a key that
+	// does not exist is not an error.
+	Delete(bucket, key string) error
+	// Keys returns all the keys in a bucket in sorted order.
+	Keys(bucket string) ([]string, error)
+	Close() error
+}
+
+// GetJSON will get a key and decode it into v.
+func GetJSON(s Store, bucket, key string, v interface{}) error {
+	b, err := s.Get(bucket, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// PutJSON will encode v as json and store it.
+func PutJSON(s Store, bucket, key string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.Put(bucket, key, b)
+}
+
+// NewMemory creates a Store that only keeps values in memory.
+func NewMemory() Store {
+	return &memory{buckets: make(map[string]map[string][]byte)}
+}
+
+type memory struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string][]byte
+}
+
+func (m *memory) Get(bucket, key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.buckets[bucket][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), v...), nil
+}
+
+func (m *memory) Put(bucket, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.buckets[bucket]
+	if !ok {
+		b = make(map[string][]byte)
+		m.buckets[bucket] = b
+	}
+	b[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (m *memory) Delete(bucket, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.buckets[bucket], key)
+	return nil
+}
+
+func (m *memory) Keys(bucket string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]string, 0, len(m.buckets[bucket]))
+	for k := range m.buckets[bucket] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (m *memory) Close() error { return nil }
+
+var _ Store = (*memory)(nil)
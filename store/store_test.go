@@ -0,0 +1,135 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-canvas-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ds, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := OpenBolt(filepath.Join(dir, "store.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, s := range map[string]Store{"memory": NewMemory(), "dir": ds, "bolt": bs} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := s.Get(ETags, "missing"); err != ErrNotFound {
+				t.Errorf("expected ErrNotFound; got %v", err)
+			}
+			long := "https://canvas.com/api/v1/courses/1/files?search_term=" + strings.Repeat("x", 300)
+			keys := []string{"https://canvas.com/api/v1/courses?page=1", ".hidden", "a/b", long}
+			for _, k := range keys {
+				if err := s.Put(ETags, k, []byte("W/"+k)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, k := range keys {
+				v, err := s.Get(ETags, k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(v) != "W/"+k {
+					t.Errorf("wrong value for %q: %q", k, v)
+				}
+			}
+			got, err := s.Keys(ETags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 4 || got[0] != ".hidden" || got[1] != "a/b" || got[2] != long {
+				t.Errorf("wrong keys: %v", got)
+			}
+			if err = s.Delete(ETags, "a/b"); err != nil {
+				t.Fatal(err)
+			}
+			if err = s.Delete(ETags, "a/b"); err != nil {
+				t.Error("deleting a missing key should not fail")
+			}
+			if err = s.Delete(ETags, long); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = s.Get(ETags, long); err != ErrNotFound {
+				t.Errorf("expected ErrNotFound for deleted long key; got %v", err)
+			}
+			if got, _ = s.Keys(ETags); len(got) != 2 {
+				t.Errorf("expected 2 keys; got %v", got)
+			}
+			if got, _ = s.Keys("empty"); len(got) != 0 {
+				t.Errorf("expected no keys; got %v", got)
+			}
+
+			var snap struct{ CourseID int }
+			snap.CourseID = 5
+			if err = PutJSON(s, Snapshots, "5", &snap); err != nil {
+				t.Fatal(err)
+			}
+			snap.CourseID = 0
+			if err = GetJSON(s, Snapshots, "5", &snap); err != nil {
+				t.Fatal(err)
+			}
+			if snap.CourseID != 5 {
+				t.Error("did not decode json value")
+			}
+			if err = s.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDirStoreNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-canvas-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{"C:\\courses", "Key", "key", "a b+c"}
+	for _, k := range keys {
+		if err = s.Put(Manifests, k, []byte(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	infos, err := ioutil.ReadDir(filepath.Join(dir, Manifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// names must not clash on case insensitive file systems
+	seen := make(map[string]bool)
+	for _, info := range infos {
+		name := info.Name()
+		if strings.ContainsAny(name, ":\\ +") {
+			t.Errorf("file name %q was not escaped", name)
+		}
+		if seen[strings.ToLower(name)] {
+			t.Errorf("file name %q differs from another only by case", name)
+		}
+		seen[strings.ToLower(name)] = true
+	}
+	got, err := s.Keys(Manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(keys) {
+		t.Fatalf("wrong keys: %q", got)
+	}
+	for _, k := range keys {
+		if v, err := s.Get(Manifests, k); err != nil || string(v) != k {
+			t.Errorf("wrong value for %q: %q, %v", k, v, err)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// StudentSubmission is one student's submission for one assignment.
+type StudentSubmission struct {
+	// Student only has the ID, SisUserID, and IntegrationID
+	// set when it comes from Course.AllSubmissions.
+	Student    *User
+	SectionID  int
+	Assignment *Assignment
+	Submission *Submission
+}
+
+// AllSubmissions will stream the submissions of every student for every
+// assignment in the course. The pages are fetched concurrently and can be
+// limited with WithPrefetch. Use the "assignment_ids[]" or "student_ids[]"
+// options to narrow down the results. Cancelling the context will stop
+// the listing and close the channel.
+//
+// Errors are passed to the course's error handler, errors caused by
+// cancelling the context are not.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.for_students
+func (c *Course) AllSubmissions(ctx context.Context, opts ...Option) <-chan *StudentSubmission {
+	return streamSubmissions(
+		ctx, c.client, c.id("/courses/%d/students/submissions"),
+		func(ctx context.Context) ([]*Assignment, error) { return c.listAssignments(ctx, nil) },
+		c.errorHandler, opts,
+	)
+}
+
+// streamSubmissions streams the grouped submissions found at path. The
+// assignments are listed first so that each submission can be sent along
+// with its assignment.
+func streamSubmissions(
+	ctx context.Context,
+	d doer,
+	path string,
+	listAssignments func(context.Context) ([]*Assignment, error),
+	errorHandler func(error) error,
+	opts []Option,
+) <-chan *StudentSubmission {
+	ch := make(studentSubmissionChan)
+	handler := func(err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return errorHandler(err)
+	}
+	opts = append([]Option{ArrayOpt("student_ids", "all")}, opts...)
+	opts = append(opts, Opt("grouped", true))
+	go func() {
+		asses, err := listAssignments(ctx)
+		if err != nil && handler(err) != nil {
+			ch.Close()
+			return
+		}
+		assignments := make(map[int]*Assignment, len(asses))
+		for _, a := range asses {
+			assignments[a.ID] = a
+		}
+		pager := newPaginatedList(d, path, sendGroupedSubmissionsFunc(ctx, d, ch, assignments), opts)
+		pager.ctx = ctx
+		handleErrs(pager, ch, handler)
+	}()
+	return ch
+}
+
+type submissionGroup struct {
+	UserID        int           `json:"user_id"`
+	SectionID     int           `json:"section_id"`
+	SisUserID     string        `json:"sis_user_id"`
+	IntegrationID string        `json:"integration_id"`
+	Submissions   []*Submission `json:"submissions"`
+}
+
+func sendGroupedSubmissionsFunc(
+	ctx context.Context,
+	d doer,
+	ch chan *StudentSubmission,
+	assignments map[int]*Assignment,
+) sendFunc {
+	return func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			var group submissionGroup
+			if err := dec.Decode(&group); err != nil {
+				return err
+			}
+			student := &User{
+				ID:            group.UserID,
+				SisUserID:     group.SisUserID,
+				IntegrationID: group.IntegrationID,
+				client:        d,
+			}
+			for _, s := range group.Submissions {
+				a, ok := assignments[s.AssignmentID]
+				if !ok {
+					a = &Assignment{ID: s.AssignmentID, client: d}
+				}
+				select {
+				case ch <- &StudentSubmission{
+					Student:    student,
+					SectionID:  group.SectionID,
+					Assignment: a,
+					Submission: s,
+				}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+	}
+}
+
+type studentSubmissionChan chan *StudentSubmission
+
+func (ssc studentSubmissionChan) Close() {
+	close(ssc)
+}
+
+// Submissions will get the submissions of every student for the assignment.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.index
+func (a *Assignment) Submissions(opts ...Option) ([]*Submission, error) {
+	return collectSubmissions(a.client, a.path("/submissions"), opts)
+}
+
+// UserSubmission will get a user's submission for the assignment.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.show
+func (a *Assignment) UserSubmission(userID int, opts ...Option) (sub *Submission, err error) {
+	sub = &Submission{}
+	return sub, getjson(a.client, sub, optEnc(opts), a.path("/submissions/%d"), userID)
+}
+
+// GradeSubmission will grade a user's submission. The grade can be a number
+// of points, a percentage like "85%", a letter grade, or "pass" and "fail"
+// depending on the assignment's grading type. The comment is only added
+// when it is not empty.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions_api.update
+func (a *Assignment) GradeSubmission(userID int, grade, comment string, opts ...Option) (*Submission, error) {
+	q := params{"submission[posted_grade]": {grade}}
+	if comment != "" {
+		q.Set("comment[text_comment]", comment)
+	}
+	q.Add(opts)
+	resp, err := put(a.client, fmt.Sprintf(a.path("/submissions/%d"), userID), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	sub := &Submission{}
+	return sub, decodeJSON(resp.Body, sub)
+}
+
+// SubmitURL will submit a url to the assignment.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions.create
+func (a *Assignment) SubmitURL(url string, opts ...Option) (*Submission, error) {
+	return a.submit(params{
+		"submission[submission_type]": {"online_url"},
+		"submission[url]":             {url},
+	}, opts)
+}
+
+// SubmitText will submit a text entry to the assignment. The
+// body can contain html.
+//
+// https://canvas.instructure.com/doc/api/submissions.html#method.submissions.create
+func (a *Assignment) SubmitText(body string, opts ...Option) (*Submission, error) {
+	return a.submit(params{
+		"submission[submission_type]": {"online_text_entry"},
+		"submission[body]":            {body},
+	}, opts)
+}
+
+func (a *Assignment) submit(q params, opts []Option) (*Submission, error) {
+	q.Add(opts)
+	resp, err := post(a.client, a.path("/submissions"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	sub := &Submission{}
+	return sub, decodeJSON(resp.Body, sub)
+}
+
+// path returns an api path relative to the assignment.
+func (a *Assignment) path(s string) string {
+	return fmt.Sprintf("/courses/%d/assignments/%d", a.CourseID, a.ID) + s
+}
+
+// Gradeable will stream the assignment's submissions that need to be
+// graded along with the students that made them.
+func (a *Assignment) Gradeable(opts ...Option) <-chan *StudentSubmission {
+	return a.SubmissionsWhere(needsGrading, opts...)
+}
+
+// MissingFor will stream the students that are missing a submission
+// for the assignment, which is useful for sending reminders. Excused
+// students are left out.
+func (a *Assignment) MissingFor(opts ...Option) <-chan *StudentSubmission {
+	return a.SubmissionsWhere(func(s *Submission) bool {
+		return s.Missing && !s.Excused
+	}, opts...)
+}
+
+// SubmissionsWhere will stream the assignment's submissions that match
+// along with the students that made them. Errors are passed to the
+// ConcurrentErrorHandler.
+//
+//	late := a.SubmissionsWhere(func(s *canvas.Submission) bool { return s.Late })
+func (a *Assignment) SubmissionsWhere(match func(*Submission) bool, opts ...Option) <-chan *StudentSubmission {
+	ch := make(studentSubmissionChan)
+	opts = append([]Option{IncludeOpt("user")}, opts...)
+	pager := newPaginatedList(a.client, a.path("/submissions"), func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			var sub struct {
+				Submission
+				User *User `json:"user"`
+			}
+			if err := dec.Decode(&sub); err != nil {
+				return err
+			}
+			if !match(&sub.Submission) {
+				return nil
+			}
+			if sub.User == nil {
+				sub.User = &User{ID: sub.UserID}
+			}
+			sub.User.client = a.client
+			sub.Submission.User = sub.User
+			ch <- &StudentSubmission{
+				Student:    sub.User,
+				Assignment: a,
+				Submission: &sub.Submission,
+			}
+			return nil
+		})
+	}, opts)
+	go handleErrs(pager, ch, ConcurrentErrorHandler)
+	return ch
+}
+
+func needsGrading(s *Submission) bool {
+	switch s.WorkflowState {
+	case "submitted", "pending_review":
+		return true
+	case "graded":
+		// resubmitted after being graded
+		return !s.SubmittedAt.IsZero() && !s.GradeMatchesCurrentSubmission
+	}
+	return false
+}
+
+// SubmissionHistory is an Option that includes every attempt
+// of each submission as Submission.History.
+var SubmissionHistory Option = IncludeOpt("submission_history")
+
+// SubmissionAttempt is one version of a submission.
+type SubmissionAttempt struct {
+	Attempt       int       `json:"attempt"`
+	Type          string    `json:"submission_type"`
+	Body          string    `json:"body"`
+	URL           string    `json:"url"`
+	Attachments   []*File   `json:"attachments"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+	Late          bool      `json:"late"`
+	Grade         string    `json:"grade"`
+	Score         float64   `json:"score"`
+	GraderID      int       `json:"grader_id"`
+	GradedAt      time.Time `json:"graded_at"`
+	WorkflowState string    `json:"workflow_state"`
+}
+
+// Attempts returns every attempt of the submission, oldest first.
+// Without the SubmissionHistory option only the latest attempt is
+// known. Submissions that were never submitted have no attempts.
+func (s *Submission) Attempts() []*SubmissionAttempt {
+	if len(s.History) > 0 {
+		attempts := make([]*SubmissionAttempt, 0, len(s.History))
+		for _, a := range s.History {
+			if !a.SubmittedAt.IsZero() {
+				attempts = append(attempts, a)
+			}
+		}
+		sort.SliceStable(attempts, func(i, j int) bool { return attempts[i].Attempt < attempts[j].Attempt })
+		return attempts
+	}
+	if s.SubmittedAt.IsZero() {
+		return nil
+	}
+	return []*SubmissionAttempt{{
+		Attempt:       s.Attempt,
+		Type:          s.Type,
+		Body:          s.Body,
+		URL:           s.URL,
+		Attachments:   s.Attachments,
+		SubmittedAt:   s.SubmittedAt,
+		Late:          s.Late,
+		Grade:         s.Grade,
+		Score:         s.Score,
+		GraderID:      s.GraderID,
+		GradedAt:      s.GradedAt,
+		WorkflowState: s.WorkflowState,
+	}}
+}
+
+// AttemptsSince returns the attempts that were submitted after t.
+func (s *Submission) AttemptsSince(t time.Time) []*SubmissionAttempt {
+	var attempts []*SubmissionAttempt
+	for _, a := range s.Attempts() {
+		if a.SubmittedAt.After(t) {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts
+}
+
+// ChangedSince returns true if the student submitted
+// a new attempt after t.
+func (s *Submission) ChangedSince(t time.Time) bool {
+	return s.SubmittedAt.After(t) || len(s.AttemptsSince(t)) > 0
+}
+
+// SubmissionsChangedSince will get the assignment's submissions that have
+// a new attempt since t along with their history. Tools that process
+// submissions can save the time of their last run and use
+// Submission.AttemptsSince to only fetch the new attempts.
+func (a *Assignment) SubmissionsChangedSince(t time.Time, opts ...Option) ([]*Submission, error) {
+	subs, err := a.Submissions(append([]Option{SubmissionHistory}, opts...)...)
+	changed := subs[:0]
+	for _, s := range subs {
+		if s.ChangedSince(t) {
+			changed = append(changed, s)
+		}
+	}
+	return changed, err
+}
@@ -0,0 +1,196 @@
+package canvas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestAllSubmissions(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	pages := 3
+	var assignmentRequests int32
+	mux.HandleFunc("/api/v1/courses/1/assignments", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&assignmentRequests, 1)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":1,"name":"one"},{"id":2,"name":"two"}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/1/students/submissions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("grouped") != "true" || q.Get("student_ids[]") != "all" {
+			t.Error("wrong query parameters")
+		}
+		var page int
+		fmt.Sscanf(q.Get("page"), "%d", &page)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/students/submissions?page=%d>; rel="last"`, DefaultHost, pages))
+		fmt.Fprintf(w, `[{"user_id":%d,"section_id":4,"submissions":[
+			{"assignment_id":1,"user_id":%[1]d,"score":1},
+			{"assignment_id":2,"user_id":%[1]d,"score":2}
+		]}]`, page)
+	})
+	course := &Course{ID: 1, client: client, errorHandler: defaultErrorHandler}
+
+	count := 0
+	for s := range course.AllSubmissions(context.Background()) {
+		is.Equal(s.Student.ID, s.Submission.UserID)
+		is.Equal(s.Assignment.ID, s.Submission.AssignmentID)
+		is.True(s.Assignment.Name != "")
+		is.Equal(s.SectionID, 4)
+		count++
+	}
+	is.Equal(count, pages*2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	count = 0
+	for range course.AllSubmissions(ctx, WithPrefetch(1)) {
+		count++
+		cancel()
+	}
+	is.True(count < pages*2)
+
+	// the assignments are not listed once the context is done
+	atomic.StoreInt32(&assignmentRequests, 0)
+	for range course.AllSubmissions(ctx) {
+		t.Error("should not get submissions after the context is cancelled")
+	}
+	is.Equal(atomic.LoadInt32(&assignmentRequests), int32(0))
+}
+
+func TestAssignmentSubmissions(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/submissions", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments/2/submissions?page=1>; rel="last"`, DefaultHost))
+			fmt.Fprint(w, `[{"user_id":7,"score":9},{"user_id":8,"score":10}]`)
+		case "POST":
+			switch q.Get("submission[submission_type]") {
+			case "online_url":
+				if q.Get("submission[url]") != "https://example.com" {
+					t.Error("wrong url")
+				}
+			case "online_text_entry":
+				if q.Get("submission[body]") != "<p>hello</p>" {
+					t.Error("wrong body")
+				}
+			default:
+				t.Error("wrong submission type")
+			}
+			fmt.Fprintf(w, `{"user_id":1,"submission_type":%q}`, q.Get("submission[submission_type]"))
+		}
+	})
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/submissions/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			q := r.URL.Query()
+			if q.Get("submission[posted_grade]") != "85%" || q.Get("comment[text_comment]") != "nice" {
+				t.Error("wrong grade request")
+			}
+			fmt.Fprint(w, `{"user_id":7,"grade":"85%","score":8.5}`)
+			return
+		}
+		fmt.Fprint(w, `{"user_id":7,"score":9}`)
+	})
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	subs, err := a.Submissions()
+	is.NoErr(err)
+	is.Equal(len(subs), 2)
+	sub, err := a.UserSubmission(7)
+	is.NoErr(err)
+	is.Equal(sub.Score, 9.0)
+	sub, err = a.GradeSubmission(7, "85%", "nice")
+	is.NoErr(err)
+	is.Equal(sub.Grade, "85%")
+	sub, err = a.SubmitURL("https://example.com")
+	is.NoErr(err)
+	is.Equal(sub.Type, "online_url")
+	sub, err = a.SubmitText("<p>hello</p>")
+	is.NoErr(err)
+	is.Equal(sub.Type, "online_text_entry")
+}
+
+func TestMissingFor(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/submissions", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include[]") != "user" {
+			t.Error("should include users")
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments/2/submissions?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[
+			{"user_id":7,"workflow_state":"unsubmitted","missing":true,"user":{"id":7,"name":"Kid One"}},
+			{"user_id":8,"workflow_state":"unsubmitted","missing":true,"excused":true},
+			{"user_id":9,"workflow_state":"submitted","submitted_at":"2020-09-01T00:00:00Z"},
+			{"user_id":10,"workflow_state":"graded","submitted_at":"2020-09-01T00:00:00Z","grade_matches_current_submission":false},
+			{"user_id":11,"workflow_state":"graded","submitted_at":"2020-09-01T00:00:00Z","grade_matches_current_submission":true}
+		]`)
+	})
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	var missing []*StudentSubmission
+	for s := range a.MissingFor() {
+		missing = append(missing, s)
+	}
+	is.Equal(len(missing), 1)
+	is.Equal(missing[0].Student.Name, "Kid One")
+	is.Equal(missing[0].Assignment, a)
+
+	var gradeable []int
+	for s := range a.Gradeable() {
+		gradeable = append(gradeable, s.Student.ID)
+	}
+	sort.Ints(gradeable)
+	is.Equal(gradeable, []int{9, 10})
+}
+
+func TestSubmissionHistory(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/courses/1/assignments/2/submissions", func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.URL.Query()["include[]"], []string{"submission_history"})
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/1/assignments/2/submissions?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[
+			{"user_id":7,"attempt":2,"submitted_at":"2026-09-10T00:00:00Z","submission_history":[
+				{"attempt":2,"submitted_at":"2026-09-10T00:00:00Z","attachments":[{"id":12,"display_name":"v2.pdf"}]},
+				{"attempt":1,"submitted_at":"2026-09-01T00:00:00Z","attachments":[{"id":11,"display_name":"v1.pdf"}]}
+			]},
+			{"user_id":8,"attempt":1,"submitted_at":"2026-09-02T00:00:00Z","submission_history":[
+				{"attempt":1,"submitted_at":"2026-09-02T00:00:00Z"}
+			]},
+			{"user_id":9,"submission_history":[{"attempt":null,"submitted_at":null}]}
+		]`)
+	})
+	a := &Assignment{ID: 2, CourseID: 1, client: client}
+	lastRun := time.Date(2026, 9, 5, 0, 0, 0, 0, time.UTC)
+	subs, err := a.SubmissionsChangedSince(lastRun)
+	is.NoErr(err)
+	is.Equal(len(subs), 1)
+	s := subs[0]
+	is.Equal(s.UserID, 7)
+	attempts := s.Attempts()
+	is.Equal(len(attempts), 2)
+	is.Equal(attempts[0].Attempt, 1)
+	is.Equal(attempts[0].Attachments[0].ID, 11)
+	newAttempts := s.AttemptsSince(lastRun)
+	is.Equal(len(newAttempts), 1)
+	is.Equal(newAttempts[0].Attachments[0].ID, 12)
+
+	// without history the latest attempt is used
+	latest := &Submission{Attempt: 3, SubmittedAt: lastRun.Add(time.Hour), Body: "hi"}
+	is.True(latest.ChangedSince(lastRun))
+	is.Equal(latest.Attempts()[0].Body, "hi")
+	is.True(!latest.ChangedSince(lastRun.Add(2 * time.Hour)))
+	is.Equal(len((&Submission{}).Attempts()), 0)
+}
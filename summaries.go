@@ -0,0 +1,59 @@
+package canvas
+
+import "time"
+
+// CourseSummary holds only the fields of a course that are needed to
+// tell courses apart. Decoding large listings into summaries skips the
+// heavy fields like the syllabus, permissions, and enrollments. Use
+// Paginate with a custom struct to pick a different set of fields.
+type CourseSummary struct {
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	CourseCode       string    `json:"course_code"`
+	SisCourseID      string    `json:"sis_course_id"`
+	WorkflowState    string    `json:"workflow_state"`
+	AccountID        int       `json:"account_id"`
+	EnrollmentTermID int       `json:"enrollment_term_id"`
+	StartAt          time.Time `json:"start_at"`
+	EndAt            time.Time `json:"end_at"`
+}
+
+// AssignmentSummary holds only the fields of an assignment that are
+// needed to list assignments. The description, rubric, and overrides
+// are left out.
+type AssignmentSummary struct {
+	ID                int       `json:"id"`
+	Name              string    `json:"name"`
+	CourseID          int       `json:"course_id"`
+	AssignmentGroupID int       `json:"assignment_group_id"`
+	PointsPossible    float64   `json:"points_possible"`
+	DueAt             time.Time `json:"due_at"`
+	Published         bool      `json:"published"`
+	HTMLURL           string    `json:"html_url"`
+}
+
+// CourseSummaries will list summaries of the current user's courses.
+//
+// https://canvas.instructure.com/doc/api/courses.html#method.courses.index
+func (c *Canvas) CourseSummaries(opts ...Option) ([]*CourseSummary, error) {
+	return listAll[*CourseSummary](c.client, "/courses", opts)
+}
+
+// CourseSummaries will list summaries of the account's courses.
+//
+// https://canvas.instructure.com/doc/api/accounts.html#method.accounts.courses_api
+func (a *Account) CourseSummaries(opts ...Option) ([]*CourseSummary, error) {
+	return listAll[*CourseSummary](a.cli, a.path("/courses"), opts)
+}
+
+// AssignmentSummaries will list summaries of the course's assignments.
+//
+// https://canvas.instructure.com/doc/api/assignments.html#method.assignments_api.index
+func (c *Course) AssignmentSummaries(opts ...Option) ([]*AssignmentSummary, error) {
+	return listAll[*AssignmentSummary](c.client, c.id("/courses/%d/assignments"), opts)
+}
+
+// listAll collects every item of a paginated listing in order.
+func listAll[T any](d doer, path string, opts []Option) ([]T, error) {
+	return find(d, path, 0, func(T) bool { return true }, opts)
+}
@@ -0,0 +1,46 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSummaries(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/accounts/1/courses", func(w http.ResponseWriter, r *http.Request) {
+		var page int
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/accounts/1/courses?page=2>; rel="last"`, DefaultHost))
+		fmt.Fprintf(w, `[{"id":%d,"name":"course %[1]d","syllabus_body":"<p>long</p>","permissions":{"create_discussion_topic":true}}]`, page)
+	})
+	mux.HandleFunc("/api/v1/courses/2/assignment_groups", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		is.Equal(q["exclude_response_fields[]"], []string{"description", "rubric"})
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/2/assignment_groups?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":1,"assignments":[{"id":3,"name":"hw"}]}]`)
+	})
+	mux.HandleFunc("/api/v1/courses/2/assignments", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses/2/assignments?page=1>; rel="last"`, DefaultHost))
+		fmt.Fprint(w, `[{"id":3,"name":"hw","course_id":2,"points_possible":10,"description":"<p>long</p>"}]`)
+	})
+
+	a := &Account{ID: 1, cli: client}
+	courses, err := a.CourseSummaries()
+	is.NoErr(err)
+	is.Equal(len(courses), 2)
+	is.Equal(courses[0].ID, 1)
+	is.Equal(courses[1].Name, "course 2")
+
+	c := &Course{ID: 2, client: client}
+	groups, err := c.AssignmentGroups(IncludeOpt("assignments"), ExcludeFields("description", "rubric"))
+	is.NoErr(err)
+	is.Equal(len(groups), 1)
+	asses, err := c.AssignmentSummaries()
+	is.NoErr(err)
+	is.Equal(asses[0].PointsPossible, 10.0)
+}
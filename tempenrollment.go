@@ -0,0 +1,169 @@
+package canvas
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harrybrwn/go-querystring/query"
+)
+
+// TemporaryEnrollment is an enrollment given to a recipient, like a
+// substitute teacher, for a limited time on behalf of a provider who
+// is already enrolled in the course.
+//
+// https://canvas.instructure.com/doc/api/enrollments.html#method.enrollments_api.create
+type TemporaryEnrollment struct {
+	// RecipientID is the user being given the enrollment.
+	RecipientID int            `url:"user_id"`
+	Type        EnrollmentType `url:"type,omitempty"`
+	// ProviderID is the user whose enrollment is being shared.
+	ProviderID int       `url:"temporary_enrollment_source_user_id"`
+	PairingID  int       `url:"temporary_enrollment_pairing_id,omitempty"`
+	StartAt    time.Time `url:"start_at,omitempty"`
+	EndAt      time.Time `url:"end_at,omitempty"`
+	// RoleID is the custom role to use instead of Type.
+	RoleID int `url:"role_id,omitempty"`
+}
+
+type temporaryEnrollmentOptions struct {
+	TemporaryEnrollment `url:"enrollment"`
+}
+
+// EnrollTemporarily will create a temporary enrollment in the course.
+// Canvas ends the enrollment at EndAt.
+func (c *Course) EnrollTemporarily(te TemporaryEnrollment, opts ...Option) (*Enrollment, error) {
+	vals, err := query.Values(&temporaryEnrollmentOptions{te})
+	if err != nil {
+		return nil, err
+	}
+	q := params(vals)
+	q.Add(opts)
+	resp, err := post(c.client, c.id("/courses/%d/enrollments"), q)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	e := &Enrollment{client: c.client}
+	return e, decodeJSON(resp.Body, e)
+}
+
+// TemporaryEnrollmentStatus tells whether a user is part
+// of any temporary enrollments.
+type TemporaryEnrollmentStatus struct {
+	IsProvider  bool `json:"is_provider"`
+	IsRecipient bool `json:"is_recipient"`
+	CanProvide  bool `json:"can_provide"`
+}
+
+// TemporaryEnrollmentStatus will find out if the user is a provider or
+// recipient of temporary enrollments. Use Opt("account_id", id) to check
+// an account other than the root account.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.show_temporary_enrollment_status
+func (u *User) TemporaryEnrollmentStatus(opts ...Option) (*TemporaryEnrollmentStatus, error) {
+	s := &TemporaryEnrollmentStatus{}
+	return s, getjson(u.client, s, optEnc(opts), "/users/%d/temporary_enrollment_status", u.ID)
+}
+
+// TemporaryEnrollmentRecipients will get the temporary
+// enrollments that the user has provided to others.
+func (u *User) TemporaryEnrollmentRecipients(opts ...Option) ([]*Enrollment, error) {
+	return u.ListEnrollments(append(opts, Opt("temporary_enrollment_recipients_for_provider", true))...)
+}
+
+// TemporaryEnrollmentProviders will get the temporary enrollments that the
+// user has been given. Each enrollment includes the users who provided it.
+func (u *User) TemporaryEnrollmentProviders(opts ...Option) ([]*Enrollment, error) {
+	return u.ListEnrollments(append(opts,
+		Opt("temporary_enrollments_for_recipient", true),
+		IncludeOpt("temporary_enrollment_providers"),
+	)...)
+}
+
+// TemporaryEnrollmentPairing groups together the temporary
+// enrollments made for one recipient.
+//
+// https://canvas.instructure.com/doc/api/temporary_enrollment_pairings.html
+type TemporaryEnrollmentPairing struct {
+	ID            int       `json:"id"`
+	RootAccountID int       `json:"root_account_id"`
+	WorkflowState string    `json:"workflow_state"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	CreatedByID   int       `json:"created_by_id"`
+	DeletedByID   int       `json:"deleted_by_id"`
+	// EndingEnrollmentState is the state the recipient's
+	// enrollments are left in when they end.
+	EndingEnrollmentState EnrollmentState `json:"ending_enrollment_state"`
+}
+
+// TemporaryEnrollmentPairings will get the account's
+// temporary enrollment pairings.
+//
+// https://canvas.instructure.com/doc/api/temporary_enrollment_pairings.html#method.temporary_enrollment_pairings_api.index
+func (a *Account) TemporaryEnrollmentPairings(opts ...Option) (pairings []*TemporaryEnrollmentPairing, err error) {
+	ch := make(chan *TemporaryEnrollmentPairing)
+	path := fmt.Sprintf("/accounts/%d/temporary_enrollment_pairings", a.ID)
+	errs := newPaginatedList(a.cli, path, func(r io.Reader) error {
+		return streamArray(r, func(dec decoder) error {
+			p := &TemporaryEnrollmentPairing{}
+			if err := dec.Decode(p); err != nil {
+				return err
+			}
+			ch <- p
+			return nil
+		})
+	}, append([]Option{InOrder}, opts...)).start()
+	var errl []error
+	for {
+		select {
+		case p := <-ch:
+			pairings = append(pairings, p)
+		case err, ok := <-errs:
+			if !ok {
+				return pairings, joinErrs(errl)
+			}
+			errl = append(errl, err)
+		}
+	}
+}
+
+// TemporaryEnrollmentPairing will get a temporary enrollment pairing.
+func (a *Account) TemporaryEnrollmentPairing(id int) (*TemporaryEnrollmentPairing, error) {
+	var res struct {
+		Pairing *TemporaryEnrollmentPairing `json:"temporary_enrollment_pairing"`
+	}
+	return res.Pairing, getjson(a.cli, &res, nil, "/accounts/%d/temporary_enrollment_pairings/%d", a.ID, id)
+}
+
+// CreateTemporaryEnrollmentPairing will create a pairing that temporary
+// enrollments can be added to with TemporaryEnrollment.PairingID. The
+// ending state is the state that the enrollments are left in when they
+// end, either EnrollmentDeleted, EnrollmentCompleted, or EnrollmentInactive.
+//
+// https://canvas.instructure.com/doc/api/temporary_enrollment_pairings.html#method.temporary_enrollment_pairings_api.create
+func (a *Account) CreateTemporaryEnrollmentPairing(ending EnrollmentState) (*TemporaryEnrollmentPairing, error) {
+	var q params
+	if ending != "" {
+		q = params{"ending_enrollment_state": {string(ending)}}
+	}
+	return a.sendPairing("POST", fmt.Sprintf("/accounts/%d/temporary_enrollment_pairings", a.ID), q)
+}
+
+// DeleteTemporaryEnrollmentPairing will delete a temporary enrollment pairing.
+func (a *Account) DeleteTemporaryEnrollmentPairing(id int) (*TemporaryEnrollmentPairing, error) {
+	return a.sendPairing("DELETE", fmt.Sprintf("/accounts/%d/temporary_enrollment_pairings/%d", a.ID, id), nil)
+}
+
+func (a *Account) sendPairing(method, path string, q encoder) (*TemporaryEnrollmentPairing, error) {
+	resp, err := do(a.cli, newreq(method, path, q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res struct {
+		Pairing *TemporaryEnrollmentPairing `json:"temporary_enrollment_pairing"`
+	}
+	return res.Pairing, decodeJSON(resp.Body, &res)
+}
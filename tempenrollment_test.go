@@ -0,0 +1,90 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestTemporaryEnrollments(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	end := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	mux.HandleFunc("/api/v1/accounts/1/temporary_enrollment_pairings", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, `[{"id":4,"workflow_state":"active"}]`)
+		case "POST":
+			if r.URL.Query().Get("ending_enrollment_state") != "completed" {
+				t.Error("wrong ending state")
+			}
+			fmt.Fprint(w, `{"temporary_enrollment_pairing":{"id":5,"ending_enrollment_state":"completed"}}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/accounts/1/temporary_enrollment_pairings/5", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"temporary_enrollment_pairing":{"id":5,"workflow_state":"deleted"}}`)
+	})
+	mux.HandleFunc("/api/v1/courses/2/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, "POST")
+		q := r.URL.Query()
+		if q.Get("enrollment[user_id]") != "8" ||
+			q.Get("enrollment[temporary_enrollment_source_user_id]") != "7" ||
+			q.Get("enrollment[temporary_enrollment_pairing_id]") != "5" ||
+			q.Get("enrollment[type]") != "TeacherEnrollment" ||
+			q.Get("enrollment[end_at]") != end.Format(time.RFC3339) ||
+			q.Has("enrollment[start_at]") ||
+			q.Get("enrollment[notify]") != "true" {
+			t.Errorf("wrong enrollment: %v", q)
+		}
+		fmt.Fprint(w, `{"id":9,"course_id":2,"user_id":8,"temporary_enrollment_source_user_id":7,"temporary_enrollment_pairing_id":5}`)
+	})
+	mux.HandleFunc("/api/v1/users/8/temporary_enrollment_status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"is_provider":false,"is_recipient":true,"can_provide":false}`)
+	})
+	mux.HandleFunc("/api/v1/users/8/enrollments", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("temporary_enrollments_for_recipient") != "true" || q.Get("include[]") != "temporary_enrollment_providers" {
+			t.Errorf("wrong query: %v", q)
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+		fmt.Fprint(w, `[{"id":9,"course_id":2,"temporary_enrollment_providers":[{"id":7}]}]`)
+	})
+
+	a := &Account{ID: 1, cli: client}
+	pairings, err := a.TemporaryEnrollmentPairings()
+	is.NoErr(err)
+	is.Equal(len(pairings), 1)
+	pairing, err := a.CreateTemporaryEnrollmentPairing(EnrollmentCompleted)
+	is.NoErr(err)
+	is.Equal(pairing.ID, 5)
+
+	c := &Course{ID: 2, client: client}
+	e, err := c.EnrollTemporarily(TemporaryEnrollment{
+		RecipientID: 8,
+		ProviderID:  7,
+		PairingID:   pairing.ID,
+		Type:        TeacherEnrollment,
+		EndAt:       end,
+	}, Opt("enrollment[notify]", true))
+	is.NoErr(err)
+	is.Equal(e.TemporaryEnrollmentSourceUserID, 7)
+
+	u := &User{ID: 8, client: client}
+	status, err := u.TemporaryEnrollmentStatus()
+	is.NoErr(err)
+	is.True(status.IsRecipient)
+	enrollments, err := u.TemporaryEnrollmentProviders()
+	is.NoErr(err)
+	is.Equal(len(enrollments), 1)
+	is.Equal(enrollments[0].TemporaryEnrollmentProviders[0].ID, 7)
+
+	pairing, err = a.DeleteTemporaryEnrollmentPairing(pairing.ID)
+	is.NoErr(err)
+	is.Equal(pairing.WorkflowState, "deleted")
+}
@@ -0,0 +1,67 @@
+package canvas
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithTimeout sets a limit on the time taken by every request, which
+// includes connecting, any redirects, and reading the response body.
+// Large file downloads need a timeout long enough to finish. Use the
+// Timeout option to limit a single call instead. It overrides the
+// timeout of a client given with WithClient.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(cc *clientConfig) {
+		cc.timeout = d
+	}
+}
+
+// Timeout is an Option that limits the time taken by each request made
+// by a call, including any retries and reading the response body. For
+// paginated listings every page gets its own timeout. It has no effect
+// on Canvas objects that are not created with New or WithHost.
+//
+//	courses, err := c.Courses(canvas.Timeout(10 * time.Second))
+func Timeout(d time.Duration) Option {
+	return &requestOption{apply: func(r *http.Request) *http.Request {
+		return r.WithContext(context.WithValue(r.Context(), timeoutKey{}, d))
+	}}
+}
+
+type timeoutKey struct{}
+
+func requestTimeout(r *http.Request) time.Duration {
+	d, _ := r.Context().Value(timeoutKey{}).(time.Duration)
+	return d
+}
+
+// withTimeout sends the request with a deadline if it has a timeout.
+// The deadline is released when the response body is closed.
+func withTimeout(r *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	d := requestTimeout(r)
+	if d <= 0 {
+		return send(r)
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), d)
+	resp, err := send(r.WithContext(ctx))
+	if resp == nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, err
+}
+
+// cancelBody cancels a request's context once it is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (cb *cancelBody) Close() error {
+	err := cb.ReadCloser.Close()
+	cb.cancel()
+	return err
+}
@@ -0,0 +1,63 @@
+package canvas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestTimeout(t *testing.T) {
+	is := is.New(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+	mux.HandleFunc("/api/v1/users/1", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	})
+	mux.HandleFunc("/api/v1/users/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2}`)
+	})
+	mux.HandleFunc("/api/v1/courses", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v1/courses?page=2>; rel="last"`, DefaultHost))
+		if r.URL.Query().Get("page") == "2" {
+			time.Sleep(time.Second)
+		}
+		fmt.Fprint(w, `[{"id":1}]`)
+	})
+	c := &Canvas{client: &client{Client: *httpClient}}
+
+	start := time.Now()
+	_, err := c.GetUser(1, Timeout(50*time.Millisecond))
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.True(time.Since(start) < 500*time.Millisecond)
+
+	// the deadline only lasts for the request
+	u, err := c.GetUser(2, Timeout(50*time.Millisecond))
+	is.NoErr(err)
+	is.Equal(u.ID, 2)
+
+	// every page of a listing has a deadline
+	start = time.Now()
+	_, err = c.Courses(Timeout(50 * time.Millisecond))
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.True(time.Since(start) < 500*time.Millisecond)
+
+	conf := newClientConfig([]ClientOption{
+		WithTimeout(time.Minute),
+		WithDialTimeout(time.Second),
+		WithTLSHandshakeTimeout(2 * time.Second),
+		WithResponseHeaderTimeout(3 * time.Second),
+	})
+	is.Equal(conf.baseClient().Timeout, time.Minute)
+	is.True(conf.transport.DialContext != nil)
+	is.Equal(conf.transport.TLSHandshakeTimeout, 2*time.Second)
+	is.Equal(conf.transport.ResponseHeaderTimeout, 3*time.Second)
+}
@@ -1,10 +1,12 @@
 package canvas
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"path"
 	"path/filepath"
+	"strconv"
 	"time"
 )
 
@@ -48,9 +50,12 @@ func (u *User) Courses(opts ...Option) ([]*Course, error) {
 	return getCourses(u.client, u.id("/users/%d/courses"), optEnc(opts))
 }
 
-// FavoriteCourses returns the user's list of favorites courses.
+// FavoriteCourses returns the user's list of favorites courses. Canvas
+// only lists the favorites of the current user.
+//
+// https://canvas.instructure.com/doc/api/favorites.html#method.favorites.list_favorite_courses
 func (u *User) FavoriteCourses(opts ...Option) ([]*Course, error) {
-	return getCourses(u.client, "/users/favorites/courses", optEnc(opts))
+	return getCourses(u.client, "/users/self/favorites/courses", optEnc(opts))
 }
 
 // File will get a user's file by id
@@ -103,9 +108,21 @@ func (u *User) UploadFile(
 	filename string,
 	r io.Reader,
 	opts ...Option,
+) (*File, error) {
+	return u.UploadFileContext(context.Background(), filename, r, opts...)
+}
+
+// UploadFileContext will upload the contents of an io.Reader to a
+// new file in the user's files and will abort the upload if the
+// context is cancelled.
+func (u *User) UploadFileContext(
+	ctx context.Context,
+	filename string,
+	r io.Reader,
+	opts ...Option,
 ) (*File, error) {
 	return uploadFile(
-		u.client, r,
+		ctx, u.client, r,
 		u.id("/users/%d/files"),
 		newFileUploadParams(filename, opts),
 	)
@@ -210,6 +227,10 @@ type Submission struct {
 	WorkflowState                 string      `json:"workflow_state"`
 	ExtraAttempts                 int         `json:"extra_attempts"`
 	AnonymousID                   string      `json:"anonymous_id"`
+	Attachments                   []*File     `json:"attachments" url:"-"`
+	// History is every attempt of the submission, it is only
+	// sent when the SubmissionHistory option is used.
+	History []*SubmissionAttempt `json:"submission_history" url:"-"`
 
 	// Used assignment submission
 	FileIDs          []int  `json:"-" url:"file_ids,omitempty"`
@@ -268,11 +289,89 @@ func (u *User) SetColor(asset, hexcode string) error {
 	return resp.Body.Close()
 }
 
+// DashboardPositions will get the positions of the cards on the user's
+// dashboard, keyed by context code like "course_1" or "group_2".
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.get_dashboard_positions
+func (u *User) DashboardPositions() (map[string]int, error) {
+	var res dashboardPositions
+	if err := getjson(u.client, &res, nil, "/users/%d/dashboard_positions", u.ID); err != nil {
+		return nil, err
+	}
+	return res.Positions, nil
+}
+
+// SetDashboardPositions will move the cards on the user's dashboard. The
+// positions are keyed by context code and cards that are left out keep
+// their position.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.set_dashboard_positions
+func (u *User) SetDashboardPositions(positions map[string]int) error {
+	q := params{}
+	for code, pos := range positions {
+		q.Set(fmt.Sprintf("dashboard_positions[%s]", code), strconv.Itoa(pos))
+	}
+	resp, err := put(u.client, u.id("/users/%d/dashboard_positions"), q)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type dashboardPositions struct {
+	Positions map[string]int `json:"dashboard_positions"`
+}
+
+// Split will undo a merge of users. Each of the users that were
+// merged into this user is restored and all of them are returned,
+// including this user.
+//
+// https://canvas.instructure.com/doc/api/users.html#method.users.split
+func (u *User) Split() ([]*User, error) {
+	resp, err := post(u.client, u.id("/users/%d/split"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var users []*User
+	if err = decodeJSON(resp.Body, &users); err != nil {
+		return nil, err
+	}
+	for _, usr := range users {
+		usr.client = u.client
+	}
+	return users, nil
+}
+
+// DeleteUserFromAccount will remove a user from an account. If the
+// account is a root account then the user's logins and enrollments
+// in the account are removed as well. The deleted user is returned.
+//
+// https://canvas.instructure.com/doc/api/accounts.html#method.accounts.remove_user
+func (c *Canvas) DeleteUserFromAccount(accountID, userID int) (*User, error) {
+	resp, err := delete(c.client, fmt.Sprintf("/accounts/%d/users/%d", accountID, userID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	u := &User{client: c.client}
+	return u, decodeJSON(resp.Body, u)
+}
+
+// DeleteUserFromAccount will remove a user from an account.
+func DeleteUserFromAccount(accountID, userID int) (*User, error) {
+	return ca.DeleteUserFromAccount(accountID, userID)
+}
+
 func getUserFile(d doer, id int, userid interface{}, opts optEnc) (*File, error) {
 	f := &File{client: d}
 	return f, getjson(d, f, opts, "/users/%v/files/%d", userid, id)
 }
 
+func (u *User) setclient(d doer) {
+	u.client = d
+}
+
 func (u *User) id(s string) string {
 	return fmt.Sprintf(s, u.ID)
 }
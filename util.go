@@ -3,6 +3,7 @@ package canvas
 import (
 	"net/url"
 	"path/filepath"
+	"strings"
 )
 
 type params map[string][]string
@@ -13,7 +14,21 @@ type encoder interface {
 
 func (p params) Add(vals []Option) {
 	for _, v := range vals {
-		p[v.Name()] = v.Value()
+		name := v.Name()
+		if name == "" {
+			continue
+		}
+		// array parameters given more than once are combined
+		// without repeating values
+		if strings.HasSuffix(name, "[]") {
+			for _, val := range v.Value() {
+				if !containsString(p[name], val) {
+					p[name] = append(p[name], val)
+				}
+			}
+			continue
+		}
+		p[name] = v.Value()
 	}
 }
 
@@ -0,0 +1,131 @@
+package canvas
+
+import (
+	"errors"
+	"path"
+	"sort"
+
+	"github.com/harrybrwn/errs"
+)
+
+// SkipFolder can be returned from a WalkFunc to skip a folder. When
+// it is returned for a folder, none of the folder's contents are
+// walked and when it is returned for a file, the rest of the files and
+// folders in the file's folder are skipped.
+var SkipFolder = errors.New("skip this folder")
+
+// WalkFunc is called for every file and folder that is walked. Only
+// one of file and folder is not nil. The path is the folder's full
+// name, like "course files/week 1", joined with the file name for
+// files. Returning an error other than SkipFolder stops the walk and
+// the error is returned by Walk.
+type WalkFunc func(path string, file *File, folder *Folder) error
+
+// walkWorkers is the number of folders whose
+// contents are fetched at once while walking.
+const walkWorkers = 4
+
+// Walk will walk the folder tree depth first, calling fn for the
+// folder itself and then for every file and sub-folder in it. Files
+// and folders are walked in order by name. The contents of sub-folders
+// are fetched concurrently ahead of time but fn is only ever called
+// from one goroutine.
+func (f *Folder) Walk(fn WalkFunc) error {
+	w := &walker{fn: fn, sem: make(chan struct{}, walkWorkers)}
+	name := f.FullName
+	if name == "" {
+		name = f.Foldername
+	}
+	err := w.walk(name, f, w.fetch(f))
+	if err == SkipFolder {
+		return nil
+	}
+	return err
+}
+
+// WalkFiles will walk the course's folder tree starting from the root
+// folder. See Folder.Walk.
+func (c *Course) WalkFiles(fn WalkFunc) error {
+	root, err := c.Root()
+	if err != nil {
+		return err
+	}
+	return root.Walk(fn)
+}
+
+type walker struct {
+	fn  WalkFunc
+	sem chan struct{}
+}
+
+type folderContents struct {
+	files   []*File
+	folders []*Folder
+	err     error
+}
+
+// fetch starts getting the files and sub-folders of a folder. The
+// channel is buffered so the fetch finishes even if the walk stops.
+func (w *walker) fetch(f *Folder) <-chan *folderContents {
+	ch := make(chan *folderContents, 1)
+	go func() {
+		w.sem <- struct{}{}
+		defer func() { <-w.sem }()
+		var (
+			fc   folderContents
+			errc = make(chan error, 1)
+		)
+		go func() {
+			var err error
+			fc.folders, err = f.ListFolders()
+			errc <- err
+		}()
+		files, err := f.ListFiles()
+		fc.files = files
+		fc.err = errs.Pair(err, <-errc)
+		for _, sub := range fc.folders {
+			sub.parent = f
+		}
+		sort.SliceStable(fc.files, func(i, j int) bool {
+			return fc.files[i].DisplayName < fc.files[j].DisplayName
+		})
+		sort.SliceStable(fc.folders, func(i, j int) bool {
+			return fc.folders[i].Foldername < fc.folders[j].Foldername
+		})
+		ch <- &fc
+	}()
+	return ch
+}
+
+func (w *walker) walk(name string, f *Folder, contents <-chan *folderContents) error {
+	if err := w.fn(name, nil, f); err != nil {
+		return err
+	}
+	fc := <-contents
+	if fc.err != nil {
+		return fc.err
+	}
+	// start fetching every sub-folder before walking any of them
+	subs := make([]<-chan *folderContents, len(fc.folders))
+	for i, sub := range fc.folders {
+		subs[i] = w.fetch(sub)
+	}
+	for _, file := range fc.files {
+		if err := w.fn(path.Join(name, file.DisplayName), file, nil); err != nil {
+			if err == SkipFolder {
+				return nil
+			}
+			return err
+		}
+	}
+	for i, sub := range fc.folders {
+		err := w.walk(path.Join(name, sub.Foldername), sub, subs[i])
+		if err == SkipFolder {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
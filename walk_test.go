@@ -0,0 +1,75 @@
+package canvas
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWalkFiles(t *testing.T) {
+	is := is.New(t)
+	client, mux, server := testServer()
+	defer server.Close()
+	list := func(path, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=1>; rel="last"`, DefaultHost, r.URL.Path))
+			fmt.Fprint(w, body)
+		})
+	}
+	mux.HandleFunc("/api/v1/courses/1/folders/root", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"name":"course files","full_name":"course files"}`)
+	})
+	list("/api/v1/folders/1/folders", `[{"id":3,"name":"week 2"},{"id":2,"name":"week 1"}]`)
+	list("/api/v1/folders/1/files", `[{"id":10,"display_name":"syllabus.pdf"}]`)
+	list("/api/v1/folders/2/folders", `[{"id":4,"name":"extra"}]`)
+	list("/api/v1/folders/2/files", `[{"id":12,"display_name":"notes.txt"},{"id":11,"display_name":"hw.txt"}]`)
+	list("/api/v1/folders/3/folders", `[]`)
+	list("/api/v1/folders/3/files", `[{"id":13,"display_name":"quiz.txt"}]`)
+	list("/api/v1/folders/4/folders", `[]`)
+	list("/api/v1/folders/4/files", `[{"id":14,"display_name":"bonus.txt"}]`)
+	c := &Course{ID: 1, client: client}
+
+	var paths []string
+	err := c.WalkFiles(func(path string, file *File, folder *Folder) error {
+		if folder != nil {
+			path += "/"
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(strings.Join(paths, "\n"), strings.Join([]string{
+		"course files/",
+		"course files/syllabus.pdf",
+		"course files/week 1/",
+		"course files/week 1/hw.txt",
+		"course files/week 1/notes.txt",
+		"course files/week 1/extra/",
+		"course files/week 1/extra/bonus.txt",
+		"course files/week 2/",
+		"course files/week 2/quiz.txt",
+	}, "\n"))
+
+	paths = nil
+	err = c.WalkFiles(func(path string, file *File, folder *Folder) error {
+		paths = append(paths, path)
+		if folder != nil && folder.Foldername == "week 1" {
+			return SkipFolder
+		}
+		if file != nil && file.ID == 13 {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+	is.Equal(err.Error(), "stop")
+	is.Equal(paths, []string{
+		"course files",
+		"course files/syllabus.pdf",
+		"course files/week 1",
+		"course files/week 2",
+		"course files/week 2/quiz.txt",
+	})
+}